@@ -0,0 +1,343 @@
+package lightning
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// BatchResult is the outcome of one of the calls in a batch.
+type BatchResult struct {
+	Result gjson.Result
+	Err    error
+}
+
+// CallBatch sends all messages at once, over a single socket connection or a
+// single spark request, and returns their results in the same order.
+//
+// The messages are first sent as a JSON-RPC array. If the server doesn't accept
+// that (lightningd doesn't) they are written one after the other in the same
+// connection and the responses are matched by id. Spark servers that don't
+// accept arrays get one request per message.
+//
+// When the client has Interceptors, Metrics, a Cassette or Compat each message
+// goes through them as a separate call, and the ones that reach the transport
+// are sent together as above.
+func (ln *Client) CallBatch(timeout time.Duration, messages ...JSONRPCMessage) []BatchResult {
+	results := make([]BatchResult, len(messages))
+	if len(messages) == 0 {
+		return results
+	}
+
+	if len(ln.Interceptors) == 0 && ln.Metrics == nil && ln.Cassette == nil && !ln.Compat {
+		ln.sendBatch(timeout, messages, results)
+		return results
+	}
+
+	collector := &batchCollector{
+		ln:       ln,
+		deadline: time.Now().Add(timeout),
+		waiting:  len(messages),
+	}
+	var wg sync.WaitGroup
+	for i, message := range messages {
+		wg.Add(1)
+		go func(i int, message JSONRPCMessage) {
+			defer wg.Done()
+			res, err := collector.call(message)
+			if err != nil {
+				results[i] = BatchResult{Err: err}
+			} else {
+				results[i] = BatchResult{Result: gjson.ParseBytes(res)}
+			}
+		}(i, message)
+	}
+	wg.Wait()
+	return results
+}
+
+// sendBatch sends the messages straight to the transport.
+func (ln *Client) sendBatch(timeout time.Duration, messages []JSONRPCMessage, results []BatchResult) {
+	if ln.Path == "" && ln.SparkURL == "" {
+		setAll(results, errors.New("misconfigured client: missing Path or SparkURL."))
+		return
+	}
+
+	batch := make([]JSONRPCMessage, len(messages))
+	index := make(map[string]int, len(messages))
+	for i, message := range messages {
+		message.Id = "batch-" + strconv.Itoa(i)
+		if message.Version == "" {
+			message.Version = version
+		}
+		if message.Params == nil {
+			message.Params = make([]string, 0)
+		}
+		batch[i] = message
+		index[message.Id.(string)] = i
+	}
+
+	if ln.Path != "" {
+		ln.batchSocket(timeout, batch, index, results)
+	} else {
+		ln.batchSpark(timeout, batch, index, results)
+	}
+}
+
+// batchCollector is the transport for the calls of a batch that go through
+// the client's chain. It holds the calls that reach it until every other call
+// has reached it too or returned (like a cassette replay), then sends them all
+// with sendBatch.
+type batchCollector struct {
+	ln       *Client
+	deadline time.Time
+
+	mu      sync.Mutex
+	waiting int // calls that haven't reached the transport or returned
+	queued  []batchedCall
+	sent    bool
+}
+
+type batchedCall struct {
+	message JSONRPCMessage
+	reply   chan BatchResult
+}
+
+func (c *batchCollector) call(message JSONRPCMessage) ([]byte, error) {
+	// counted tells if this call was already subtracted from c.waiting
+	counted := false
+
+	res, err := c.ln.chain(func(timeout time.Duration, message JSONRPCMessage) ([]byte, error) {
+		c.mu.Lock()
+		if counted || c.sent {
+			// an interceptor calling next again, or after the batch was sent
+			c.mu.Unlock()
+			return c.ln.send(timeout, message)
+		}
+		counted = true
+		reply := make(chan BatchResult, 1)
+		c.queued = append(c.queued, batchedCall{message, reply})
+		c.mu.Unlock()
+
+		c.done()
+		r := <-reply
+		if r.Err != nil {
+			return nil, r.Err
+		}
+		return []byte(r.Result.Raw), nil
+	})(time.Until(c.deadline), message)
+
+	c.mu.Lock()
+	returnedEarly := !counted
+	counted = true
+	c.mu.Unlock()
+	if returnedEarly {
+		c.done()
+	}
+	return res, err
+}
+
+// done sends the batch when no other call can still reach the transport.
+func (c *batchCollector) done() {
+	c.mu.Lock()
+	c.waiting--
+	if c.waiting > 0 || c.sent {
+		c.mu.Unlock()
+		return
+	}
+	c.sent = true
+	queued := c.queued
+	c.mu.Unlock()
+
+	if len(queued) == 0 {
+		return
+	}
+	messages := make([]JSONRPCMessage, len(queued))
+	for i, q := range queued {
+		messages[i] = q.message
+	}
+	results := make([]BatchResult, len(queued))
+	c.ln.sendBatch(time.Until(c.deadline), messages, results)
+	for i, q := range queued {
+		q.reply <- results[i]
+	}
+}
+
+func (ln *Client) batchSocket(timeout time.Duration, batch []JSONRPCMessage, index map[string]int, results []BatchResult) {
+	deadline := time.Now().Add(timeout)
+
+	if !ln.noBatchArrays.Load() {
+		array, _ := encodeJSON(batch)
+		values, err := ln.socketExchange(timeout, array, 1)
+		switch err.(type) {
+		case nil:
+			if responses, ok := decodeBatchResponses(values[0]); ok {
+				fillResults(responses, index, results)
+				return
+			}
+			// lightningd answers "Expected {} for json command", don't try
+			// again with this client
+			ln.noBatchArrays.Store(true)
+		case ErrorConnect, ErrorTimeout:
+			setAll(results, err)
+			return
+		}
+		// if the connection broke we don't know, so try the array next time
+	}
+
+	// pipeline the messages in a single connection
+	var pipelined []byte
+	for _, message := range batch {
+		m, _ := encodeJSON(message)
+		pipelined = append(pipelined, m...)
+	}
+	values, err := ln.socketExchange(time.Until(deadline), pipelined, len(batch))
+	responses := make([]JSONRPCResponse, 0, len(values))
+	for _, value := range values {
+		var response JSONRPCResponse
+		if json.Unmarshal(value, &response) == nil {
+			responses = append(responses, response)
+		}
+	}
+	fillResults(responses, index, results)
+	if err != nil {
+		for i := range results {
+			if !results[i].Result.Exists() && results[i].Err == nil {
+				results[i].Err = err
+			}
+		}
+	}
+}
+
+// socketExchange writes payload and reads up to n JSON responses, skipping
+// notifications (messages with a method but no id).
+func (ln *Client) socketExchange(timeout time.Duration, payload []byte, n int) (values []json.RawMessage, err error) {
+	conn, err := net.DialTimeout("unix", ln.Path, timeout)
+	if err != nil {
+		return nil, ErrorConnect{ln.Path, err.Error()}
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	if _, err := conn.Write(payload); err != nil {
+		return nil, ErrorConnectionBroken{}
+	}
+
+	decoder := json.NewDecoder(conn)
+	for len(values) < n {
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			if err == io.EOF {
+				return values, ErrorConnectionBroken{}
+			}
+			if operr, ok := err.(net.Error); ok && operr.Timeout() {
+				return values, ErrorTimeout{int(timeout.Seconds())}
+			}
+			return values, ErrorJSONDecode{err.Error()}
+		}
+
+		if bytes.HasPrefix(bytes.TrimSpace(value), []byte("{")) {
+			id := gjson.GetBytes(value, "id")
+			if gjson.GetBytes(value, "method").Exists() && (!id.Exists() || id.Type == gjson.Null) {
+				continue
+			}
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func (ln *Client) batchSpark(timeout time.Duration, batch []JSONRPCMessage, index map[string]int, results []BatchResult) {
+	deadline := time.Now().Add(timeout)
+
+	if !ln.noBatchArrays.Load() {
+		array, _ := encodeJSON(batch)
+		res, _, err := ln.callSpark(timeout, array)
+		if err == nil {
+			if responses, ok := decodeBatchResponses(res); ok {
+				fillResults(responses, index, results)
+				return
+			}
+		} else if !isInvalidRequest(err) {
+			setAll(results, err)
+			return
+		}
+
+		ln.noBatchArrays.Store(true)
+	}
+
+	for i, message := range batch {
+		res, err := ln.send(time.Until(deadline), message)
+		if err != nil {
+			results[i] = BatchResult{Err: err}
+		} else {
+			results[i] = BatchResult{Result: gjson.ParseBytes(res)}
+		}
+	}
+}
+
+// isInvalidRequest tells if the server refused the array itself.
+func isInvalidRequest(err error) bool {
+	cmderr, ok := err.(ErrorCommand)
+	if !ok {
+		return false
+	}
+	switch cmderr.Code {
+	case -32600, -32601, 400:
+		// invalid request, no such method (spark reads the array as a
+		// message without a method) and bad request
+		return true
+	}
+	return false
+}
+
+func decodeBatchResponses(value []byte) (responses []JSONRPCResponse, ok bool) {
+	if !bytes.HasPrefix(bytes.TrimSpace(value), []byte("[")) {
+		return nil, false
+	}
+	if err := json.Unmarshal(value, &responses); err != nil {
+		return nil, false
+	}
+	return responses, true
+}
+
+func fillResults(responses []JSONRPCResponse, index map[string]int, results []BatchResult) {
+	for _, response := range responses {
+		i, ok := index[fmt.Sprint(response.Id)]
+		if !ok {
+			continue
+		}
+		if response.Error != nil && response.Error.Code != 0 {
+			results[i] = BatchResult{Err: ErrorCommand{response.Error.Message, response.Error.Code, response.Error.Data}}
+		} else {
+			results[i] = BatchResult{Result: gjson.ParseBytes(response.Result)}
+		}
+	}
+	for i := range results {
+		if !results[i].Result.Exists() && results[i].Err == nil {
+			results[i].Err = ErrorJSONDecode{"no response for batch call " + strconv.Itoa(i)}
+		}
+	}
+}
+
+func setAll(results []BatchResult, err error) {
+	for i := range results {
+		results[i] = BatchResult{Err: err}
+	}
+}
+
+func encodeJSON(v interface{}) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	err := encoder.Encode(v)
+	return buffer.Bytes(), err
+}
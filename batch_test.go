@@ -0,0 +1,175 @@
+package lightning_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	lightning "github.com/fiatjaf/lightningd-gjson-rpc"
+	"github.com/fiatjaf/lightningd-gjson-rpc/lightningtest"
+)
+
+func batchMessages() []lightning.JSONRPCMessage {
+	return []lightning.JSONRPCMessage{
+		{Method: "getinfo"},
+		{Method: "listfunds"},
+		{Method: "pay", Params: map[string]interface{}{"bolt11": "lnbc1"}},
+	}
+}
+
+func checkBatchResults(t *testing.T, results []lightning.BatchResult) {
+	t.Helper()
+	if len(results) != 3 {
+		t.Fatalf("%d results", len(results))
+	}
+	if results[0].Err != nil || results[0].Result.Get("id").String() != "02aa" {
+		t.Errorf("getinfo: %s, %v", results[0].Result.Raw, results[0].Err)
+	}
+	if results[1].Err != nil || results[1].Result.Get("outputs.#").Int() != 1 {
+		t.Errorf("listfunds: %s, %v", results[1].Result.Raw, results[1].Err)
+	}
+	if cmderr, ok := results[2].Err.(lightning.ErrorCommand); !ok || cmderr.Code != 210 {
+		t.Errorf("pay: %v", results[2].Err)
+	}
+}
+
+func newBatchServer(t *testing.T) *lightningtest.Server {
+	rpc := lightningtest.NewServer(t)
+	rpc.Respond("getinfo", map[string]interface{}{"id": "02aa"})
+	rpc.Respond("listfunds", map[string]interface{}{"outputs": []interface{}{map[string]interface{}{"amount_msat": 1000}}})
+	rpc.Fail("pay", 210, "Ran out of routes to try")
+	return rpc
+}
+
+// lightningd answers an array with an error, like the fake server, so the
+// messages are pipelined on a new connection.
+func TestCallBatchPipelined(t *testing.T) {
+	rpc := newBatchServer(t)
+	ln := rpc.Client()
+
+	checkBatchResults(t, ln.CallBatch(time.Second*5, batchMessages()...))
+	if n := rpc.Connections(); n != 2 {
+		t.Errorf("%d connections, expected the array attempt and the pipelined one", n)
+	}
+
+	// the client remembers arrays don't work
+	checkBatchResults(t, ln.CallBatch(time.Second*5, batchMessages()...))
+	if n := rpc.Connections(); n != 3 {
+		t.Errorf("%d connections, expected a single one for the second batch", n)
+	}
+	if n := len(rpc.Calls("getinfo")); n != 2 {
+		t.Errorf("getinfo called %d times", n)
+	}
+}
+
+func TestCallBatchSpark(t *testing.T) {
+	rpc := newBatchServer(t)
+	ln := rpc.SparkClient()
+	checkBatchResults(t, ln.CallBatch(time.Second*5, batchMessages()...))
+}
+
+func TestCallBatchThroughChain(t *testing.T) {
+	rpc := newBatchServer(t)
+	ln := rpc.Client()
+	ln.Metrics = lightning.NewMetrics()
+
+	var intercepted int32
+	ln.Use(func(timeout time.Duration, message lightning.JSONRPCMessage, next lightning.Invoker) ([]byte, error) {
+		atomic.AddInt32(&intercepted, 1)
+		if message.Method == "cached" {
+			return []byte(`{"cached":true}`), nil
+		}
+		return next(timeout, message)
+	})
+
+	messages := append(batchMessages(), lightning.JSONRPCMessage{Method: "cached"})
+	results := ln.CallBatch(time.Second*5, messages...)
+	checkBatchResults(t, results[:3])
+	if !results[3].Result.Get("cached").Bool() {
+		t.Errorf("cached: %s, %v", results[3].Result.Raw, results[3].Err)
+	}
+
+	if n := atomic.LoadInt32(&intercepted); n != 4 {
+		t.Errorf("interceptor saw %d calls", n)
+	}
+	if n := rpc.Connections(); n != 2 {
+		t.Errorf("%d connections, expected the array attempt and the pipelined one", n)
+	}
+	if n := len(rpc.Calls("cached")); n != 0 {
+		t.Errorf("short-circuited call reached the server")
+	}
+
+	metrics := &bytes.Buffer{}
+	ln.Metrics.WriteTo(metrics)
+	for _, line := range []string{
+		`lightning_rpc_calls_total{method="getinfo"} 1`,
+		`lightning_rpc_calls_total{method="cached"} 1`,
+		`lightning_rpc_command_errors_total{method="pay",code="210"} 1`,
+	} {
+		if !bytes.Contains(metrics.Bytes(), []byte(line)) {
+			t.Errorf("metrics are missing %s", line)
+		}
+	}
+}
+
+// a connection that breaks doesn't tell if the server takes arrays, so the
+// next batch tries again.
+func TestCallBatchBrokenArrayAttempt(t *testing.T) {
+	dir, err := os.MkdirTemp("", "batch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	listener, err := net.Listen("unix", filepath.Join(dir, "rpc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	var arrays int32
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				decoder := json.NewDecoder(conn)
+				for {
+					var raw json.RawMessage
+					if decoder.Decode(&raw) != nil {
+						return
+					}
+					if raw[0] == '[' {
+						atomic.AddInt32(&arrays, 1)
+						return
+					}
+					var msg lightning.JSONRPCMessage
+					json.Unmarshal(raw, &msg)
+					json.NewEncoder(conn).Encode(lightning.JSONRPCResponse{
+						Version: "2.0",
+						Id:      msg.Id,
+						Result:  json.RawMessage(`{"method":"` + msg.Method + `"}`),
+					})
+				}
+			}()
+		}
+	}()
+
+	ln := &lightning.Client{Path: listener.Addr().String()}
+	for i := 0; i < 2; i++ {
+		results := ln.CallBatch(time.Second*5, lightning.JSONRPCMessage{Method: "getinfo"}, lightning.JSONRPCMessage{Method: "listfunds"})
+		if results[0].Result.Get("method").String() != "getinfo" || results[1].Result.Get("method").String() != "listfunds" {
+			t.Errorf("results: %v", results)
+		}
+	}
+	if n := atomic.LoadInt32(&arrays); n != 2 {
+		t.Errorf("tried arrays %d times, expected on every batch", n)
+	}
+}
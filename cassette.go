@@ -0,0 +1,250 @@
+package lightning
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+type CassetteMode int
+
+const (
+	// CassetteRecord calls the node and writes every request and response to the file.
+	CassetteRecord CassetteMode = iota
+	// CassetteReplay serves responses from the file without talking to any node.
+	CassetteReplay
+)
+
+// Cassette records RPC traffic to a JSONL file and replays it later.
+// Set it as Client.Cassette. Calls are matched on method, params and filter,
+// normalized so key order and spacing don't matter.
+type Cassette struct {
+	Path string
+	Mode CassetteMode
+
+	mu       sync.Mutex
+	file     *os.File
+	writeErr error // the first failed write, returned by Close
+	entries  []CassetteEntry
+	used     []bool
+}
+
+// CassetteEntry is a line in the cassette file.
+type CassetteEntry struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Filter json.RawMessage `json:"filter,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *CassetteError  `json:"error,omitempty"`
+}
+
+// CassetteError keeps enough of the original error so the same type is returned on replay.
+type CassetteError struct {
+	Type    string      `json:"type"`
+	Message string      `json:"message"`
+	Code    int         `json:"code,omitempty"`
+	Data    interface{} `json:"data,omitempty"`
+	Seconds int         `json:"seconds,omitempty"`
+}
+
+// RecordCassette creates (or truncates) the file at path and records to it.
+func RecordCassette(path string) (*Cassette, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Cassette{Path: path, Mode: CassetteRecord, file: file}, nil
+}
+
+// ReplayCassette loads a previously recorded file.
+func ReplayCassette(path string) (*Cassette, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	c := &Cassette{Path: path, Mode: CassetteReplay}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 256*1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry CassetteEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("invalid cassette line %d: %w", len(c.entries)+1, err)
+		}
+		entry.Params = normalizeParams(entry.Params)
+		entry.Filter = normalizeFilter(entry.Filter)
+		c.entries = append(c.entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	c.used = make([]bool, len(c.entries))
+
+	return c, nil
+}
+
+// Close closes the file being recorded. It returns the first error writing
+// to it, as the calls themselves don't fail when recording them does.
+func (c *Cassette) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.file == nil {
+		return c.writeErr
+	}
+	err := c.file.Close()
+	c.file = nil
+	if c.writeErr != nil {
+		return c.writeErr
+	}
+	return err
+}
+
+func (c *Cassette) roundTrip(
+	timeout time.Duration,
+	message JSONRPCMessage,
+	next Invoker,
+) ([]byte, error) {
+	params := normalizeParams(message.Params)
+	filter := normalizeFilter(message.Filter)
+
+	if c.Mode == CassetteReplay {
+		return c.replay(message.Method, params, filter)
+	}
+
+	res, err := next(timeout, message)
+	entry := CassetteEntry{Method: message.Method, Params: params, Filter: filter}
+	if err != nil {
+		entry.Error = cassetteError(err)
+	} else {
+		entry.Result = res
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.file != nil {
+		line, _ := json.Marshal(entry)
+		if _, werr := c.file.Write(append(line, '\n')); werr != nil && c.writeErr == nil {
+			c.writeErr = fmt.Errorf("failed to record %s: %w", message.Method, werr)
+		}
+	}
+
+	return res, err
+}
+
+// replay returns the first unused recording for this call. When all of them
+// were used the last one is repeated, so polling calls keep working.
+func (c *Cassette) replay(method string, params, filter json.RawMessage) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	last := -1
+	for i, entry := range c.entries {
+		if entry.Method != method || string(entry.Params) != string(params) || string(entry.Filter) != string(filter) {
+			continue
+		}
+		last = i
+		if !c.used[i] {
+			break
+		}
+	}
+	if last == -1 {
+		if filter != nil {
+			return nil, fmt.Errorf("cassette %s has no recording for %s %s with filter %s", c.Path, method, params, filter)
+		}
+		return nil, fmt.Errorf("cassette %s has no recording for %s %s", c.Path, method, params)
+	}
+	c.used[last] = true
+
+	entry := c.entries[last]
+	if entry.Error != nil {
+		return nil, entry.Error.err()
+	}
+	return entry.Result, nil
+}
+
+func cassetteError(err error) *CassetteError {
+	ce := &CassetteError{Type: ErrorKind(err), Message: err.Error()}
+	switch e := err.(type) {
+	case ErrorCommand:
+		ce.Message, ce.Code, ce.Data = e.Message, e.Code, e.Data
+	case ErrorTimeout:
+		ce.Seconds = e.Seconds
+	case ErrorConnect:
+		ce.Message, ce.Data = e.Message, e.Path
+	case ErrorJSONDecode:
+		ce.Message = e.Message
+	}
+	return ce
+}
+
+func (e CassetteError) err() error {
+	switch e.Type {
+	case "command":
+		return ErrorCommand{e.Message, e.Code, e.Data}
+	case "timeout":
+		return ErrorTimeout{e.Seconds}
+	case "connect":
+		path, _ := e.Data.(string)
+		return ErrorConnect{path, e.Message}
+	case "broken":
+		return ErrorConnectionBroken{}
+	case "decode":
+		return ErrorJSONDecode{e.Message}
+	default:
+		return fmt.Errorf("%s", e.Message)
+	}
+}
+
+// normalizeParams gives the same JSON for equivalent params: map keys sorted
+// and empty params as [].
+func normalizeParams(params interface{}) json.RawMessage {
+	j, err := json.Marshal(params)
+	if err != nil {
+		return json.RawMessage("[]")
+	}
+
+	var v interface{}
+	if err := decodeUsingNumber(j, &v); err != nil {
+		return json.RawMessage("[]")
+	}
+	switch p := v.(type) {
+	case nil:
+		return json.RawMessage("[]")
+	case []interface{}:
+		if len(p) == 0 {
+			return json.RawMessage("[]")
+		}
+	case map[string]interface{}:
+		if len(p) == 0 {
+			return json.RawMessage("[]")
+		}
+	}
+
+	normalized, _ := json.Marshal(v)
+	return normalized
+}
+
+// normalizeFilter is like normalizeParams, but no filter stays nil.
+func normalizeFilter(filter interface{}) json.RawMessage {
+	if filter == nil {
+		return nil
+	}
+	j, err := json.Marshal(filter)
+	if err != nil {
+		return nil
+	}
+	var v interface{}
+	if err := decodeUsingNumber(j, &v); err != nil || v == nil {
+		return nil
+	}
+	normalized, _ := json.Marshal(v)
+	return normalized
+}
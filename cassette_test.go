@@ -0,0 +1,91 @@
+package lightning_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	lightning "github.com/fiatjaf/lightningd-gjson-rpc"
+	"github.com/fiatjaf/lightningd-gjson-rpc/lightningtest"
+)
+
+func TestCassetteRecordReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "calls.jsonl")
+
+	rpc := lightningtest.NewServer(t)
+	rpc.Respond("getinfo", map[string]interface{}{"id": "02aa", "alias": "alice"})
+	rpc.Respond("listfunds", map[string]interface{}{"outputs": []interface{}{}})
+	rpc.Fail("pay", 210, "Ran out of routes to try")
+
+	recording, err := lightning.RecordCassette(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln := rpc.Client()
+	ln.Cassette = recording
+	if _, err := ln.Call("getinfo"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ln.CallWithFilter(time.Second, []string{"id"}, "getinfo"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ln.CallNamed("listfunds", "spent", true); err != nil {
+		t.Fatal(err)
+	}
+	ln.Call("pay", "lnbc1")
+	if err := recording.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// no server this time
+	replaying, err := lightning.ReplayCassette(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln = &lightning.Client{Path: filepath.Join(t.TempDir(), "nothing"), Cassette: replaying}
+
+	if res, err := ln.Call("getinfo"); err != nil || res.Get("alias").String() != "alice" {
+		t.Errorf("getinfo: %s, %v", res.Raw, err)
+	}
+	if _, err := ln.CallWithFilter(time.Second, []string{"id"}, "getinfo"); err != nil {
+		t.Errorf("getinfo with the recorded filter: %v", err)
+	}
+	if _, err := ln.CallWithFilter(time.Second, []string{"alias"}, "getinfo"); err == nil || !strings.Contains(err.Error(), "no recording") {
+		t.Errorf("getinfo with another filter: %v", err)
+	}
+	if _, err := ln.CallNamed("listfunds", "spent", true); err != nil {
+		t.Errorf("listfunds: %v", err)
+	}
+	if _, err := ln.CallNamed("listfunds", "spent", false); err == nil {
+		t.Error("listfunds with other params was replayed")
+	}
+	if _, err := ln.Call("pay", "lnbc1"); err == nil {
+		t.Error("pay didn't fail")
+	} else if cmderr, ok := err.(lightning.ErrorCommand); !ok || cmderr.Code != 210 {
+		t.Errorf("pay: %v", err)
+	}
+}
+
+func TestCassetteWriteError(t *testing.T) {
+	if _, err := os.Stat("/dev/full"); err != nil {
+		t.Skip("no /dev/full")
+	}
+
+	rpc := lightningtest.NewServer(t)
+	rpc.Respond("getinfo", map[string]interface{}{"id": "02aa"})
+
+	recording, err := lightning.RecordCassette("/dev/full")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln := rpc.Client()
+	ln.Cassette = recording
+	if _, err := ln.Call("getinfo"); err != nil {
+		t.Errorf("the call failed because recording it did: %v", err)
+	}
+	if err := recording.Close(); err == nil || !strings.Contains(err.Error(), "failed to record getinfo") {
+		t.Errorf("Close returned %v", err)
+	}
+}
@@ -4,11 +4,15 @@ import (
 	"bytes"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net"
 	"net/http"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/tidwall/gjson"
@@ -21,6 +25,29 @@ type Client struct {
 	LastInvoiceIndex int
 	CallTimeout      time.Duration
 
+	// Compat makes the client detect the node version (with a single getinfo)
+	// and translate renamed parameters and result fields so callers can always
+	// use the names of the current lightningd version. See compat.go.
+	Compat bool
+
+	// Interceptors wrap every call, see interceptor.go.
+	Interceptors []Interceptor
+
+	// Metrics, if set, counts calls, errors, reconnects and invoices received. See metrics.go.
+	Metrics *Metrics
+
+	// RetryPolicy decides which failed calls are retried and how. Defaults to DefaultRetryPolicy.
+	RetryPolicy *RetryPolicy
+
+	// Cassette, if set, records all calls or replays them from a file. See cassette.go.
+	Cassette *Cassette
+
+	nodeVersion  *NodeVersion
+	versionMutex sync.Mutex
+
+	// set when the server rejected a batch sent as an array, see batch.go.
+	noBatchArrays atomic.Bool
+
 	// lightning-rpc socket
 	Path         string
 	LightningDir string
@@ -31,30 +58,32 @@ type Client struct {
 	DontCheckCertificates bool
 }
 
-// the lowest-level method for a socket client
+// the lowest-level method for a socket client.
+// if onNotification is given notifications are enabled in the connection first
+// and all notifications received before the response are passed to it.
 func (ln *Client) callMessageBytes(
 	timeout time.Duration,
-	retrySequence int,
 	message []byte,
+	id string,
+	onNotification func(Notification),
 ) (res []byte, err error) {
-	conn, err := net.Dial("unix", ln.Path)
+	conn, err := net.DialTimeout("unix", ln.Path, timeout)
 	if err != nil {
-		if retrySequence < 6 {
-			time.Sleep(time.Second * 2 * (time.Duration(retrySequence) + 1))
-			return ln.callMessageBytes(timeout, retrySequence+1, message)
-		} else {
-			err = ErrorConnect{ln.Path, err.Error()}
-			return
-		}
+		err = ErrorConnect{ln.Path, err.Error()}
+		return
 	}
 	defer conn.Close()
 
-	respchan := make(chan []byte)
-	errchan := make(chan error)
+	respchan := make(chan []byte, 1)
+	errchan := make(chan error, 1)
 	go func() {
 		decoder := json.NewDecoder(conn)
 		for {
-			var response JSONRPCResponse
+			var response struct {
+				JSONRPCResponse
+				Method string          `json:"method"`
+				Params json.RawMessage `json:"params"`
+			}
 			err := decoder.Decode(&response)
 			if err == io.EOF {
 				errchan <- ErrorConnectionBroken{}
@@ -62,14 +91,32 @@ func (ln *Client) callMessageBytes(
 			} else if err != nil {
 				errchan <- ErrorJSONDecode{err.Error()}
 				break
-			} else if response.Error != nil && response.Error.Code != 0 {
+			}
+
+			if response.Id == nil && response.Method != "" {
+				// a notification
+				if onNotification != nil {
+					onNotification(Notification{response.Method, gjson.ParseBytes(response.Params)})
+				}
+				continue
+			}
+			if response.Id != nil && fmt.Sprint(response.Id) != id {
+				// the response to some other message, like the one enabling notifications
+				continue
+			}
+
+			if response.Error != nil && response.Error.Code != 0 {
 				errchan <- ErrorCommand{response.Error.Message, response.Error.Code, response.Error.Data}
 				break
 			}
 			respchan <- response.Result
+			break
 		}
 	}()
 
+	if onNotification != nil {
+		conn.Write(enableNotifications)
+	}
 	conn.Write(message)
 
 	select {
@@ -83,8 +130,25 @@ func (ln *Client) callMessageBytes(
 	}
 }
 
-// the lowest-level method for a spark client
-func (ln *Client) callSpark(timeout time.Duration, body []byte) (res []byte, err error) {
+// the lowest-level method for a spark client.
+// delivered is false when the request surely didn't reach the server.
+func (ln *Client) callSpark(timeout time.Duration, body []byte) (res []byte, delivered bool, err error) {
+	resp, delivered, err := ln.sparkRequest(timeout, body)
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, delivered, sparkError(resp)
+	}
+
+	res, err = ioutil.ReadAll(resp.Body)
+	return
+}
+
+// sparkRequest posts the body and returns the response without reading it.
+func (ln *Client) sparkRequest(timeout time.Duration, body []byte) (resp *http.Response, delivered bool, err error) {
 	client := &http.Client{
 		Timeout: timeout,
 		Transport: &http.Transport{
@@ -103,12 +167,18 @@ func (ln *Client) callSpark(timeout time.Duration, body []byte) (res []byte, err
 		err = ErrorConnect{url, err.Error()}
 		return
 	}
+	delivered = true
 	if ln.SparkToken != "" {
 		req.Header.Add("X-Access", ln.SparkToken)
 	}
 
-	resp, err := client.Do(req)
+	resp, err = client.Do(req)
 	if err != nil {
+		var operr *net.OpError
+		if errors.As(err, &operr) && operr.Op == "dial" {
+			delivered = false
+		}
+
 		if strings.Index(err.Error(), "imeout") != -1 {
 			err = ErrorTimeout{int(timeout.Seconds())}
 			return
@@ -118,15 +188,14 @@ func (ln *Client) callSpark(timeout time.Duration, body []byte) (res []byte, err
 		return
 	}
 
-	if resp.StatusCode >= 300 {
-		var sparkerr JSONRPCError
-		err = json.NewDecoder(resp.Body).Decode(&sparkerr)
-		if err != nil {
-			return
-		}
-		return nil, ErrorCommand{sparkerr.Message, sparkerr.Code, sparkerr.Data}
-	}
-
-	res, err = ioutil.ReadAll(resp.Body)
 	return
 }
+
+// sparkError reads the error from a failed spark response.
+func sparkError(resp *http.Response) error {
+	var sparkerr JSONRPCError
+	if err := json.NewDecoder(resp.Body).Decode(&sparkerr); err != nil {
+		return err
+	}
+	return ErrorCommand{sparkerr.Message, sparkerr.Code, sparkerr.Data}
+}
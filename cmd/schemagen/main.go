@@ -0,0 +1,355 @@
+// schemagen reads the JSON schemas lightningd ships in doc/schemas/ and writes
+// typed request/response structs plus one Client method per RPC command.
+//
+// Usage: go run ./cmd/schemagen -schemas testdata/schemas -out rpc_generated.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// words used to split a lightningd command name into a Go identifier,
+// listpeerchannels -> ListPeerChannels. the split with the fewest words wins.
+var words = []string{
+	"abort", "account", "add", "addr", "addresses", "any", "apy", "auto",
+	"backup", "balances", "batching", "bkpr", "blacklist", "block", "bump",
+	"by", "cancel", "channel", "channels", "check", "clean", "close", "closed",
+	"commando", "complete", "config", "configs", "connect", "create", "csv",
+	"custom", "data", "datastore", "decode", "del", "description", "dev",
+	"disable", "discard", "disconnect", "dump", "edit", "emergency", "events",
+	"fee", "fetch", "forget", "forward", "forwards", "fund", "funder", "funds",
+	"get", "gossip", "height", "help", "htlcs", "id", "income", "info", "init",
+	"inputs", "inspect", "invoice", "invoices", "key", "keysend", "list", "log",
+	"make", "message", "msg", "multi", "new", "node", "nodes", "notifications",
+	"offer", "offers", "once", "onion", "open", "outpoint", "output", "parse",
+	"pay", "payment", "pays", "peer", "peers", "ping", "plugin", "preapprove",
+	"prepare", "psbt", "rate", "rates", "recover", "rene", "request",
+	"requests", "reserve", "route", "rune", "runes", "schemas", "secret",
+	"send", "set", "show", "sign", "signed", "splice", "sql", "start",
+	"static", "status", "stop", "store", "transactions", "tx", "unreserve",
+	"update", "upgrade", "usage", "utxo", "version", "wait", "wallet",
+	"withdraw",
+}
+
+// methods whose name is taken by a hand-written method.
+var methodNames = map[string]string{
+	"getroute": "GetRouteTyped",
+}
+
+var scalars = map[string]string{
+	"string":               "string",
+	"pubkey":               "string",
+	"short_channel_id":     "string",
+	"short_channel_id_dir": "string",
+	"hex":                  "string",
+	"hash":                 "string",
+	"secret":               "string",
+	"txid":                 "string",
+	"signature":            "string",
+	"bip340sig":            "string",
+	"point32":              "string",
+	"outpoint":             "string",
+	"feerate":              "string",
+	"msat":                 "Msat",
+	"msat_or_any":          "Msat",
+	"msat_or_all":          "Msat",
+	"sat_or_all":           "string",
+	"sat":                  "uint64",
+	"integer":              "int64",
+	"u64":                  "uint64",
+	"u32":                  "uint32",
+	"u16":                  "uint16",
+	"port":                 "uint16",
+	"u8":                   "uint8",
+	"number":               "float64",
+	"boolean":              "bool",
+}
+
+type field struct {
+	GoName   string
+	JSONName string
+	GoType   string
+	Doc      string
+	Required bool
+}
+
+type structDef struct {
+	Name   string
+	Doc    string
+	Fields []field
+}
+
+type generator struct {
+	structs []structDef
+	names   map[string]bool
+}
+
+func main() {
+	schemas := flag.String("schemas", "testdata/schemas", "directory containing lightning-*.json schemas")
+	out := flag.String("out", "rpc_generated.go", "output file")
+	flag.Parse()
+
+	src, err := generate(*schemas)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generate returns the formatted source for all schemas in the directory.
+func generate(schemas string) ([]byte, error) {
+	files, err := filepath.Glob(filepath.Join(schemas, "lightning-*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	b := &bytes.Buffer{}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		schema := gjson.ParseBytes(data)
+		rpc := schema.Get("rpc").String()
+		if rpc == "" {
+			return nil, fmt.Errorf("%s: missing 'rpc' field", file)
+		}
+
+		g := &generator{names: make(map[string]bool)}
+		g.method(b, rpc, schema)
+		for _, s := range g.structs {
+			writeStruct(b, s)
+		}
+	}
+
+	header := &bytes.Buffer{}
+	fmt.Fprintf(header, "// Code generated by schemagen from %s; DO NOT EDIT.\n\n", filepath.ToSlash(schemas))
+	fmt.Fprintf(header, "package lightning\n\n")
+	if bytes.Contains(b.Bytes(), []byte("json.RawMessage")) {
+		fmt.Fprintf(header, "import (\n\t\"context\"\n\t\"encoding/json\"\n)\n\n")
+	} else {
+		fmt.Fprintf(header, "import \"context\"\n\n")
+	}
+
+	src, err := format.Source(append(header.Bytes(), b.Bytes()...))
+	if err != nil {
+		return nil, fmt.Errorf("generated code doesn't parse: %w", err)
+	}
+	return src, nil
+}
+
+func (g *generator) method(b *bytes.Buffer, rpc string, schema gjson.Result) {
+	name := methodName(rpc)
+	title := strings.TrimSuffix(schema.Get("title").String(), ".")
+
+	hasRequest := len(properties(schema.Get("request"))) > 0
+	if hasRequest {
+		g.object(name+"Request", "are the parameters for `"+rpc+"`.", schema.Get("request"), true)
+	}
+	g.object(name+"Response", "is the result of `"+rpc+"`.", schema.Get("response"), false)
+
+	fmt.Fprintf(b, "// %s calls `%s`: %s.\n", name, rpc, title)
+	if hasRequest {
+		fmt.Fprintf(b, "func (ln *Client) %s(ctx context.Context, req %sRequest) (resp %sResponse, err error) {\n", name, name, name)
+		fmt.Fprintf(b, "\terr = ln.CallTyped(ctx, %q, req, &resp)\n", rpc)
+	} else {
+		fmt.Fprintf(b, "func (ln *Client) %s(ctx context.Context) (resp %sResponse, err error) {\n", name, name)
+		fmt.Fprintf(b, "\terr = ln.CallTyped(ctx, %q, nil, &resp)\n", rpc)
+	}
+	fmt.Fprintf(b, "\treturn\n}\n\n")
+}
+
+// object registers a struct for the given object schema and returns its name.
+func (g *generator) object(name string, doc string, s gjson.Result, isRequest bool) string {
+	if g.names[name] {
+		log.Fatalf("duplicate type name %s", name)
+	}
+	g.names[name] = true
+
+	required := make(map[string]bool)
+	for _, r := range s.Get("required").Array() {
+		required[r.String()] = true
+	}
+
+	// nested types are named after the method, not after the request/response
+	prefix := strings.TrimSuffix(strings.TrimSuffix(name, "Response"), "Request")
+
+	def := structDef{Name: name, Doc: doc}
+	idx := len(g.structs)
+	g.structs = append(g.structs, def)
+
+	for _, prop := range properties(s) {
+		key := prop.key
+		f := field{
+			GoName:   fieldName(key),
+			JSONName: key,
+			Doc:      description(prop.schema),
+			Required: required[key],
+		}
+		f.GoType = g.goType(prefix+fieldName(key), prop.schema, isRequest)
+		if isRequest && !f.Required && !strings.HasPrefix(f.GoType, "[]") && f.GoType != "json.RawMessage" {
+			f.GoType = "*" + f.GoType
+		}
+		if prop.schema.Get("deprecated").Exists() {
+			f.Doc = strings.TrimSpace(f.Doc + "\n\nDeprecated: removed in newer lightningd versions.")
+		}
+		def.Fields = append(def.Fields, f)
+	}
+
+	g.structs[idx] = def
+	return name
+}
+
+func (g *generator) goType(name string, s gjson.Result, isRequest bool) string {
+	t := s.Get("type")
+	if t.IsArray() {
+		return "json.RawMessage"
+	}
+
+	switch t.String() {
+	case "object":
+		if len(properties(s)) == 0 {
+			return "json.RawMessage"
+		}
+		return g.object(name, "", s, isRequest)
+	case "array":
+		return "[]" + g.goType(name, s.Get("items"), isRequest)
+	}
+
+	if gotype, ok := scalars[t.String()]; ok {
+		return gotype
+	}
+	return "json.RawMessage"
+}
+
+type property struct {
+	key    string
+	schema gjson.Result
+}
+
+// properties merges the base properties of an object with the ones declared
+// under allOf/if/then, keeping schema order and the first typed definition.
+func properties(s gjson.Result) (props []property) {
+	index := make(map[string]int)
+	add := func(set gjson.Result) {
+		set.ForEach(func(k, v gjson.Result) bool {
+			if i, ok := index[k.String()]; ok {
+				if !props[i].schema.Get("type").Exists() && v.Get("type").Exists() {
+					props[i].schema = v
+				}
+				return true
+			}
+			index[k.String()] = len(props)
+			props = append(props, property{k.String(), v})
+			return true
+		})
+	}
+
+	add(s.Get("properties"))
+	for _, branch := range s.Get("allOf").Array() {
+		add(branch.Get("then.properties"))
+		add(branch.Get("else.properties"))
+	}
+	return props
+}
+
+func description(s gjson.Result) string {
+	d := s.Get("description")
+	if d.IsArray() {
+		lines := make([]string, 0, len(d.Array()))
+		for _, line := range d.Array() {
+			lines = append(lines, line.String())
+		}
+		return strings.Join(lines, " ")
+	}
+	return d.String()
+}
+
+func writeStruct(b *bytes.Buffer, s structDef) {
+	if s.Doc != "" {
+		fmt.Fprintf(b, "// %s %s\n", s.Name, s.Doc)
+	}
+	fmt.Fprintf(b, "type %s struct {\n", s.Name)
+	for _, f := range s.Fields {
+		if f.Doc != "" {
+			for _, line := range strings.Split(f.Doc, "\n") {
+				fmt.Fprintf(b, "\t%s\n", strings.TrimSpace("// "+line))
+			}
+		}
+		tag := f.JSONName
+		if !f.Required {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(b, "\t%s %s `json:\"%s\"`\n", f.GoName, f.GoType, tag)
+	}
+	fmt.Fprintf(b, "}\n\n")
+}
+
+// methodName turns a command name into a Go identifier, splitting on '-' and
+// '_' first: bkpr-listincome -> BkprListIncome, fundchannel_start -> FundChannelStart.
+func methodName(rpc string) string {
+	if name, ok := methodNames[rpc]; ok {
+		return name
+	}
+	name := ""
+	parts := strings.FieldsFunc(rpc, func(r rune) bool { return r == '_' || r == '-' })
+	for _, part := range parts {
+		for _, word := range split(part) {
+			if word == "id" {
+				name += "ID"
+				continue
+			}
+			name += strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return name
+}
+
+// split breaks a word into the fewest known words, or keeps it whole if it
+// can't be covered by them.
+func split(s string) []string {
+	// best[i] is the shortest split of s[:i]
+	best := make([][]string, len(s)+1)
+	best[0] = []string{}
+	for i := 0; i < len(s); i++ {
+		if best[i] == nil {
+			continue
+		}
+		for _, w := range words {
+			j := i + len(w)
+			if strings.HasPrefix(s[i:], w) && (best[j] == nil || len(best[i])+1 < len(best[j])) {
+				best[j] = append(append([]string{}, best[i]...), w)
+			}
+		}
+	}
+	if best[len(s)] == nil {
+		return []string{s}
+	}
+	return best[len(s)]
+}
+
+func fieldName(key string) string {
+	parts := strings.FieldsFunc(key, func(r rune) bool { return r == '_' || r == '-' })
+	name := ""
+	for _, part := range parts {
+		if part == "id" {
+			name += "ID"
+			continue
+		}
+		name += strings.ToUpper(part[:1]) + part[1:]
+	}
+	return name
+}
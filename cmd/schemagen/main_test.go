@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMethodName(t *testing.T) {
+	for rpc, expected := range map[string]string{
+		"getinfo":                         "GetInfo",
+		"listpeerchannels":                "ListPeerChannels",
+		"sendcustommsg":                   "SendCustomMsg",
+		"waitanyinvoice":                  "WaitAnyInvoice",
+		"fundchannel_start":               "FundChannelStart",
+		"bkpr-listincome":                 "BkprListIncome",
+		"emergencyrecover":                "EmergencyRecover",
+		"renepaystatus":                   "RenePayStatus",
+		"datastoreusage":                  "DatastoreUsage",
+		"bkpr-editdescriptionbypaymentid": "BkprEditDescriptionByPaymentID",
+		"getroute":                        "GetRouteTyped",
+		"xyzzy":                           "Xyzzy",
+	} {
+		if name := methodName(rpc); name != expected {
+			t.Errorf("methodName(%q) = %q, expected %q", rpc, name, expected)
+		}
+	}
+}
+
+// TestGenerated fails when rpc_generated.go is out of date with the schemas
+// or the generator, run `go generate` in the repository root to fix it.
+func TestGenerated(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir("../.."); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	src, err := generate("testdata/schemas")
+	if err != nil {
+		t.Fatal(err)
+	}
+	current, err := os.ReadFile("rpc_generated.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(src, current) {
+		t.Fatal("rpc_generated.go is out of date, run go generate")
+	}
+}
+
+func TestGenerateNamesWithSeparators(t *testing.T) {
+	dir := t.TempDir()
+	for _, rpc := range []string{"bkpr-listincome", "fundchannel_start"} {
+		schema := `{
+			"rpc": "` + rpc + `",
+			"title": "Test",
+			"request": {"required": ["id"], "properties": {"id": {"type": "pubkey"}}},
+			"response": {"properties": {"status": {"type": "string"}}}
+		}`
+		err := os.WriteFile(filepath.Join(dir, "lightning-"+rpc+".json"), []byte(schema), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	src, err := generate(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"func (ln *Client) BkprListIncome(ctx context.Context, req BkprListIncomeRequest)",
+		"func (ln *Client) FundChannelStart(ctx context.Context, req FundChannelStartRequest)",
+		`ln.CallTyped(ctx, "bkpr-listincome", req, &resp)`,
+	} {
+		if !strings.Contains(string(src), expected) {
+			t.Errorf("generated code is missing %q", expected)
+		}
+	}
+}
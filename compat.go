@@ -0,0 +1,391 @@
+package lightning
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NodeVersion is the parsed `version` field from getinfo, like "v23.08.1-modded".
+type NodeVersion struct {
+	Major int
+	Minor int
+	Patch int
+	Raw   string
+}
+
+func ParseNodeVersion(raw string) (v NodeVersion, err error) {
+	v.Raw = raw
+
+	s := strings.TrimPrefix(strings.TrimSpace(raw), "v")
+	s, _, _ = strings.Cut(s, "-")
+
+	parts := strings.Split(s, ".")
+	numbers := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, part := range parts {
+		if i >= len(numbers) {
+			break
+		}
+		// "02rc1" -> 2
+		digits := part
+		if end := strings.IndexFunc(digits, func(r rune) bool { return r < '0' || r > '9' }); end != -1 {
+			digits = digits[:end]
+		}
+		n, err := strconv.Atoi(digits)
+		if err != nil {
+			return v, fmt.Errorf("invalid lightningd version %q", raw)
+		}
+		*numbers[i] = n
+	}
+
+	return v, nil
+}
+
+// Less tells if v is older than major.minor.
+func (v NodeVersion) Less(major, minor int) bool {
+	if v.Major != major {
+		return v.Major < major
+	}
+	return v.Minor < minor
+}
+
+func (v NodeVersion) String() string {
+	return fmt.Sprintf("v%d.%02d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// NodeVersion returns the version of the node, calling getinfo only the first time.
+func (ln *Client) NodeVersion() (NodeVersion, error) {
+	ln.versionMutex.Lock()
+	defer ln.versionMutex.Unlock()
+
+	if ln.nodeVersion != nil {
+		return *ln.nodeVersion, nil
+	}
+
+	timeout := ln.CallTimeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	res, err := ln.send(timeout, JSONRPCMessage{Version: version, Method: "getinfo"})
+	if err != nil {
+		return NodeVersion{}, err
+	}
+
+	var info struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(res, &info); err != nil {
+		return NodeVersion{}, ErrorJSONDecode{err.Error()}
+	}
+
+	v, err := ParseNodeVersion(info.Version)
+	if err != nil {
+		return NodeVersion{}, err
+	}
+	ln.nodeVersion = &v
+	return v, nil
+}
+
+// compatRule describes how a method changed: nodes older than major.minor
+// take the legacy param names and return the legacy result fields.
+type compatRule struct {
+	major, minor int
+	params       map[string]string // modern -> legacy
+	paramFields  []paramFieldRename
+	results      []fieldRename
+}
+
+// paramFieldRename renames a field in the objects of a param that is an array,
+// like the hops of a route.
+type paramFieldRename struct {
+	param    string
+	position int // of the param when they are given by position
+	legacy   string
+	modern   string
+}
+
+type fieldRename struct {
+	path   string // "" for the result object itself, otherwise a field holding an array of objects
+	legacy string
+	modern string
+	scale  int64 // legacy fields in satoshis have to be multiplied by 1000
+}
+
+var compatRules = map[string][]compatRule{
+	"getinfo": {
+		{major: 23, minor: 5, results: []fieldRename{
+			{"", "msatoshi_fees_collected", "fees_collected_msat", 1},
+		}},
+	},
+	"invoice": {
+		{major: 22, minor: 11, params: map[string]string{"amount_msat": "msatoshi"}},
+	},
+	"pay": {
+		{major: 22, minor: 11, params: map[string]string{"amount_msat": "msatoshi"}},
+		{major: 23, minor: 5, results: []fieldRename{
+			{"", "msatoshi", "amount_msat", 1},
+			{"", "msatoshi_sent", "amount_sent_msat", 1},
+		}},
+	},
+	"sendpay": {
+		{
+			major: 22, minor: 11,
+			params:      map[string]string{"amount_msat": "msatoshi"},
+			paramFields: []paramFieldRename{{"route", 0, "msatoshi", "amount_msat"}},
+		},
+	},
+	"listinvoices": {
+		{major: 23, minor: 5, results: []fieldRename{
+			{"invoices", "msatoshi", "amount_msat", 1},
+			{"invoices", "msatoshi_received", "amount_received_msat", 1},
+		}},
+	},
+	"waitanyinvoice": {
+		{major: 23, minor: 5, results: []fieldRename{
+			{"", "msatoshi", "amount_msat", 1},
+			{"", "msatoshi_received", "amount_received_msat", 1},
+		}},
+	},
+	"waitinvoice": {
+		{major: 23, minor: 5, results: []fieldRename{
+			{"", "msatoshi", "amount_msat", 1},
+			{"", "msatoshi_received", "amount_received_msat", 1},
+		}},
+	},
+	"listsendpays": {
+		{major: 23, minor: 5, results: []fieldRename{
+			{"payments", "msatoshi", "amount_msat", 1},
+			{"payments", "msatoshi_sent", "amount_sent_msat", 1},
+		}},
+	},
+	"listpays": {
+		{major: 23, minor: 5, results: []fieldRename{
+			{"pays", "amount_sent_msatoshi", "amount_sent_msat", 1},
+		}},
+	},
+	"listforwards": {
+		{major: 23, minor: 5, results: []fieldRename{
+			{"forwards", "in_msatoshi", "in_msat", 1},
+			{"forwards", "out_msatoshi", "out_msat", 1},
+			{"forwards", "fee", "fee_msat", 1},
+		}},
+	},
+	"listfunds": {
+		{major: 23, minor: 5, results: []fieldRename{
+			{"outputs", "value", "amount_msat", 1000},
+			{"channels", "channel_sat", "our_amount_msat", 1000},
+			{"channels", "channel_total_sat", "amount_msat", 1000},
+		}},
+	},
+	"listchannels": {
+		{major: 23, minor: 5, results: []fieldRename{
+			{"channels", "satoshis", "amount_msat", 1000},
+		}},
+	},
+	"listpeerchannels": {
+		{major: 23, minor: 5, results: []fieldRename{
+			{"channels", "msatoshi_to_us", "to_us_msat", 1},
+			{"channels", "msatoshi_total", "total_msat", 1},
+			{"channels", "spendable_msatoshi", "spendable_msat", 1},
+			{"channels", "receivable_msatoshi", "receivable_msat", 1},
+		}},
+	},
+}
+
+func (ln *Client) callCompat(timeout time.Duration, message JSONRPCMessage, send Invoker) ([]byte, error) {
+	nodeVersion, err := ln.NodeVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	// listpeerchannels only exists since v23.02, before that the channels were inside listpeers
+	emulateListPeerChannels := message.Method == "listpeerchannels" && nodeVersion.Less(23, 2)
+	if emulateListPeerChannels {
+		message.Method = "listpeers"
+	}
+
+	var results []fieldRename
+	for _, rule := range compatRules[message.Method] {
+		if !nodeVersion.Less(rule.major, rule.minor) {
+			continue
+		}
+		if len(rule.paramFields) > 0 {
+			message.Params = renameParamFields(message.Params, rule.paramFields)
+		}
+		if len(rule.params) > 0 {
+			message.Params = renameParams(message.Params, rule.params)
+		}
+		results = append(results, rule.results...)
+	}
+	if emulateListPeerChannels {
+		for _, rule := range compatRules["listpeerchannels"] {
+			results = append(results, rule.results...)
+		}
+	}
+
+	// before v23.05 amounts came as "123msat" strings, now they are plain integers
+	legacyAmounts := nodeVersion.Less(23, 5)
+
+	// a filter would use the current field names, so it can't be applied to renamed fields
+	if len(results) > 0 || emulateListPeerChannels {
+		message.Filter = nil
+	}
+
+	res, err := send(timeout, message)
+	if err != nil || (len(results) == 0 && !emulateListPeerChannels && !legacyAmounts) {
+		return res, err
+	}
+
+	var iresult interface{}
+	if err := decodeUsingNumber(res, &iresult); err != nil {
+		return nil, ErrorJSONDecode{err.Error()}
+	}
+	result, ok := iresult.(map[string]interface{})
+	if !ok {
+		return res, nil
+	}
+
+	if emulateListPeerChannels {
+		result = peersToPeerChannels(result)
+	}
+	for _, rename := range results {
+		renameResult(result, rename)
+	}
+	if legacyAmounts {
+		normalizeAmounts(result)
+	}
+	return json.Marshal(result)
+}
+
+// renameParams only touches named params, positional ones don't change with renames.
+func renameParams(params interface{}, renames map[string]string) interface{} {
+	named, ok := params.(map[string]interface{})
+	if !ok {
+		return params
+	}
+
+	translated := make(map[string]interface{}, len(named))
+	for k, v := range named {
+		if legacy, ok := renames[k]; ok {
+			k = legacy
+		}
+		translated[k] = v
+	}
+	return translated
+}
+
+// renameParamFields goes through JSON so it works on any params, like a []RouteHop.
+func renameParamFields(params interface{}, renames []paramFieldRename) interface{} {
+	j, err := json.Marshal(params)
+	if err != nil {
+		return params
+	}
+	var iparams interface{}
+	if err := decodeUsingNumber(j, &iparams); err != nil {
+		return params
+	}
+
+	for _, rename := range renames {
+		var objects []interface{}
+		switch p := iparams.(type) {
+		case map[string]interface{}:
+			objects, _ = p[rename.param].([]interface{})
+		case []interface{}:
+			if rename.position < len(p) {
+				objects, _ = p[rename.position].([]interface{})
+			}
+		}
+
+		for _, iobj := range objects {
+			obj, ok := iobj.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if v, ok := obj[rename.modern]; ok {
+				delete(obj, rename.modern)
+				obj[rename.legacy] = v
+			}
+		}
+	}
+	return iparams
+}
+
+func renameResult(result map[string]interface{}, rename fieldRename) {
+	objects := []interface{}{result}
+	if rename.path != "" {
+		objects, _ = result[rename.path].([]interface{})
+	}
+
+	for _, iobj := range objects {
+		obj, ok := iobj.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if _, hasModern := obj[rename.modern]; hasModern {
+			continue
+		}
+		legacy, ok := obj[rename.legacy]
+		if !ok {
+			continue
+		}
+
+		if n, ok := legacy.(json.Number); ok && rename.scale != 1 {
+			if v, err := n.Int64(); err == nil {
+				legacy = json.Number(strconv.FormatInt(v*rename.scale, 10))
+			}
+		}
+		obj[rename.modern] = legacy
+	}
+}
+
+// normalizeAmounts turns all "123msat" strings in fields ending in _msat into integers.
+func normalizeAmounts(v interface{}) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, field := range val {
+			if str, ok := field.(string); ok && strings.HasSuffix(k, "_msat") && strings.HasSuffix(str, "msat") {
+				if m, err := ParseMsat(str); err == nil {
+					val[k] = json.Number(strconv.FormatInt(int64(m), 10))
+				}
+				continue
+			}
+			normalizeAmounts(field)
+		}
+	case []interface{}:
+		for _, item := range val {
+			normalizeAmounts(item)
+		}
+	}
+}
+
+// peersToPeerChannels turns a listpeers result into what listpeerchannels returns.
+func peersToPeerChannels(result map[string]interface{}) map[string]interface{} {
+	channels := make([]interface{}, 0)
+	peers, _ := result["peers"].([]interface{})
+	for _, ipeer := range peers {
+		peer, ok := ipeer.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		peerchannels, _ := peer["channels"].([]interface{})
+		for _, ich := range peerchannels {
+			ch, ok := ich.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			ch["peer_id"] = peer["id"]
+			ch["peer_connected"] = peer["connected"]
+			channels = append(channels, ch)
+		}
+	}
+	return map[string]interface{}{"channels": channels}
+}
+
+func decodeUsingNumber(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
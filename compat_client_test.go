@@ -0,0 +1,118 @@
+package lightning_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	lightning "github.com/fiatjaf/lightningd-gjson-rpc"
+	"github.com/fiatjaf/lightningd-gjson-rpc/lightningtest"
+)
+
+// TestCompatFixtures replays responses recorded from each version and checks
+// that a Compat client sees the current field names and formats.
+func TestCompatFixtures(t *testing.T) {
+	for _, version := range []string{"v0.10.2", "v0.12.1", "v23.02.2", "v24.08.1"} {
+		t.Run(version, func(t *testing.T) {
+			rpc := lightningtest.NewServer(t)
+			files, _ := filepath.Glob(filepath.Join("testdata", "compat", version, "*.json"))
+			for _, file := range files {
+				data, err := os.ReadFile(file)
+				if err != nil {
+					t.Fatal(err)
+				}
+				method := strings.TrimSuffix(filepath.Base(file), ".json")
+				rpc.Respond(method, json.RawMessage(data))
+			}
+
+			ln := rpc.Client()
+			ln.Compat = true
+
+			if _, err := ln.CallNamed("invoice", "amount_msat", 1000000, "label", "order-1", "description", "order 1"); err != nil {
+				t.Fatal(err)
+			}
+			params := rpc.Calls("invoice")[0].Params
+			legacy := version == "v0.10.2" || version == "v0.12.1"
+			if params.Get("msatoshi").Exists() != legacy || params.Get("amount_msat").Exists() == legacy {
+				t.Errorf("invoice sent with %s", params.Raw)
+			}
+
+			rpc.Respond("sendpay", map[string]interface{}{"status": "pending"})
+			route := []lightning.RouteHop{{Id: "03864ef025fde8fb587d989186ce6a4a186895ee44a926bfc370e2c366597a3f8f", Channel: "699000x1x0", AmountMsat: 1000, Delay: 9}}
+			if _, err := ln.Call("sendpay", route, "hash"); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := ln.CallNamed("sendpay", "route", route, "payment_hash", "hash"); err != nil {
+				t.Fatal(err)
+			}
+			for i, call := range rpc.Calls("sendpay") {
+				hop := call.Params.Get("0.0")
+				if i == 1 {
+					hop = call.Params.Get("route.0")
+				}
+				amount := "amount_msat"
+				if legacy {
+					amount = "msatoshi"
+				}
+				if hop.Get(amount).Raw != "1000" || len(hop.Map()) != 5 {
+					t.Errorf("sendpay route sent as %s", hop.Raw)
+				}
+			}
+
+			info, err := ln.Call("getinfo")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Get("fees_collected_msat").Raw != "1234" {
+				t.Errorf("getinfo: fees_collected_msat = %s", info.Get("fees_collected_msat").Raw)
+			}
+
+			funds, err := ln.Call("listfunds")
+			if err != nil {
+				t.Fatal(err)
+			}
+			for path, expected := range map[string]string{
+				"outputs.0.amount_msat":      "150000000",
+				"channels.0.our_amount_msat": "400000000",
+				"channels.0.amount_msat":     "1000000000",
+			} {
+				if got := funds.Get(path).Raw; got != expected {
+					t.Errorf("listfunds: %s = %s, expected %s", path, got, expected)
+				}
+			}
+
+			invoice, err := ln.Call("waitanyinvoice", 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if invoice.Get("amount_received_msat").Raw != "1000000" || invoice.Get("amount_msat").Raw != "1000000" {
+				t.Errorf("waitanyinvoice: %s", invoice.Raw)
+			}
+
+			channels, err := ln.Call("listpeerchannels")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n := len(channels.Get("channels").Array()); n != 1 {
+				t.Fatalf("listpeerchannels: %d channels", n)
+			}
+			for path, expected := range map[string]string{
+				"channels.0.peer_id":          `"03864ef025fde8fb587d989186ce6a4a186895ee44a926bfc370e2c366597a3f8f"`,
+				"channels.0.peer_connected":   "true",
+				"channels.0.short_channel_id": `"699000x1x0"`,
+				"channels.0.to_us_msat":       "400000000",
+				"channels.0.spendable_msat":   "390000000",
+			} {
+				if got := channels.Get(path).Raw; got != expected {
+					t.Errorf("listpeerchannels: %s = %s, expected %s", path, got, expected)
+				}
+			}
+			emulated := len(rpc.Calls("listpeerchannels")) == 0
+			if emulated != legacy {
+				t.Errorf("listpeerchannels emulated: %v", emulated)
+			}
+		})
+	}
+}
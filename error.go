@@ -43,3 +43,24 @@ func (j ErrorJSONDecode) Error() string {
 func (c ErrorConnectionBroken) Error() string {
 	return "got an EOF while reading response, it seems the connection is broken"
 }
+
+// ErrorKind names the type of an error returned by a call: "connect",
+// "timeout", "command", "decode", "broken", "other" or "" for nil.
+func ErrorKind(err error) string {
+	switch err.(type) {
+	case nil:
+		return ""
+	case ErrorConnect:
+		return "connect"
+	case ErrorTimeout:
+		return "timeout"
+	case ErrorCommand:
+		return "command"
+	case ErrorJSONDecode:
+		return "decode"
+	case ErrorConnectionBroken:
+		return "broken"
+	default:
+		return "other"
+	}
+}
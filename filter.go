@@ -0,0 +1,75 @@
+package lightning
+
+import (
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// FilterFromPaths builds a filter for lightningd (v23.02+) from gjson-like paths,
+// so only these fields are returned. "#" stands for every item of an array:
+//
+//	FilterFromPaths("channels.#.short_channel_id", "channels.#.source")
+//	// {"channels": [{"short_channel_id": true, "source": true}]}
+func FilterFromPaths(paths ...string) map[string]interface{} {
+	filter := make(map[string]interface{})
+	for _, path := range paths {
+		addFilterPath(filter, strings.Split(path, "."))
+	}
+	return filter
+}
+
+func addFilterPath(node map[string]interface{}, parts []string) {
+	key := parts[0]
+	rest := parts[1:]
+
+	array := len(rest) > 0 && rest[0] == "#"
+	if array {
+		rest = rest[1:]
+	}
+	if len(rest) == 0 {
+		node[key] = true
+		return
+	}
+
+	// get or create the object for the next part
+	var child map[string]interface{}
+	switch existing := node[key].(type) {
+	case bool:
+		// the whole field is already included
+		return
+	case map[string]interface{}:
+		child = existing
+	case []interface{}:
+		child, _ = existing[0].(map[string]interface{})
+	}
+	if child == nil {
+		child = make(map[string]interface{})
+		if array {
+			node[key] = []interface{}{child}
+		} else {
+			node[key] = child
+		}
+	}
+
+	addFilterPath(child, rest)
+}
+
+// CallWithFilter is like CallWithCustomTimeout, but asks lightningd to only
+// return the given fields (see FilterFromPaths). Older nodes ignore the filter
+// and return everything.
+func (ln *Client) CallWithFilter(
+	timeout time.Duration,
+	fields []string,
+	method string,
+	params ...interface{},
+) (gjson.Result, error) {
+	message := JSONRPCMessage{
+		Version: version,
+		Method:  method,
+		Params:  payloadFromParams(params),
+		Filter:  FilterFromPaths(fields...),
+	}
+	return ln.CallMessage(timeout, message)
+}
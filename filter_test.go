@@ -0,0 +1,48 @@
+package lightning_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	lightning "github.com/fiatjaf/lightningd-gjson-rpc"
+	"github.com/fiatjaf/lightningd-gjson-rpc/lightningtest"
+)
+
+func TestFilterFromPaths(t *testing.T) {
+	for expected, paths := range map[string][]string{
+		`{"id":true}`: {"id"},
+		`{"channels":[{"short_channel_id":true,"source":true}]}`: {"channels.#.short_channel_id", "channels.#.source"},
+		`{"our_features":{"init":true},"version":true}`:          {"our_features.init", "version"},
+		`{"channels":[{"source":true}],"our_features":true}`:     {"channels.#.source", "our_features", "our_features.init"},
+		`{"peers":[{"channels":[{"state":true}],"id":true}]}`:    {"peers.#.id", "peers.#.channels.#.state"},
+	} {
+		j, _ := json.Marshal(lightning.FilterFromPaths(paths...))
+		if string(j) != expected {
+			t.Errorf("%v: %s, expected %s", paths, j, expected)
+		}
+	}
+}
+
+func TestCallWithFilterSendsIt(t *testing.T) {
+	rpc := lightningtest.NewServer(t)
+	rpc.Respond("listchannels", map[string]interface{}{"channels": []interface{}{}})
+
+	for name, ln := range map[string]*lightning.Client{"socket": rpc.Client(), "spark": rpc.SparkClient()} {
+		before := len(rpc.Calls("listchannels"))
+		if _, err := ln.CallWithFilter(time.Second*5, []string{"channels.#.source"}, "listchannels"); err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+		if _, err := ln.Call("listchannels"); err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+
+		calls := rpc.Calls("listchannels")[before:]
+		if filter := calls[0].Filter.Raw; filter != `{"channels":[{"source":true}]}` {
+			t.Errorf("%s: sent filter %s", name, filter)
+		}
+		if calls[1].Filter.Exists() {
+			t.Errorf("%s: sent filter %s without asking for one", name, calls[1].Filter.Raw)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package lightning
+
+import (
+	"time"
+)
+
+// Invoker performs a call and returns the raw result.
+type Invoker func(timeout time.Duration, message JSONRPCMessage) ([]byte, error)
+
+// Interceptor wraps every call made through CallMessageRaw. It can inspect or
+// rewrite the message, call next zero or more times and change the result.
+// Use ErrorKind to tell the errors apart.
+type Interceptor func(timeout time.Duration, message JSONRPCMessage, next Invoker) ([]byte, error)
+
+// Use appends interceptors to the chain. The first one added is the outermost.
+func (ln *Client) Use(interceptors ...Interceptor) {
+	ln.Interceptors = append(ln.Interceptors, interceptors...)
+}
+
+// invoker builds the chain: metrics -> interceptors -> cassette -> compat -> transport.
+func (ln *Client) invoker() Invoker {
+	return ln.chain(ln.send)
+}
+
+// chain builds the chain around the given transport.
+func (ln *Client) chain(transport Invoker) Invoker {
+	next := transport
+	if ln.Compat {
+		next = func(timeout time.Duration, message JSONRPCMessage) ([]byte, error) {
+			return ln.callCompat(timeout, message, transport)
+		}
+	}
+	if ln.Cassette != nil {
+		cassette, inner := ln.Cassette, next
+		next = func(timeout time.Duration, message JSONRPCMessage) ([]byte, error) {
+			return cassette.roundTrip(timeout, message, inner)
+		}
+	}
+	for i := len(ln.Interceptors) - 1; i >= 0; i-- {
+		interceptor, inner := ln.Interceptors[i], next
+		next = func(timeout time.Duration, message JSONRPCMessage) ([]byte, error) {
+			return interceptor(timeout, message, inner)
+		}
+	}
+	if ln.Metrics != nil {
+		interceptor, inner := ln.Metrics.Interceptor(), next
+		next = func(timeout time.Duration, message JSONRPCMessage) ([]byte, error) {
+			return interceptor(timeout, message, inner)
+		}
+	}
+	return next
+}
+
+// LoggingInterceptor logs every call with its duration and, if it failed, the kind of error.
+func LoggingInterceptor(logf func(string, ...interface{})) Interceptor {
+	return func(timeout time.Duration, message JSONRPCMessage, next Invoker) ([]byte, error) {
+		start := time.Now()
+		res, err := next(timeout, message)
+		if err != nil {
+			logf("%s failed after %s (%s): %s", message.Method, time.Since(start), ErrorKind(err), err)
+		} else {
+			logf("%s took %s", message.Method, time.Since(start))
+		}
+		return res, err
+	}
+}
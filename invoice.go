@@ -20,7 +20,7 @@ const DESCRIPTION_HASH_DESCRIPTION_PREFIX = "with description_hash: "
 
 func (ln *Client) InvoiceWithDescriptionHash(
 	label string,
-	msatoshi int64,
+	amount Msat,
 	descriptionHash []byte,
 	ppreimage *[]byte,
 	pexpiry *time.Duration,
@@ -42,7 +42,7 @@ func (ln *Client) InvoiceWithDescriptionHash(
 	// we won't expose this, but it will still get paid
 	params := map[string]interface{}{
 		"label":       label,
-		"msatoshi":    msatoshi,
+		"amount_msat": amount,
 		"preimage":    hex.EncodeToString(preimage),
 		"description": DESCRIPTION_HASH_DESCRIPTION_PREFIX + description_hash,
 	}
@@ -103,7 +103,7 @@ func (ln *Client) TranslateInvoiceWithDescriptionHash(bolt11 string) (string, er
 }
 
 func (ln *Client) InvoiceWithShadowRoute(
-	msatoshi int64,
+	amount Msat,
 	descriptionOrHash interface{}, /* can be either a string (description) or a []byte (description_hash) */
 	ppreimage *[]byte,
 	pprivateKey **btcec.PrivateKey,
@@ -157,8 +157,8 @@ func (ln *Client) InvoiceWithShadowRoute(
 	}
 
 	// set amount if not zero
-	if msatoshi > 0 {
-		params = append(params, zpay32.Amount(lnwire.MilliSatoshi(msatoshi)))
+	if amount > 0 {
+		params = append(params, zpay32.Amount(lnwire.MilliSatoshi(amount)))
 	}
 
 	// set the shadow route hint with the public key of our real node
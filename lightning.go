@@ -51,35 +51,33 @@ func (ln *Client) CallWithCustomTimeout(
 	method string,
 	params ...interface{},
 ) (gjson.Result, error) {
-	var payload interface{}
-	var sparams []interface{}
+	message := JSONRPCMessage{
+		Version: version,
+		Method:  method,
+		Params:  payloadFromParams(params),
+	}
 
+	return ln.CallMessage(timeout, message)
+}
+
+// payloadFromParams turns variadic params into the JSON-RPC params: a single map
+// is sent as named params, anything else as a positional array.
+func payloadFromParams(params []interface{}) interface{} {
 	if params == nil {
-		payload = make([]string, 0)
-		goto gotpayload
+		return make([]string, 0)
 	}
 
 	if len(params) == 1 {
 		if named, ok := params[0].(map[string]interface{}); ok {
-			payload = named
-			goto gotpayload
+			return named
 		}
 	}
 
-	sparams = make([]interface{}, len(params))
+	sparams := make([]interface{}, len(params))
 	for i, iparam := range params {
 		sparams[i] = iparam
 	}
-	payload = sparams
-
-gotpayload:
-	message := JSONRPCMessage{
-		Version: version,
-		Method:  method,
-		Params:  payload,
-	}
-
-	return ln.CallMessage(timeout, message)
+	return sparams
 }
 
 func (ln *Client) CallMessage(timeout time.Duration, message JSONRPCMessage) (gjson.Result, error) {
@@ -91,6 +89,11 @@ func (ln *Client) CallMessage(timeout time.Duration, message JSONRPCMessage) (gj
 }
 
 func (ln *Client) CallMessageRaw(timeout time.Duration, message JSONRPCMessage) ([]byte, error) {
+	return ln.invoker()(timeout, message)
+}
+
+// send encodes the message and hands it to the configured transport
+func (ln *Client) send(timeout time.Duration, message JSONRPCMessage) ([]byte, error) {
 	message.Id = "0"
 	if message.Params == nil {
 		message.Params = make([]string, 0)
@@ -103,10 +106,16 @@ func (ln *Client) CallMessageRaw(timeout time.Duration, message JSONRPCMessage)
 
 	if ln.Path != "" {
 		// it's a socket client
-		return ln.callMessageBytes(timeout, 0, mbytes)
+		return ln.withRetries(timeout, message.Method, func(timeout time.Duration) ([]byte, bool, error) {
+			res, err := ln.callMessageBytes(timeout, mbytes, "0", message.OnNotification)
+			_, dialFailed := err.(ErrorConnect)
+			return res, !dialFailed, err
+		})
 	} else if ln.SparkURL != "" {
 		// it's a spark client
-		return ln.callSpark(timeout, mbytes)
+		return ln.withRetries(timeout, message.Method, func(timeout time.Duration) ([]byte, bool, error) {
+			return ln.callSpark(timeout, mbytes)
+		})
 	} else {
 		return nil, errors.New("misconfigured client: missing Path or SparkURL.")
 	}
@@ -119,6 +128,13 @@ type JSONRPCMessage struct {
 	Id      interface{} `json:"id"`
 	Method  string      `json:"method"`
 	Params  interface{} `json:"params"`
+
+	// Filter trims the result to the given fields, see FilterFromPaths.
+	Filter interface{} `json:"filter,omitempty"`
+
+	// OnNotification, if set, gets the notifications lightningd sends while
+	// running this command. Only socket clients receive them.
+	OnNotification func(Notification) `json:"-"`
 }
 
 type JSONRPCResponse struct {
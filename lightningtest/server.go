@@ -0,0 +1,417 @@
+// Package lightningtest provides a fake lightningd that speaks JSON-RPC over a
+// unix socket (like lightning-rpc) and over HTTP (like spark/sparko), so code
+// that uses lightning.Client can be tested without a real node.
+package lightningtest
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	lightning "github.com/fiatjaf/lightningd-gjson-rpc"
+	"github.com/tidwall/gjson"
+)
+
+// Handler answers a call. params is the raw params array or object.
+// Returning a lightning.ErrorCommand makes the server reply with that code and message.
+type Handler func(params gjson.Result) (result interface{}, err error)
+
+type Server struct {
+	// Path is the unix socket to be used as lightning.Client.Path.
+	Path string
+	// URL is the HTTP endpoint to be used as lightning.Client.SparkURL.
+	URL string
+	// SparkToken, if set, is required in the X-Access header of HTTP calls.
+	SparkToken string
+
+	mu       sync.Mutex
+	handlers map[string]Handler
+	delays   map[string]time.Duration
+	hangups  map[string]bool
+	notifies map[string][]Notification
+	calls    []Call
+	conns    int
+
+	// invoices emitted with PayInvoice, served by waitanyinvoice
+	paid       []gjson.Result
+	paidSignal chan struct{}
+	closed     chan struct{}
+	closeOnce  sync.Once
+
+	listener net.Listener
+	http     *httptest.Server
+}
+
+// Call is a request received by the server.
+type Call struct {
+	Method string
+	Params gjson.Result
+	Filter gjson.Result // doesn't exist if the request had no filter
+	Time   time.Time
+}
+
+// Notification is sent before the response on socket connections that enabled
+// notifications. The id of the request is added to Params.
+type Notification struct {
+	Method string
+	Params map[string]interface{}
+}
+
+// NewServer starts both the unix socket and the HTTP server. They are closed
+// automatically when the test ends.
+func NewServer(t testing.TB) *Server {
+	// not t.TempDir(), it has the test name and unix socket paths are limited
+	// to 104 bytes on macOS
+	dir, err := os.MkdirTemp("", "ln")
+	if err != nil {
+		t.Fatalf("failed to create a directory for the socket: %s", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	s := &Server{
+		Path:       filepath.Join(dir, "lightning-rpc"),
+		handlers:   make(map[string]Handler),
+		delays:     make(map[string]time.Duration),
+		hangups:    make(map[string]bool),
+		notifies:   make(map[string][]Notification),
+		paidSignal: make(chan struct{}),
+		closed:     make(chan struct{}),
+	}
+	s.handlers["waitanyinvoice"] = s.waitanyinvoice
+	s.handlers["notifications"] = func(gjson.Result) (interface{}, error) {
+		return map[string]interface{}{}, nil
+	}
+
+	listener, err := net.Listen("unix", s.Path)
+	if err != nil {
+		t.Fatalf("failed to listen on %s: %s", s.Path, err)
+	}
+	s.listener = listener
+	go s.acceptLoop()
+
+	s.http = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.http.URL + "/rpc"
+
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Client returns a client connected to the unix socket.
+func (s *Server) Client() *lightning.Client {
+	return &lightning.Client{Path: s.Path}
+}
+
+// SparkClient returns a client connected to the HTTP endpoint.
+func (s *Server) SparkClient() *lightning.Client {
+	return &lightning.Client{SparkURL: s.URL, SparkToken: s.SparkToken}
+}
+
+func (s *Server) Close() {
+	s.closeOnce.Do(func() {
+		close(s.closed)
+		s.listener.Close()
+		s.http.Close()
+	})
+}
+
+// Handle sets the handler for a method, replacing any previous one.
+func (s *Server) Handle(method string, handler Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[method] = handler
+}
+
+// Respond makes the server always reply to method with the given result.
+func (s *Server) Respond(method string, result interface{}) {
+	s.Handle(method, func(gjson.Result) (interface{}, error) { return result, nil })
+}
+
+// Fail makes the server always reply to method with the given error.
+func (s *Server) Fail(method string, code int, message string) {
+	s.Handle(method, func(gjson.Result) (interface{}, error) {
+		return nil, lightning.ErrorCommand{Message: message, Code: code}
+	})
+}
+
+// Delay makes the server wait before answering method.
+func (s *Server) Delay(method string, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.delays[method] = d
+}
+
+// HangUp makes the server close the connection without answering method,
+// which the client sees as an EOF.
+func (s *Server) HangUp(method string, hangup bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hangups[method] = hangup
+}
+
+// Notify makes the server send notifications (like "message" or "progress")
+// while handling method, to socket clients that enabled them.
+func (s *Server) Notify(method string, notifications ...Notification) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.notifies[method] = notifications
+}
+
+// Calls returns all calls received so far, or only the ones for a method if given.
+func (s *Server) Calls(method string) []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	calls := make([]Call, 0, len(s.calls))
+	for _, call := range s.calls {
+		if method == "" || call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Connections returns how many socket connections were accepted so far.
+func (s *Server) Connections() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conns
+}
+
+// PayInvoice marks an invoice as paid so it will be returned by waitanyinvoice.
+// If it doesn't have a pay_index the next one is assigned. It returns the
+// invoice as it will be served.
+func (s *Server) PayInvoice(invoice map[string]interface{}) gjson.Result {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	inv := make(map[string]interface{}, len(invoice)+3)
+	for k, v := range invoice {
+		inv[k] = v
+	}
+	if _, ok := inv["pay_index"]; !ok {
+		inv["pay_index"] = len(s.paid) + 1
+	}
+	if _, ok := inv["status"]; !ok {
+		inv["status"] = "paid"
+	}
+	if _, ok := inv["paid_at"]; !ok {
+		inv["paid_at"] = time.Now().Unix()
+	}
+
+	j, _ := json.Marshal(inv)
+	res := gjson.ParseBytes(j)
+	s.paid = append(s.paid, res)
+
+	// wake up everybody waiting
+	close(s.paidSignal)
+	s.paidSignal = make(chan struct{})
+
+	return res
+}
+
+func (s *Server) waitanyinvoice(params gjson.Result) (interface{}, error) {
+	lastpayindex := params.Get("lastpay_index").Int()
+	timeout := params.Get("timeout")
+	if params.IsArray() {
+		lastpayindex = params.Get("0").Int()
+		timeout = params.Get("1")
+	}
+
+	var expired <-chan time.Time
+	if timeout.Exists() {
+		expired = time.After(time.Duration(timeout.Int()) * time.Second)
+	}
+
+	for {
+		s.mu.Lock()
+		for _, inv := range s.paid {
+			if inv.Get("pay_index").Int() > lastpayindex {
+				s.mu.Unlock()
+				return json.RawMessage(inv.Raw), nil
+			}
+		}
+		signal := s.paidSignal
+		s.mu.Unlock()
+
+		select {
+		case <-signal:
+		case <-expired:
+			return nil, lightning.ErrorCommand{Message: "Timed out", Code: 904}
+		case <-s.closed:
+			return nil, errClosed
+		}
+	}
+}
+
+var (
+	// errHangUp means the connection should be closed without an answer.
+	errHangUp = errors.New("hang up")
+	errClosed = errors.New("server closed")
+)
+
+func (s *Server) handle(method string, params, filter gjson.Result) (json.RawMessage, *lightning.JSONRPCError, error) {
+	s.mu.Lock()
+	s.calls = append(s.calls, Call{method, params, filter, time.Now()})
+	handler, ok := s.handlers[method]
+	delay := s.delays[method]
+	hangup := s.hangups[method]
+	s.mu.Unlock()
+
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-s.closed:
+			return nil, nil, errHangUp
+		}
+	}
+	if hangup {
+		return nil, nil, errHangUp
+	}
+	if !ok {
+		return nil, &lightning.JSONRPCError{Code: -32601, Message: "Unknown command '" + method + "'"}, nil
+	}
+
+	result, err := handler(params)
+	if err != nil {
+		var cmderr lightning.ErrorCommand
+		if errors.As(err, &cmderr) {
+			return nil, &lightning.JSONRPCError{Code: cmderr.Code, Message: cmderr.Message, Data: cmderr.Data}, nil
+		}
+		if err == errClosed {
+			return nil, nil, errHangUp
+		}
+		return nil, &lightning.JSONRPCError{Code: -1, Message: err.Error()}, nil
+	}
+
+	jresult, err := json.Marshal(result)
+	if err != nil {
+		return nil, &lightning.JSONRPCError{Code: -32603, Message: "failed to encode result: " + err.Error()}, nil
+	}
+	return jresult, nil, nil
+}
+
+func (s *Server) acceptLoop() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns++
+		s.mu.Unlock()
+		go s.serveConn(conn)
+	}
+}
+
+func (s *Server) serveConn(conn net.Conn) {
+	defer conn.Close()
+
+	decoder := json.NewDecoder(conn)
+	encoder := json.NewEncoder(conn)
+	notificationsEnabled := false
+	for {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return
+		}
+
+		var msg struct {
+			Version string          `json:"jsonrpc"`
+			Id      interface{}     `json:"id"`
+			Method  string          `json:"method"`
+			Params  json.RawMessage `json:"params"`
+			Filter  json.RawMessage `json:"filter"`
+		}
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			// like lightningd, which doesn't take arrays
+			encoder.Encode(lightning.JSONRPCResponse{
+				Version: "2.0",
+				Error:   &lightning.JSONRPCError{Code: -32600, Message: "Expected {} for json command"},
+			})
+			continue
+		}
+
+		params := gjson.ParseBytes(msg.Params)
+		if msg.Method == "notifications" {
+			notificationsEnabled = params.Get("enable").Bool()
+		}
+		if notificationsEnabled {
+			s.mu.Lock()
+			notifications := s.notifies[msg.Method]
+			s.mu.Unlock()
+			for _, n := range notifications {
+				nparams := map[string]interface{}{"id": msg.Id}
+				for k, v := range n.Params {
+					nparams[k] = v
+				}
+				encoder.Encode(map[string]interface{}{
+					"jsonrpc": "2.0",
+					"method":  n.Method,
+					"params":  nparams,
+				})
+			}
+		}
+
+		result, rpcerr, err := s.handle(msg.Method, params, gjson.ParseBytes(msg.Filter))
+		if err != nil {
+			return
+		}
+
+		encoder.Encode(lightning.JSONRPCResponse{
+			Version: msg.Version,
+			Id:      msg.Id,
+			Result:  result,
+			Error:   rpcerr,
+		})
+	}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "method not allowed", 405)
+		return
+	}
+	if s.SparkToken != "" && r.Header.Get("X-Access") != s.SparkToken {
+		w.WriteHeader(401)
+		json.NewEncoder(w).Encode(lightning.JSONRPCError{Code: 401, Message: "Unauthorized"})
+		return
+	}
+
+	var msg struct {
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+		Filter json.RawMessage `json:"filter"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+		w.WriteHeader(400)
+		json.NewEncoder(w).Encode(lightning.JSONRPCError{Code: 400, Message: err.Error()})
+		return
+	}
+
+	result, rpcerr, err := s.handle(msg.Method, gjson.ParseBytes(msg.Params), gjson.ParseBytes(msg.Filter))
+	if err != nil {
+		// drop the connection without writing anything
+		if hj, ok := w.(http.Hijacker); ok {
+			if conn, _, err := hj.Hijack(); err == nil {
+				conn.Close()
+				return
+			}
+		}
+		panic(http.ErrAbortHandler)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if rpcerr != nil {
+		w.WriteHeader(500)
+		json.NewEncoder(w).Encode(rpcerr)
+		return
+	}
+	w.Write(result)
+}
@@ -0,0 +1,144 @@
+package lightningtest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	lightning "github.com/fiatjaf/lightningd-gjson-rpc"
+	"github.com/tidwall/gjson"
+)
+
+func TestSocketPathIsShort(t *testing.T) {
+	var path string
+	name := strings.Repeat("a_very_long_subtest_name_", 8)
+	t.Run(name, func(t *testing.T) {
+		s := NewServer(t)
+		path = s.Path
+		if len(s.Path) >= 104 {
+			t.Fatalf("socket path has %d bytes: %s", len(s.Path), s.Path)
+		}
+
+		s.Respond("getinfo", map[string]interface{}{"id": "02aa"})
+		res, err := s.Client().Call("getinfo")
+		if err != nil || res.Get("id").String() != "02aa" {
+			t.Fatalf("getinfo: %s, %v", res.Raw, err)
+		}
+	})
+
+	if _, err := os.Stat(filepath.Dir(path)); !os.IsNotExist(err) {
+		t.Errorf("socket directory not removed: %v", err)
+	}
+}
+
+func TestConcurrentClose(t *testing.T) {
+	s := NewServer(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.Close()
+		}()
+	}
+	wg.Wait()
+
+	ln := s.Client()
+	ln.RetryPolicy = &lightning.RetryPolicy{MaxAttempts: 1}
+	if _, err := ln.Call("getinfo"); err == nil {
+		t.Fatal("call to a closed server succeeded")
+	}
+}
+
+func TestPayInvoiceListener(t *testing.T) {
+	s := NewServer(t)
+
+	received := make(chan gjson.Result, 2)
+	ln := s.Client()
+	ln.PaymentHandler = func(inv gjson.Result) { received <- inv }
+	ln.ListenForInvoices()
+
+	s.PayInvoice(map[string]interface{}{"label": "first", "amount_msat": 1000})
+	s.PayInvoice(map[string]interface{}{"label": "second", "amount_msat": 2000})
+
+	for i, label := range []string{"first", "second"} {
+		select {
+		case inv := <-received:
+			if inv.Get("label").String() != label || inv.Get("pay_index").Int() != int64(i+1) || inv.Get("status").String() != "paid" {
+				t.Errorf("invoice %d: %s", i, inv.Raw)
+			}
+		case <-time.After(time.Second * 5):
+			t.Fatalf("invoice %s not received", label)
+		}
+	}
+
+	// each call asks for the invoices after the last one received
+	calls := s.Calls("waitanyinvoice")
+	for i, call := range calls[:2] {
+		if call.Params.Get("0").Int() != int64(i) {
+			t.Errorf("waitanyinvoice %d called with %s", i, call.Params.Raw)
+		}
+	}
+}
+
+func TestHangUpRetried(t *testing.T) {
+	s := NewServer(t)
+	s.Respond("getinfo", map[string]interface{}{"id": "02aa"})
+	s.Respond("pay", map[string]interface{}{"status": "complete"})
+	s.HangUp("getinfo", true)
+	s.HangUp("pay", true)
+
+	ln := s.Client()
+	ln.RetryPolicy = &lightning.RetryPolicy{
+		MaxAttempts:       3,
+		InitialBackoff:    time.Millisecond,
+		RetryOn:           []string{"broken"},
+		IdempotentMethods: []string{"getinfo"},
+	}
+
+	if _, err := ln.Call("getinfo"); lightning.ErrorKind(err) != "broken" {
+		t.Errorf("getinfo: %v", err)
+	}
+	if n := len(s.Calls("getinfo")); n != 3 {
+		t.Errorf("getinfo called %d times, expected every attempt", n)
+	}
+
+	// pay may have been received, so it isn't sent again
+	if _, err := ln.Call("pay", "lnbc1"); lightning.ErrorKind(err) != "broken" {
+		t.Errorf("pay: %v", err)
+	}
+	if n := len(s.Calls("pay")); n != 1 {
+		t.Errorf("pay called %d times", n)
+	}
+
+	s.HangUp("getinfo", false)
+	if res, err := ln.Call("getinfo"); err != nil || res.Get("id").String() != "02aa" {
+		t.Errorf("getinfo after the hang up: %s, %v", res.Raw, err)
+	}
+}
+
+func TestDelayTimesOut(t *testing.T) {
+	s := NewServer(t)
+	s.Respond("getinfo", map[string]interface{}{"id": "02aa"})
+	s.Delay("getinfo", time.Millisecond*500)
+
+	for _, ln := range []*lightning.Client{s.Client(), s.SparkClient()} {
+		ln.CallTimeout = time.Millisecond * 100
+		start := time.Now()
+		if _, err := ln.Call("getinfo"); lightning.ErrorKind(err) != "timeout" {
+			t.Errorf("expected a timeout, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Millisecond*400 {
+			t.Errorf("timed out after %s", elapsed)
+		}
+
+		ln.CallTimeout = time.Second * 5
+		if res, err := ln.Call("getinfo"); err != nil || res.Get("id").String() != "02aa" {
+			t.Errorf("getinfo with a longer timeout: %s, %v", res.Raw, err)
+		}
+	}
+}
@@ -30,6 +30,9 @@ func (ln *Client) ListenForInvoices() {
 
 			index := res.Get("pay_index").Int()
 			ln.LastInvoiceIndex = int(index)
+			if ln.Metrics != nil {
+				ln.Metrics.observeInvoice(index)
+			}
 
 			ln.PaymentHandler(res)
 		}
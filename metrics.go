@@ -0,0 +1,227 @@
+package lightning
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyBuckets are the upper bounds, in seconds, of the call duration histogram.
+var DefaultLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// Metrics collects call counts, latencies and errors of a Client, plus reconnects
+// and invoice listener progress. Set it as Client.Metrics and expose it with
+// ServeHTTP or WriteTo, which use the Prometheus text format.
+type Metrics struct {
+	// Buckets are read on the first call, changing them later has no effect.
+	Buckets []float64
+
+	mu            sync.Mutex
+	buckets       []float64
+	methods       map[string]*methodMetrics
+	commandErrors map[string]map[int]uint64
+	reconnects    uint64
+
+	invoiceIndex   int64
+	invoiceHandled time.Time
+}
+
+type methodMetrics struct {
+	calls   uint64
+	errors  map[string]uint64 // by ErrorKind
+	buckets []uint64
+	sum     float64
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{
+		Buckets:       DefaultLatencyBuckets,
+		methods:       make(map[string]*methodMetrics),
+		commandErrors: make(map[string]map[int]uint64),
+	}
+}
+
+// Interceptor returns an interceptor that records every call. It is added
+// automatically when Client.Metrics is set.
+func (m *Metrics) Interceptor() Interceptor {
+	return func(timeout time.Duration, message JSONRPCMessage, next Invoker) ([]byte, error) {
+		start := time.Now()
+		res, err := next(timeout, message)
+		m.observeCall(message.Method, time.Since(start), err)
+		return res, err
+	}
+}
+
+func (m *Metrics) observeCall(method string, duration time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// allow &Metrics{} to be used directly
+	if m.methods == nil {
+		m.methods = make(map[string]*methodMetrics)
+		m.commandErrors = make(map[string]map[int]uint64)
+	}
+	if m.buckets == nil {
+		m.buckets = DefaultLatencyBuckets
+		if m.Buckets != nil {
+			m.buckets = append([]float64{}, m.Buckets...)
+		}
+	}
+
+	mm, ok := m.methods[method]
+	if !ok {
+		mm = &methodMetrics{
+			errors:  make(map[string]uint64),
+			buckets: make([]uint64, len(m.buckets)),
+		}
+		m.methods[method] = mm
+	}
+
+	mm.calls++
+	seconds := duration.Seconds()
+	mm.sum += seconds
+	for i, le := range m.buckets {
+		if seconds <= le {
+			mm.buckets[i]++
+		}
+	}
+
+	if err != nil {
+		mm.errors[ErrorKind(err)]++
+		if cmderr, ok := err.(ErrorCommand); ok {
+			if _, ok := m.commandErrors[method]; !ok {
+				m.commandErrors[method] = make(map[int]uint64)
+			}
+			m.commandErrors[method][cmderr.Code]++
+		}
+	}
+}
+
+func (m *Metrics) observeReconnect() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.reconnects++
+}
+
+// observeInvoice is called by ListenForInvoices for each payment received.
+func (m *Metrics) observeInvoice(index int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.invoiceIndex = index
+	m.invoiceHandled = time.Now()
+}
+
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	m.WriteTo(w)
+}
+
+// WriteTo writes all metrics in the Prometheus text exposition format.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+
+	methods := make([]string, 0, len(m.methods))
+	for method := range m.methods {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+
+	cw.printf("# HELP lightning_rpc_calls_total Calls made, by method.\n")
+	cw.printf("# TYPE lightning_rpc_calls_total counter\n")
+	for _, method := range methods {
+		cw.printf("lightning_rpc_calls_total{method=%s} %d\n", label(method), m.methods[method].calls)
+	}
+
+	cw.printf("# HELP lightning_rpc_call_duration_seconds Duration of calls, by method.\n")
+	cw.printf("# TYPE lightning_rpc_call_duration_seconds histogram\n")
+	for _, method := range methods {
+		mm := m.methods[method]
+		for i, le := range m.buckets {
+			cw.printf("lightning_rpc_call_duration_seconds_bucket{method=%s,le=\"%s\"} %d\n",
+				label(method), strconv.FormatFloat(le, 'g', -1, 64), mm.buckets[i])
+		}
+		cw.printf("lightning_rpc_call_duration_seconds_bucket{method=%s,le=\"+Inf\"} %d\n", label(method), mm.calls)
+		cw.printf("lightning_rpc_call_duration_seconds_sum{method=%s} %s\n",
+			label(method), strconv.FormatFloat(mm.sum, 'g', -1, 64))
+		cw.printf("lightning_rpc_call_duration_seconds_count{method=%s} %d\n", label(method), mm.calls)
+	}
+
+	cw.printf("# HELP lightning_rpc_errors_total Failed calls, by method and error type.\n")
+	cw.printf("# TYPE lightning_rpc_errors_total counter\n")
+	for _, method := range methods {
+		kinds := make([]string, 0, len(m.methods[method].errors))
+		for kind := range m.methods[method].errors {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+		for _, kind := range kinds {
+			cw.printf("lightning_rpc_errors_total{method=%s,type=%s} %d\n",
+				label(method), label(kind), m.methods[method].errors[kind])
+		}
+	}
+
+	cw.printf("# HELP lightning_rpc_command_errors_total Errors returned by lightningd, by method and code.\n")
+	cw.printf("# TYPE lightning_rpc_command_errors_total counter\n")
+	for _, method := range methods {
+		codes := make([]int, 0, len(m.commandErrors[method]))
+		for code := range m.commandErrors[method] {
+			codes = append(codes, code)
+		}
+		sort.Ints(codes)
+		for _, code := range codes {
+			cw.printf("lightning_rpc_command_errors_total{method=%s,code=\"%d\"} %d\n",
+				label(method), code, m.commandErrors[method][code])
+		}
+	}
+
+	cw.printf("# HELP lightning_rpc_reconnects_total Connection attempts retried.\n")
+	cw.printf("# TYPE lightning_rpc_reconnects_total counter\n")
+	cw.printf("lightning_rpc_reconnects_total %d\n", m.reconnects)
+
+	cw.printf("# HELP lightning_invoice_listener_last_index pay_index of the last invoice handled by ListenForInvoices.\n")
+	cw.printf("# TYPE lightning_invoice_listener_last_index gauge\n")
+	cw.printf("lightning_invoice_listener_last_index %d\n", m.invoiceIndex)
+
+	// only after the first invoice, before that there is nothing to be late for
+	if !m.invoiceHandled.IsZero() {
+		cw.printf("# HELP lightning_invoice_listener_lag_seconds Time since ListenForInvoices handled the last invoice.\n")
+		cw.printf("# TYPE lightning_invoice_listener_lag_seconds gauge\n")
+		cw.printf("lightning_invoice_listener_lag_seconds %s\n",
+			strconv.FormatFloat(time.Since(m.invoiceHandled).Seconds(), 'g', -1, 64))
+	}
+
+	if cw.err == nil {
+		cw.err = cw.w.Flush()
+	}
+	return cw.n, cw.err
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func label(value string) string {
+	return `"` + labelEscaper.Replace(value) + `"`
+}
+
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (cw *countingWriter) printf(format string, args ...interface{}) {
+	if cw.err != nil {
+		return
+	}
+	n, err := fmt.Fprintf(cw.w, format, args...)
+	cw.n += int64(n)
+	cw.err = err
+}
@@ -0,0 +1,99 @@
+package lightning_test
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	lightning "github.com/fiatjaf/lightningd-gjson-rpc"
+	"github.com/fiatjaf/lightningd-gjson-rpc/lightningtest"
+	"github.com/tidwall/gjson"
+)
+
+func scrape(t *testing.T, m *lightning.Metrics) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if _, err := m.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestMetricsCalls(t *testing.T) {
+	rpc := lightningtest.NewServer(t)
+	rpc.Respond("getinfo", map[string]interface{}{"id": "02aa"})
+	rpc.Fail("pay", 210, "failed")
+
+	ln := rpc.Client()
+	ln.Metrics = lightning.NewMetrics()
+	ln.Metrics.Buckets = []float64{1, 10}
+	ln.Call("getinfo")
+	ln.Call("pay", "lnbc1")
+
+	// too late, the first call already used them
+	ln.Metrics.Buckets = []float64{1, 2, 3, 4}
+	ln.Call("getinfo")
+
+	out := scrape(t, ln.Metrics)
+	for _, line := range []string{
+		`lightning_rpc_calls_total{method="getinfo"} 2`,
+		`lightning_rpc_calls_total{method="pay"} 1`,
+		`lightning_rpc_call_duration_seconds_bucket{method="getinfo",le="1"} 2`,
+		`lightning_rpc_call_duration_seconds_bucket{method="getinfo",le="10"} 2`,
+		`lightning_rpc_call_duration_seconds_bucket{method="getinfo",le="+Inf"} 2`,
+		`lightning_rpc_errors_total{method="pay",type="command"} 1`,
+		`lightning_rpc_command_errors_total{method="pay",code="210"} 1`,
+	} {
+		if !strings.Contains(out, line+"\n") {
+			t.Errorf("missing %s in:\n%s", line, out)
+		}
+	}
+	if strings.Contains(out, `le="2"`) {
+		t.Errorf("buckets changed after the first call:\n%s", out)
+	}
+}
+
+var lagLine = regexp.MustCompile(`(?m)^lightning_invoice_listener_lag_seconds (\S+)$`)
+
+func lag(t *testing.T, m *lightning.Metrics) float64 {
+	t.Helper()
+	match := lagLine.FindStringSubmatch(scrape(t, m))
+	if match == nil {
+		t.Fatal("no lag metric")
+	}
+	seconds, _ := strconv.ParseFloat(match[1], 64)
+	return seconds
+}
+
+func TestMetricsInvoiceLag(t *testing.T) {
+	rpc := lightningtest.NewServer(t)
+	ln := rpc.Client()
+	ln.Metrics = lightning.NewMetrics()
+
+	if lagLine.MatchString(scrape(t, ln.Metrics)) {
+		t.Error("lag reported before any invoice")
+	}
+
+	handled := make(chan int64, 1)
+	ln.PaymentHandler = func(inv gjson.Result) { handled <- inv.Get("pay_index").Int() }
+	ln.ListenForInvoices()
+
+	rpc.PayInvoice(map[string]interface{}{"label": "a"})
+	select {
+	case <-handled:
+	case <-time.After(time.Second * 5):
+		t.Fatal("invoice not handled")
+	}
+
+	first := lag(t, ln.Metrics)
+	time.Sleep(time.Millisecond * 50)
+	if second := lag(t, ln.Metrics); second <= first {
+		t.Errorf("lag didn't grow between scrapes: %v, %v", first, second)
+	}
+	if out := scrape(t, ln.Metrics); !strings.Contains(out, "lightning_invoice_listener_last_index 1\n") {
+		t.Errorf("last index not reported:\n%s", out)
+	}
+}
@@ -0,0 +1,210 @@
+package lightning
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// Msat is an amount in millisatoshis, as lightningd expects and returns them.
+// MsatAny and MsatAll are the special "any" and "all" amounts some commands
+// accept. They are not amounts, so arithmetic and conversions on them fail
+// with ErrMsatSpecial.
+type Msat int64
+
+const (
+	MsatAny Msat = math.MinInt64
+	MsatAll Msat = math.MinInt64 + 1
+)
+
+var (
+	ErrMsatSpecial  = errors.New("lightning: \"any\" and \"all\" are not amounts")
+	ErrMsatOverflow = errors.New("lightning: msat overflow")
+)
+
+// ParseMsat understands every format lightningd has used for amounts:
+// plain integers (millisatoshis), "1000msat", "1sat", "0.00000001btc", "any" and "all".
+func ParseMsat(s string) (Msat, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	switch {
+	case s == "any":
+		return MsatAny, nil
+	case s == "all":
+		return MsatAll, nil
+	case strings.HasSuffix(s, "msat"):
+		return parseMsatUnits(s, strings.TrimSuffix(s, "msat"), 0)
+	case strings.HasSuffix(s, "sat"):
+		return parseMsatUnits(s, strings.TrimSuffix(s, "sat"), 3)
+	case strings.HasSuffix(s, "btc"):
+		return parseMsatUnits(s, strings.TrimSuffix(s, "btc"), 11)
+	default:
+		return parseMsatUnits(s, s, 0)
+	}
+}
+
+// parseMsatUnits parses a non-negative decimal number with at most `decimals`
+// digits after the point and scales it by 10^decimals, without going through floats.
+func parseMsatUnits(original, number string, decimals int) (Msat, error) {
+	whole, frac, _ := strings.Cut(number, ".")
+	if len(frac) > decimals {
+		return 0, fmt.Errorf("invalid amount %q: more precision than a millisatoshi", original)
+	}
+	digits := whole + frac + strings.Repeat("0", decimals-len(frac))
+
+	v, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil || v < 0 || strings.HasPrefix(digits, "+") {
+		return 0, fmt.Errorf("invalid amount %q", original)
+	}
+	return Msat(v), nil
+}
+
+// MsatFromSat converts satoshis to millisatoshis.
+func MsatFromSat(sat int64) Msat { return Msat(sat * 1000) }
+
+// MsatFromResult reads an amount from a gjson result, accepting both numbers and strings.
+func MsatFromResult(r gjson.Result) (Msat, error) {
+	switch r.Type {
+	case gjson.Number:
+		return parseMsatUnits(r.Raw, r.Raw, 0)
+	case gjson.String:
+		return ParseMsat(r.Str)
+	case gjson.Null:
+		return 0, errors.New("amount is missing")
+	default:
+		return 0, fmt.Errorf("invalid amount %s", r.Raw)
+	}
+}
+
+// GetMsat is a shortcut for MsatFromResult(r.Get(path)) that returns 0 on errors.
+func GetMsat(r gjson.Result, path string) Msat {
+	m, _ := MsatFromResult(r.Get(path))
+	return m
+}
+
+func (m Msat) IsAny() bool { return m == MsatAny }
+func (m Msat) IsAll() bool { return m == MsatAll }
+
+func (m Msat) Sat() (int64, error) {
+	if m.IsAny() || m.IsAll() {
+		return 0, ErrMsatSpecial
+	}
+	return int64(m) / 1000, nil
+}
+
+func (m Msat) Btc() (float64, error) {
+	if m.IsAny() || m.IsAll() {
+		return 0, ErrMsatSpecial
+	}
+	return float64(m) / 100000000000, nil
+}
+
+func (m Msat) Add(o Msat) (Msat, error) {
+	if m.IsAny() || m.IsAll() || o.IsAny() || o.IsAll() {
+		return 0, ErrMsatSpecial
+	}
+	return checkedAdd(int64(m), int64(o))
+}
+
+func (m Msat) Sub(o Msat) (Msat, error) {
+	if m.IsAny() || m.IsAll() || o.IsAny() || o.IsAll() {
+		return 0, ErrMsatSpecial
+	}
+	if o == math.MinInt64 {
+		return 0, ErrMsatOverflow
+	}
+	return checkedAdd(int64(m), -int64(o))
+}
+
+func (m Msat) Mul(n int64) (Msat, error) {
+	if m.IsAny() || m.IsAll() {
+		return 0, ErrMsatSpecial
+	}
+	return checkedMul(int64(m), n)
+}
+
+// PPM returns the given parts-per-million of m, rounded up, as lightningd does for fees.
+func (m Msat) PPM(ppm int64) (Msat, error) {
+	parts, err := m.Mul(ppm)
+	if err != nil {
+		return 0, err
+	}
+	parts, err = checkedAdd(int64(parts), 999999)
+	if err != nil {
+		return 0, err
+	}
+	return parts / 1000000, nil
+}
+
+// checkedAdd fails when a+b doesn't fit in an int64, or lands on the special amounts.
+func checkedAdd(a, b int64) (Msat, error) {
+	sum := a + b
+	// it overflowed if both have the same sign and the sum doesn't
+	if (a < 0) == (b < 0) && (sum < 0) != (a < 0) {
+		return 0, ErrMsatOverflow
+	}
+	return checkSpecial(Msat(sum))
+}
+
+// checkedMul fails when a*n doesn't fit in an int64, or lands on the special amounts.
+func checkedMul(a, n int64) (Msat, error) {
+	negative := (a < 0) != (n < 0)
+	hi, lo := bits.Mul64(abs(a), abs(n))
+	if hi != 0 || lo > math.MaxInt64 {
+		return 0, ErrMsatOverflow
+	}
+	if negative {
+		return checkSpecial(Msat(-int64(lo)))
+	}
+	return checkSpecial(Msat(lo))
+}
+
+func checkSpecial(m Msat) (Msat, error) {
+	if m.IsAny() || m.IsAll() {
+		return 0, ErrMsatOverflow
+	}
+	return m, nil
+}
+
+func abs(n int64) uint64 {
+	if n < 0 {
+		return uint64(-n)
+	}
+	return uint64(n)
+}
+
+func (m Msat) String() string {
+	switch m {
+	case MsatAny:
+		return "any"
+	case MsatAll:
+		return "all"
+	}
+	return strconv.FormatInt(int64(m), 10) + "msat"
+}
+
+func (m Msat) MarshalJSON() ([]byte, error) {
+	switch m {
+	case MsatAny, MsatAll:
+		return json.Marshal(m.String())
+	}
+	return []byte(strconv.FormatInt(int64(m), 10)), nil
+}
+
+func (m *Msat) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	v, err := MsatFromResult(gjson.ParseBytes(data))
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
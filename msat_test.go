@@ -0,0 +1,106 @@
+package lightning
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestMsatNegativeIsNotSpecial(t *testing.T) {
+	m, err := Msat(1000).Sub(1001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.IsAny() || m.IsAll() {
+		t.Fatalf("%d is special", m)
+	}
+	if j, _ := json.Marshal(m); string(j) != "-1" {
+		t.Errorf("marshaled as %s", j)
+	}
+	if m, _ := Msat(5).Sub(7); m.String() != "-2msat" {
+		t.Errorf("String() = %s", m)
+	}
+}
+
+func TestMsatSpecial(t *testing.T) {
+	for _, s := range []string{"any", "all"} {
+		m, err := ParseMsat(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if j, _ := json.Marshal(m); string(j) != `"`+s+`"` {
+			t.Errorf("%s marshaled as %s", s, j)
+		}
+
+		for name, op := range map[string]func() error{
+			"Sat": func() error { _, err := m.Sat(); return err },
+			"Btc": func() error { _, err := m.Btc(); return err },
+			"PPM": func() error { _, err := m.PPM(1000); return err },
+			"Add": func() error { _, err := Msat(1).Add(m); return err },
+			"Sub": func() error { _, err := Msat(1).Sub(m); return err },
+			"Mul": func() error { _, err := m.Mul(2); return err },
+		} {
+			if err := op(); !errors.Is(err, ErrMsatSpecial) {
+				t.Errorf("%s on %s: %v", name, s, err)
+			}
+		}
+	}
+}
+
+func TestMsatArithmetic(t *testing.T) {
+	for name, c := range map[string]struct {
+		op       func() (Msat, error)
+		expected Msat
+	}{
+		"add":           {func() (Msat, error) { return Msat(1000).Add(234) }, 1234},
+		"sub":           {func() (Msat, error) { return Msat(1000).Sub(1) }, 999},
+		"mul":           {func() (Msat, error) { return Msat(-3).Mul(7) }, -21},
+		"ppm rounds up": {func() (Msat, error) { return Msat(1001).PPM(1000) }, 2},
+		"ppm":           {func() (Msat, error) { return Msat(100000000).PPM(1) }, 100},
+		"max":           {func() (Msat, error) { return Msat(math.MaxInt64 - 1).Add(1) }, math.MaxInt64},
+	} {
+		m, err := c.op()
+		if err != nil || m != c.expected {
+			t.Errorf("%s = %d, %v", name, m, err)
+		}
+	}
+}
+
+func TestMsatOverflow(t *testing.T) {
+	for name, op := range map[string]func() (Msat, error){
+		"add":             func() (Msat, error) { return Msat(math.MaxInt64).Add(1) },
+		"add far":         func() (Msat, error) { return Msat(math.MaxInt64).Add(math.MaxInt64) },
+		"sub":             func() (Msat, error) { return Msat(-10).Sub(math.MaxInt64) },
+		"sub into any":    func() (Msat, error) { return Msat(-1).Sub(math.MaxInt64) },
+		"mul":             func() (Msat, error) { return Msat(math.MaxInt64 / 2).Mul(3) },
+		"mul wraps":       func() (Msat, error) { return Msat(1 << 62).Mul(4) },
+		"mul negative":    func() (Msat, error) { return Msat(1 << 62).Mul(-3) },
+		"ppm":             func() (Msat, error) { return Msat(math.MaxInt64 / 1000).PPM(1000000) },
+		"ppm rounding up": func() (Msat, error) { return Msat(math.MaxInt64 - 1).PPM(1) },
+	} {
+		if m, err := op(); !errors.Is(err, ErrMsatOverflow) {
+			t.Errorf("%s = %d, %v", name, m, err)
+		}
+	}
+}
+
+func TestMsatParse(t *testing.T) {
+	for s, expected := range map[string]Msat{
+		"1000":          1000,
+		"1000msat":      1000,
+		"1sat":          1000,
+		"0.00000001btc": 1000,
+		"0.5sat":        500,
+	} {
+		m, err := ParseMsat(s)
+		if err != nil || m != expected {
+			t.Errorf("ParseMsat(%q) = %d, %v", s, m, err)
+		}
+	}
+	for _, s := range []string{"-1", "0.0001sat", "x", "+1"} {
+		if _, err := ParseMsat(s); err == nil {
+			t.Errorf("ParseMsat(%q) didn't fail", s)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package lightning
+
+import (
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// Notification is sent by lightningd while a command runs, when notifications
+// are enabled in the connection. Method is "message", with "level" and
+// "message" in Params, or "progress", with "num", "total" and maybe "stage".
+// Params also contain the "id" of the command.
+//
+// Set JSONRPCMessage.OnNotification or use CallWithNotifications to get them.
+// The callback is called in order, from the goroutine reading the socket.
+type Notification struct {
+	Method string
+	Params gjson.Result
+}
+
+var enableNotifications = []byte(`{"jsonrpc":"2.0","id":"enable-notifications","method":"notifications","params":{"enable":true}}` + "\n")
+
+// CallWithNotifications is like CallWithCustomTimeout, but calls onNotification
+// for every "message" or "progress" notification about this command.
+func (ln *Client) CallWithNotifications(
+	timeout time.Duration,
+	onNotification func(Notification),
+	method string,
+	params ...interface{},
+) (gjson.Result, error) {
+	message := JSONRPCMessage{
+		Version:        version,
+		Method:         method,
+		Params:         payloadFromParams(params),
+		OnNotification: onNotification,
+	}
+	return ln.CallMessage(timeout, message)
+}
@@ -0,0 +1,69 @@
+package lightning_test
+
+import (
+	"testing"
+	"time"
+
+	lightning "github.com/fiatjaf/lightningd-gjson-rpc"
+	"github.com/fiatjaf/lightningd-gjson-rpc/lightningtest"
+)
+
+func TestCallWithNotifications(t *testing.T) {
+	rpc := lightningtest.NewServer(t)
+	rpc.Respond("pay", map[string]interface{}{"status": "complete"})
+	rpc.Notify("pay",
+		lightningtest.Notification{Method: "message", Params: map[string]interface{}{"level": "info", "message": "trying route 1"}},
+		lightningtest.Notification{Method: "progress", Params: map[string]interface{}{"num": 1, "total": 3}},
+	)
+	ln := rpc.Client()
+
+	var received []lightning.Notification
+	res, err := ln.CallWithNotifications(time.Second*5, func(n lightning.Notification) {
+		received = append(received, n)
+	}, "pay", "lnbc1")
+	if err != nil || res.Get("status").String() != "complete" {
+		t.Fatalf("pay: %s, %v", res.Raw, err)
+	}
+
+	if len(received) != 2 {
+		t.Fatalf("got %d notifications", len(received))
+	}
+	if n := received[0]; n.Method != "message" || n.Params.Get("message").String() != "trying route 1" || n.Params.Get("level").String() != "info" {
+		t.Errorf("first notification: %s %s", n.Method, n.Params.Raw)
+	}
+	if n := received[1]; n.Method != "progress" || n.Params.Get("num").Int() != 1 || n.Params.Get("total").Int() != 3 {
+		t.Errorf("second notification: %s %s", n.Method, n.Params.Raw)
+	}
+	for _, n := range received {
+		if n.Params.Get("id").String() != "0" {
+			t.Errorf("notification without the id of the call: %s", n.Params.Raw)
+		}
+	}
+	if n := len(rpc.Calls("notifications")); n != 1 {
+		t.Errorf("notifications enabled %d times", n)
+	}
+
+	// without a callback notifications aren't enabled and the result is the same
+	res, err = ln.Call("pay", "lnbc1")
+	if err != nil || res.Get("status").String() != "complete" {
+		t.Errorf("pay without a callback: %s, %v", res.Raw, err)
+	}
+	if n := len(rpc.Calls("notifications")); n != 1 {
+		t.Errorf("notifications enabled for a call without a callback")
+	}
+}
+
+func TestCallWithNotificationsError(t *testing.T) {
+	rpc := lightningtest.NewServer(t)
+	rpc.Fail("pay", 210, "Ran out of routes to try")
+	rpc.Notify("pay", lightningtest.Notification{Method: "message", Params: map[string]interface{}{"level": "debug", "message": "no route"}})
+
+	count := 0
+	_, err := rpc.Client().CallWithNotifications(time.Second*5, func(n lightning.Notification) { count++ }, "pay", "lnbc1")
+	if cmderr, ok := err.(lightning.ErrorCommand); !ok || cmderr.Code != 210 {
+		t.Errorf("pay: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("got %d notifications before the error", count)
+	}
+}
@@ -0,0 +1,126 @@
+package lightning
+
+import (
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// Pager goes through the results of a paginated list command (listinvoices,
+// listsendpays, listforwards and others that take index, start and limit),
+// a page at a time. Use it like a bufio.Scanner:
+//
+//	pager := ln.Paginate("listinvoices", "created", lastIndex, 100)
+//	for pager.Next() {
+//		invoice := pager.Item()
+//	}
+//	if err := pager.Err(); err != nil { ... }
+//	lastIndex = pager.Start // resume from here next time
+type Pager struct {
+	Method string
+	// Field is where the items are in the result, defaults to the method
+	// name without "list" ("payments" for listsendpays).
+	Field string
+	// Index is "created" or "updated".
+	Index string
+	// Start is the index of the next item to be fetched. It is moved forward
+	// as items are read, so it can be stored to resume later.
+	Start uint64
+	// Limit is the page size.
+	Limit int
+	// Params are other params sent with every call, like {"status": "paid"}.
+	Params  map[string]interface{}
+	Timeout time.Duration
+
+	ln   *Client
+	page []gjson.Result
+	item gjson.Result
+	done bool
+	err  error
+}
+
+var pagedFields = map[string]string{
+	"listsendpays": "payments",
+}
+
+// Paginate returns a Pager for method, starting at the given index.
+func (ln *Client) Paginate(method string, index string, start uint64, limit int) *Pager {
+	field, ok := pagedFields[method]
+	if !ok {
+		field = strings.TrimPrefix(method, "list")
+	}
+	if limit <= 0 {
+		limit = 100
+	}
+
+	return &Pager{
+		Method: method,
+		Field:  field,
+		Index:  index,
+		Start:  start,
+		Limit:  limit,
+		ln:     ln,
+	}
+}
+
+// Next moves to the next item, fetching a new page if needed. It returns false
+// when there are no more items or there was an error.
+func (p *Pager) Next() bool {
+	if p.err != nil {
+		return false
+	}
+	if len(p.page) == 0 {
+		if p.done {
+			return false
+		}
+		if p.err = p.fetch(); p.err != nil || len(p.page) == 0 {
+			return false
+		}
+	}
+
+	p.item = p.page[0]
+	p.page = p.page[1:]
+	if index := p.item.Get(p.Index + "_index"); index.Exists() {
+		p.Start = index.Uint() + 1
+	}
+	return true
+}
+
+// Item is the current item.
+func (p *Pager) Item() gjson.Result { return p.item }
+
+// Err is the error that made Next return false, if any.
+func (p *Pager) Err() error { return p.err }
+
+func (p *Pager) fetch() error {
+	params := make(map[string]interface{}, len(p.Params)+3)
+	for k, v := range p.Params {
+		params[k] = v
+	}
+	params["index"] = p.Index
+	params["start"] = p.Start
+	params["limit"] = p.Limit
+
+	timeout := p.Timeout
+	if timeout == 0 {
+		timeout = p.ln.CallTimeout
+	}
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+
+	res, err := p.ln.CallWithCustomTimeout(timeout, p.Method, params)
+	if err != nil {
+		return err
+	}
+
+	p.page = res.Get(p.Field).Array()
+	if len(p.page) < p.Limit {
+		p.done = true
+	} else if !p.page[len(p.page)-1].Get(p.Index + "_index").Exists() {
+		// without indexes we can't know where the next page starts
+		p.done = true
+	}
+	return nil
+}
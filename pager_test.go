@@ -0,0 +1,118 @@
+package lightning_test
+
+import (
+	"testing"
+
+	lightning "github.com/fiatjaf/lightningd-gjson-rpc"
+	"github.com/fiatjaf/lightningd-gjson-rpc/lightningtest"
+	"github.com/tidwall/gjson"
+)
+
+// serveInvoices makes rpc answer listinvoices pages from n invoices with
+// created_index 1..n, like lightningd does.
+func serveInvoices(rpc *lightningtest.Server, n uint64) {
+	rpc.Handle("listinvoices", func(params gjson.Result) (interface{}, error) {
+		invoices := []interface{}{}
+		start := params.Get("start").Uint()
+		if start == 0 {
+			start = 1
+		}
+		for i := start; i <= n && len(invoices) < int(params.Get("limit").Int()); i++ {
+			invoices = append(invoices, map[string]interface{}{
+				"label":         params.Get("status").String(),
+				"created_index": i,
+			})
+		}
+		return map[string]interface{}{"invoices": invoices}, nil
+	})
+}
+
+func TestPager(t *testing.T) {
+	rpc := lightningtest.NewServer(t)
+	serveInvoices(rpc, 7)
+	ln := rpc.Client()
+
+	pager := ln.Paginate("listinvoices", "created", 0, 3)
+	pager.Params = map[string]interface{}{"status": "paid"}
+	var seen []uint64
+	for pager.Next() {
+		if pager.Item().Get("label").String() != "paid" {
+			t.Errorf("params not sent: %s", pager.Item().Raw)
+		}
+		seen = append(seen, pager.Item().Get("created_index").Uint())
+	}
+	if err := pager.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(seen) != 7 || seen[0] != 1 || seen[6] != 7 {
+		t.Errorf("got %v", seen)
+	}
+	if pager.Start != 8 {
+		t.Errorf("Start is %d, expected 8", pager.Start)
+	}
+	// pages of 3, 3 and a last short one ends it
+	if calls := len(rpc.Calls("listinvoices")); calls != 3 {
+		t.Errorf("%d calls, expected 3", calls)
+	}
+
+	// resuming from the stored index only returns the new invoices
+	serveInvoices(rpc, 9)
+	resumed := ln.Paginate("listinvoices", "created", pager.Start, 3)
+	seen = nil
+	for resumed.Next() {
+		seen = append(seen, resumed.Item().Get("created_index").Uint())
+	}
+	if resumed.Err() != nil || len(seen) != 2 || seen[0] != 8 || seen[1] != 9 {
+		t.Errorf("resumed got %v, %v", seen, resumed.Err())
+	}
+	if resumed.Start != 10 {
+		t.Errorf("resumed Start is %d, expected 10", resumed.Start)
+	}
+}
+
+func TestPagerEnd(t *testing.T) {
+	rpc := lightningtest.NewServer(t)
+	serveInvoices(rpc, 6)
+
+	// a full last page needs one more call that comes back empty
+	pager := rpc.Client().Paginate("listinvoices", "created", 0, 3)
+	count := 0
+	for pager.Next() {
+		count++
+	}
+	if pager.Err() != nil || count != 6 {
+		t.Errorf("got %d items, %v", count, pager.Err())
+	}
+	if calls := len(rpc.Calls("listinvoices")); calls != 3 {
+		t.Errorf("%d calls, expected 3", calls)
+	}
+	if pager.Next() {
+		t.Error("Next returned true after the end")
+	}
+	if calls := len(rpc.Calls("listinvoices")); calls != 3 {
+		t.Errorf("Next after the end made another call")
+	}
+
+	// without indexes in the items a full page is still the last one
+	rpc.Respond("listforwards", map[string]interface{}{
+		"forwards": []interface{}{map[string]interface{}{}, map[string]interface{}{}},
+	})
+	pager = rpc.Client().Paginate("listforwards", "created", 0, 2)
+	count = 0
+	for pager.Next() {
+		count++
+	}
+	if pager.Err() != nil || count != 2 || len(rpc.Calls("listforwards")) != 1 {
+		t.Errorf("got %d items in %d calls, %v", count, len(rpc.Calls("listforwards")), pager.Err())
+	}
+
+	// errors stop it and are kept
+	rpc.Fail("listsendpays", -32602, "bad index")
+	pager = rpc.Client().Paginate("listsendpays", "created", 0, 2)
+	if pager.Next() {
+		t.Error("Next returned true on an error")
+	}
+	if _, ok := pager.Err().(lightning.ErrorCommand); !ok {
+		t.Errorf("expected a command error, got %v", pager.Err())
+	}
+}
@@ -0,0 +1,282 @@
+package plugin
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// CustomMessageHandler handles messages of one type sent by peers with
+// sendcustommsg. Types must be odd and at least 32768, so peers that don't
+// know them just ignore them.
+//
+// If ResponseType is set, the stream returned by Handler is sent back to the
+// peer with that type and the request id of the message (see RequestCustomMessage).
+// Otherwise it is ignored. Returning an error sends nothing.
+type CustomMessageHandler struct {
+	Type         uint16
+	ResponseType uint16
+	Handler      func(p *Plugin, peer string, body TLVStream) (TLVStream, error)
+}
+
+// RequestIDRecord is the TLV record that correlates requests and responses.
+// It is odd so peers that don't use it can ignore it.
+const RequestIDRecord uint64 = 65537
+
+var DefaultCustomMessageTimeout = time.Second * 30
+
+type customMessages struct {
+	setup    sync.Once
+	setupErr error
+
+	mu       sync.Mutex
+	handlers map[uint16]CustomMessageHandler
+	pending  map[string]chan TLVStream
+}
+
+// setupCustomMessages registers the custommsg hook and the feature bits, only
+// the first time it is called.
+func (p *Plugin) setupCustomMessages() error {
+	p.custom.setup.Do(func() {
+		p.custom.setupErr = p.registerCustomMessages()
+	})
+	return p.custom.setupErr
+}
+
+func (p *Plugin) registerCustomMessages() error {
+	p.custom.handlers = make(map[uint16]CustomMessageHandler)
+	for _, h := range p.CustomMessages {
+		if err := checkCustomType(h.Type); err != nil {
+			return err
+		}
+		if h.ResponseType != 0 {
+			if err := checkCustomType(h.ResponseType); err != nil {
+				return err
+			}
+		}
+		p.custom.handlers[h.Type] = h
+	}
+
+	if len(p.FeatureBits) > 0 {
+		p.Features.Node = setFeatureBits(p.Features.Node, p.FeatureBits)
+		p.Features.Init = setFeatureBits(p.Features.Init, p.FeatureBits)
+	}
+
+	if len(p.CustomMessages) == 0 {
+		return nil
+	}
+
+	// handle our types first and pass everything else to a custommsg hook the plugin may have.
+	// The hook gets its own workers, so responses to RequestCustomMessage get
+	// through even when handlers waiting for them take all of MaxConcurrency.
+	for i, hook := range p.Hooks {
+		if hook.Type == "custommsg" {
+			other := hook.Handler
+			p.Hooks[i].Handler = func(p *Plugin, params Params) interface{} {
+				if p.handleCustomMessage(params) {
+					return Continue()
+				}
+				return other(p, params)
+			}
+			if hook.Concurrency <= 0 {
+				p.Hooks[i].Concurrency = DefaultMaxConcurrency
+			}
+			return nil
+		}
+	}
+	p.Hooks = append(p.Hooks, Hook{
+		Type: "custommsg",
+		Handler: func(p *Plugin, params Params) interface{} {
+			p.handleCustomMessage(params)
+			return Continue()
+		},
+		Concurrency: DefaultMaxConcurrency,
+	})
+	return nil
+}
+
+func checkCustomType(t uint16) error {
+	if t < 32768 || t%2 == 0 {
+		return fmt.Errorf("custom message type %d must be odd and >= 32768", t)
+	}
+	return nil
+}
+
+// handleCustomMessage tells if the message was one of ours.
+func (p *Plugin) handleCustomMessage(params Params) bool {
+	peer := params.Get("peer_id").String()
+	payload, err := hex.DecodeString(params.Get("payload").String())
+	if err != nil || len(payload) < 2 {
+		return false
+	}
+	msgType := binary.BigEndian.Uint16(payload)
+
+	p.custom.mu.Lock()
+	handler, isRequest := p.custom.handlers[msgType]
+	p.custom.mu.Unlock()
+
+	body, err := DecodeTLVStream(payload[2:])
+	if err != nil {
+		if isRequest {
+			p.Unusualf("invalid custom message %d from %s: %s", msgType, peer, err)
+		}
+		return isRequest
+	}
+
+	// a response to one of our requests?
+	if id, ok := body[RequestIDRecord]; ok {
+		key := pendingKey(peer, msgType, id)
+		p.custom.mu.Lock()
+		waiting, ok := p.custom.pending[key]
+		delete(p.custom.pending, key)
+		p.custom.mu.Unlock()
+		if ok {
+			waiting <- body
+			return true
+		}
+	}
+
+	if !isRequest {
+		return false
+	}
+
+	// don't hold the hook while the handler runs, but let shutdown wait for it
+	p.inflight.Add(1)
+	go func() {
+		defer p.inflight.Done()
+		resp, err := handler.Handler(p, peer, body)
+		if err != nil {
+			p.Unusualf("custom message %d from %s: %s", msgType, peer, err)
+			return
+		}
+		if handler.ResponseType == 0 || resp == nil {
+			return
+		}
+		if id, ok := body[RequestIDRecord]; ok {
+			resp[RequestIDRecord] = id
+		}
+		if err := p.SendCustomMessage(peer, handler.ResponseType, resp); err != nil {
+			p.Unusualf("failed to respond to custom message %d from %s: %s", msgType, peer, err)
+		}
+	}()
+	return true
+}
+
+// SendCustomMessage sends a message to a connected peer.
+func (p *Plugin) SendCustomMessage(peer string, msgType uint16, body TLVStream) error {
+	if p.Client == nil {
+		return errors.New("plugin is not initialized")
+	}
+	msg := binary.BigEndian.AppendUint16(nil, msgType)
+	msg = append(msg, body.Encode()...)
+
+	_, err := p.Client.CallNamed("sendcustommsg",
+		"node_id", peer,
+		"msg", hex.EncodeToString(msg),
+	)
+	return err
+}
+
+// RequestCustomMessage sends a message with a new request id and waits for the
+// peer to answer with a message of responseType and the same id, until ctx is
+// done or DefaultCustomMessageTimeout if ctx has no deadline.
+func (p *Plugin) RequestCustomMessage(
+	ctx context.Context,
+	peer string,
+	msgType uint16,
+	responseType uint16,
+	body TLVStream,
+) (TLVStream, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultCustomMessageTimeout)
+		defer cancel()
+	}
+
+	id := make([]byte, 8)
+	rand.Read(id)
+	request := make(TLVStream, len(body)+1)
+	for t, v := range body {
+		request[t] = v
+	}
+	request[RequestIDRecord] = id
+
+	key := pendingKey(peer, responseType, id)
+	waiting := make(chan TLVStream, 1)
+	p.custom.mu.Lock()
+	if p.custom.pending == nil {
+		p.custom.pending = make(map[string]chan TLVStream)
+	}
+	p.custom.pending[key] = waiting
+	p.custom.mu.Unlock()
+
+	defer func() {
+		p.custom.mu.Lock()
+		delete(p.custom.pending, key)
+		p.custom.mu.Unlock()
+	}()
+
+	if err := p.SendCustomMessage(peer, msgType, request); err != nil {
+		return nil, err
+	}
+
+	select {
+	case resp := <-waiting:
+		return resp, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func pendingKey(peer string, msgType uint16, id []byte) string {
+	return fmt.Sprintf("%s/%d/%x", peer, msgType, id)
+}
+
+// setFeatureBits sets bits in a hex-encoded feature bitfield.
+func setFeatureBits(features string, bits []int) string {
+	current, _ := hex.DecodeString(features)
+
+	size := len(current)
+	for _, bit := range bits {
+		if bit/8+1 > size {
+			size = bit/8 + 1
+		}
+	}
+
+	// bit 0 is the least significant bit of the last byte
+	field := make([]byte, size)
+	copy(field[size-len(current):], current)
+	for _, bit := range bits {
+		field[size-1-bit/8] |= 1 << (bit % 8)
+	}
+	return hex.EncodeToString(field)
+}
+
+// peerFeatureBit tells if a peer (an item from listpeers or listnodes) has a feature bit set.
+func peerFeatureBit(peer gjson.Result, bit int) bool {
+	features, err := hex.DecodeString(peer.Get("features").String())
+	if err != nil || bit/8 >= len(features) {
+		return false
+	}
+	return features[len(features)-1-bit/8]&(1<<(bit%8)) != 0
+}
+
+// PeerSupports tells if a connected peer advertises a feature bit, so it is
+// worth sending it our custom messages.
+func (p *Plugin) PeerSupports(peer string, bit int) (bool, error) {
+	if p.Client == nil {
+		return false, errors.New("plugin is not initialized")
+	}
+	res, err := p.Client.CallNamed("listpeers", "id", peer)
+	if err != nil {
+		return false, err
+	}
+	return peerFeatureBit(res.Get("peers.0"), bit), nil
+}
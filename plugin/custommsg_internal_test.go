@@ -0,0 +1,39 @@
+package plugin
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSetupCustomMessagesOnce(t *testing.T) {
+	p := &Plugin{
+		FeatureBits: []int{101},
+		CustomMessages: []CustomMessageHandler{
+			{Type: 32769, Handler: func(p *Plugin, peer string, body TLVStream) (TLVStream, error) { return nil, nil }},
+		},
+	}
+	if err := p.setupCustomMessages(); err != nil {
+		t.Fatal(err)
+	}
+	handlers := reflect.ValueOf(p.custom.handlers).Pointer()
+	hook := reflect.ValueOf(p.Hooks[0].Handler).Pointer()
+
+	if err := p.setupCustomMessages(); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Hooks) != 1 || reflect.ValueOf(p.Hooks[0].Handler).Pointer() != hook {
+		t.Error("custommsg hook registered again")
+	}
+	if reflect.ValueOf(p.custom.handlers).Pointer() != handlers {
+		t.Error("handlers registered again")
+	}
+}
+
+func TestSetupCustomMessagesInvalidType(t *testing.T) {
+	for _, h := range []CustomMessageHandler{{Type: 32768}, {Type: 101}, {Type: 32769, ResponseType: 32770}} {
+		p := &Plugin{CustomMessages: []CustomMessageHandler{h}}
+		if err := p.setupCustomMessages(); err == nil {
+			t.Errorf("%d/%d accepted", h.Type, h.ResponseType)
+		}
+	}
+}
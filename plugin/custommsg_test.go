@@ -0,0 +1,207 @@
+package plugin_test
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fiatjaf/lightningd-gjson-rpc/plugin"
+	"github.com/fiatjaf/lightningd-gjson-rpc/plugin/plugintest"
+	"github.com/tidwall/gjson"
+)
+
+const (
+	aliceID = "02aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	bobID   = "03bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+
+	pingType = 32769
+	pongType = 32771
+)
+
+// connect makes messages sent by each plugin arrive at the other one's
+// custommsg hook, like two nodes running the same plugin.
+func connect(t *testing.T, alice, bob *plugintest.Harness) {
+	forward := func(to *plugintest.Harness, from string) func(gjson.Result) (interface{}, error) {
+		return func(params gjson.Result) (interface{}, error) {
+			_, err := to.Hook("custommsg", map[string]interface{}{
+				"peer_id": from,
+				"payload": params.Get("msg").String(),
+			})
+			return map[string]interface{}{"status": "Message sent to connectd for delivery"}, err
+		}
+	}
+	alice.RPC.Handle("sendcustommsg", forward(bob, aliceID))
+	bob.RPC.Handle("sendcustommsg", forward(alice, bobID))
+}
+
+func pingPlugin(pings *int32) *plugin.Plugin {
+	return &plugin.Plugin{
+		Name:        "ping",
+		FeatureBits: []int{101},
+		CustomMessages: []plugin.CustomMessageHandler{
+			{
+				Type:         pingType,
+				ResponseType: pongType,
+				Handler: func(p *plugin.Plugin, peer string, body plugin.TLVStream) (plugin.TLVStream, error) {
+					atomic.AddInt32(pings, 1)
+					n, _ := body.Uint64(1)
+					resp := plugin.TLVStream{3: []byte(peer)}
+					resp.SetUint64(1, n+1)
+					return resp, nil
+				},
+			},
+		},
+	}
+}
+
+func TestCustomMessageRequest(t *testing.T) {
+	var alicePings, bobPings int32
+	alice := plugintest.New(t, pingPlugin(&alicePings))
+	bob := plugintest.New(t, pingPlugin(&bobPings))
+	alice.Start(t, nil)
+	bob.Start(t, nil)
+	connect(t, alice, bob)
+
+	body := plugin.TLVStream{}
+	body.SetUint64(1, 41)
+	resp, err := bob.Plugin.RequestCustomMessage(context.Background(), aliceID, pingType, pongType, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := resp.Uint64(1); n != 42 {
+		t.Errorf("got %d", n)
+	}
+	if string(resp[3]) != bobID {
+		t.Errorf("alice saw the message coming from %q", resp[3])
+	}
+	if _, ok := resp[plugin.RequestIDRecord]; !ok {
+		t.Error("response without the request id")
+	}
+	if atomic.LoadInt32(&alicePings) != 1 || atomic.LoadInt32(&bobPings) != 0 {
+		t.Errorf("alice handled %d pings, bob %d", alicePings, bobPings)
+	}
+
+	// what was sent is a valid message: the type and a TLV stream
+	sent := bob.RPC.Calls("sendcustommsg")[0].Params.Get("msg").String()
+	msg, _ := hex.DecodeString(sent)
+	if !strings.HasPrefix(sent, "8001") {
+		t.Errorf("sent %s", sent)
+	}
+	if _, err := plugin.DecodeTLVStream(msg[2:]); err != nil {
+		t.Errorf("sent %s: %s", sent, err)
+	}
+}
+
+func TestCustomMessageTimeout(t *testing.T) {
+	var pings int32
+	bob := plugintest.New(t, pingPlugin(&pings))
+	bob.Start(t, nil)
+	bob.RPC.Respond("sendcustommsg", map[string]interface{}{"status": "Message sent to connectd for delivery"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+	_, err := bob.Plugin.RequestCustomMessage(ctx, aliceID, pingType, pongType, plugin.TLVStream{})
+	if err != context.DeadlineExceeded {
+		t.Fatalf("expected a timeout, got %v", err)
+	}
+
+	// a late response is ignored
+	late := plugin.TLVStream{plugin.RequestIDRecord: []byte("12345678")}
+	res, err := bob.Hook("custommsg", map[string]interface{}{
+		"peer_id": aliceID,
+		"payload": hex.EncodeToString(append([]byte{0x80, 0x03}, late.Encode()...)),
+	})
+	if err != nil || res.Get("result").String() != "continue" {
+		t.Errorf("hook returned %s, %v", res.Raw, err)
+	}
+}
+
+func TestCustomMessageFeatureBits(t *testing.T) {
+	var pings int32
+	alice := plugintest.New(t, pingPlugin(&pings))
+	manifest, err := alice.Manifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	features := manifest.Get("featurebits")
+	if features.Get("node").String() != "20000000000000000000000000" ||
+		features.Get("init").String() != "20000000000000000000000000" {
+		t.Fatalf("featurebits = %s", features.Raw)
+	}
+	alice.Init(nil)
+
+	alice.RPC.Respond("listpeers", map[string]interface{}{
+		"peers": []interface{}{map[string]interface{}{"id": bobID, "features": features.Get("init").String()}},
+	})
+	if ok, err := alice.Plugin.PeerSupports(bobID, 101); !ok || err != nil {
+		t.Errorf("bit 101: %v, %v", ok, err)
+	}
+	if ok, _ := alice.Plugin.PeerSupports(bobID, 103); ok {
+		t.Error("bit 103 is set")
+	}
+}
+
+func runTwice(t *testing.T, p *plugin.Plugin) {
+	for i := 0; i < 2; i++ {
+		p.Stdin = strings.NewReader("")
+		p.Stdout = io.Discard
+		if err := p.Listener(make(chan bool, 1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// Listener can be called more than once, messages must still be handled once.
+func TestCustomMessageWrapsHook(t *testing.T) {
+	var pings, others int32
+	p := pingPlugin(&pings)
+	p.Hooks = []plugin.Hook{
+		plugin.CustomMsgHook(func(p *plugin.Plugin, req plugin.CustomMsg) plugin.ContinueResponse {
+			atomic.AddInt32(&others, 1)
+			return plugin.Continue()
+		}),
+	}
+
+	runTwice(t, p)
+
+	h := plugintest.New(t, p)
+	manifest, err := h.Manifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hooks := manifest.Get("hooks").Raw; hooks != `[{"name":"custommsg"}]` {
+		t.Errorf("hooks = %s", hooks)
+	}
+	if features := manifest.Get("featurebits.node").String(); features != "20000000000000000000000000" {
+		t.Errorf("featurebits.node = %s", features)
+	}
+	h.Init(nil)
+	h.RPC.Respond("sendcustommsg", map[string]interface{}{})
+
+	ping := plugin.TLVStream{}
+	ping.SetUint64(1, 1)
+	for _, payload := range []string{
+		"8001" + hex.EncodeToString(ping.Encode()),
+		"8005" + hex.EncodeToString(ping.Encode()),
+	} {
+		if _, err := h.Hook("custommsg", map[string]interface{}{"peer_id": bobID, "payload": payload}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(&pings) == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond * 10)
+	}
+	time.Sleep(time.Millisecond * 50)
+	if n := atomic.LoadInt32(&pings); n != 1 {
+		t.Errorf("ping handled %d times", n)
+	}
+	if n := atomic.LoadInt32(&others); n != 1 {
+		t.Errorf("the plugin's own hook got %d messages, expected only the unknown one", n)
+	}
+}
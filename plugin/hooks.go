@@ -0,0 +1,387 @@
+package plugin
+
+import (
+	"encoding/json"
+
+	lightning "github.com/fiatjaf/lightningd-gjson-rpc"
+)
+
+// NewHook creates a hook whose payload is decoded into Req and whose response
+// is encoded from Resp. Prefer the specific constructors below (HtlcAcceptedHook
+// etc.), which have the right types for each hook.
+//
+// If the payload can't be decoded the error is logged and lightningd is told
+// to continue.
+func NewHook[Req interface{}, Resp interface{}](hookType string, handler func(p *Plugin, req Req) Resp) Hook {
+	return Hook{
+		Type: hookType,
+		Handler: func(p *Plugin, params Params) interface{} {
+			var req Req
+			j, _ := json.Marshal(params)
+			if err := json.Unmarshal(j, &req); err != nil {
+				p.Brokenf("failed to decode %s payload: %s", hookType, err)
+				return map[string]interface{}{"result": "continue"}
+			}
+			return handler(p, req)
+		},
+	}
+}
+
+const (
+	resultContinue   = "continue"
+	resultFail       = "fail"
+	resultResolve    = "resolve"
+	resultReject     = "reject"
+	resultDisconnect = "disconnect"
+)
+
+// htlc_accepted
+
+type HtlcAccepted struct {
+	Onion struct {
+		Payload           string         `json:"payload"`
+		ShortChannelID    string         `json:"short_channel_id,omitempty"`
+		ForwardMsat       lightning.Msat `json:"forward_msat,omitempty"`
+		OutgoingCltvValue uint32         `json:"outgoing_cltv_value,omitempty"`
+		TotalMsat         lightning.Msat `json:"total_msat,omitempty"`
+		PaymentSecret     string         `json:"payment_secret,omitempty"`
+		PaymentMetadata   string         `json:"payment_metadata,omitempty"`
+		SharedSecret      string         `json:"shared_secret,omitempty"`
+		NextOnion         string         `json:"next_onion,omitempty"`
+		Type              string         `json:"type,omitempty"`
+	} `json:"onion"`
+	Htlc struct {
+		ShortChannelID     string         `json:"short_channel_id"`
+		ID                 uint64         `json:"id"`
+		AmountMsat         lightning.Msat `json:"amount_msat"`
+		CltvExpiry         uint32         `json:"cltv_expiry"`
+		CltvExpiryRelative int32          `json:"cltv_expiry_relative"`
+		PaymentHash        string         `json:"payment_hash"`
+		ExtraTlvs          string         `json:"extra_tlvs,omitempty"`
+	} `json:"htlc"`
+	ForwardTo string `json:"forward_to,omitempty"`
+}
+
+type HtlcAcceptedResponse struct {
+	Result         string `json:"result"`
+	Payload        string `json:"payload,omitempty"`
+	ForwardTo      string `json:"forward_to,omitempty"`
+	ExtraTlvs      string `json:"extra_tlvs,omitempty"`
+	FailureMessage string `json:"failure_message,omitempty"`
+	FailureOnion   string `json:"failure_onion,omitempty"`
+	PaymentKey     string `json:"payment_key,omitempty"`
+}
+
+func HtlcAcceptedHook(handler func(p *Plugin, req HtlcAccepted) HtlcAcceptedResponse) Hook {
+	return NewHook("htlc_accepted", handler)
+}
+
+// HtlcContinue lets lightningd handle the HTLC as usual.
+func HtlcContinue() HtlcAcceptedResponse { return HtlcAcceptedResponse{Result: resultContinue} }
+
+// HtlcFail fails the HTLC with a hex-encoded BOLT#4 failure message, like "2002" (temporary_node_failure).
+func HtlcFail(failureMessage string) HtlcAcceptedResponse {
+	return HtlcAcceptedResponse{Result: resultFail, FailureMessage: failureMessage}
+}
+
+// HtlcResolve settles the HTLC with the hex-encoded preimage.
+func HtlcResolve(paymentKey string) HtlcAcceptedResponse {
+	return HtlcAcceptedResponse{Result: resultResolve, PaymentKey: paymentKey}
+}
+
+// peer_connected
+
+type PeerConnected struct {
+	Peer struct {
+		ID        string `json:"id"`
+		Direction string `json:"direction"`
+		Addr      string `json:"addr"`
+		Features  string `json:"features"`
+	} `json:"peer"`
+}
+
+type PeerConnectedResponse struct {
+	Result       string `json:"result"`
+	ErrorMessage string `json:"error_message,omitempty"`
+}
+
+func PeerConnectedHook(handler func(p *Plugin, req PeerConnected) PeerConnectedResponse) Hook {
+	return NewHook("peer_connected", handler)
+}
+
+func PeerContinue() PeerConnectedResponse { return PeerConnectedResponse{Result: resultContinue} }
+
+// PeerDisconnect disconnects the peer, sending errorMessage to it if not empty.
+func PeerDisconnect(errorMessage string) PeerConnectedResponse {
+	return PeerConnectedResponse{Result: resultDisconnect, ErrorMessage: errorMessage}
+}
+
+// openchannel
+
+type OpenChannel struct {
+	OpenChannel struct {
+		ID                       string         `json:"id"`
+		FundingMsat              lightning.Msat `json:"funding_msat"`
+		PushMsat                 lightning.Msat `json:"push_msat"`
+		DustLimitMsat            lightning.Msat `json:"dust_limit_msat"`
+		MaxHtlcValueInFlightMsat lightning.Msat `json:"max_htlc_value_in_flight_msat"`
+		ChannelReserveMsat       lightning.Msat `json:"channel_reserve_msat"`
+		HtlcMinimumMsat          lightning.Msat `json:"htlc_minimum_msat"`
+		FeeratePerKw             uint32         `json:"feerate_per_kw"`
+		ToSelfDelay              uint32         `json:"to_self_delay"`
+		MaxAcceptedHtlcs         uint32         `json:"max_accepted_htlcs"`
+		ChannelFlags             uint8          `json:"channel_flags"`
+		ShutdownScriptpubkey     string         `json:"shutdown_scriptpubkey,omitempty"`
+		ChannelType              *ChannelType   `json:"channel_type,omitempty"`
+	} `json:"openchannel"`
+}
+
+type ChannelType struct {
+	Bits  []uint32 `json:"bits"`
+	Names []string `json:"names"`
+}
+
+type OpenChannelResponse struct {
+	Result       string `json:"result"`
+	CloseTo      string `json:"close_to,omitempty"`
+	Mindepth     *int   `json:"mindepth,omitempty"`
+	Reserve      string `json:"reserve,omitempty"`
+	ErrorMessage string `json:"error_message,omitempty"`
+}
+
+func OpenChannelHook(handler func(p *Plugin, req OpenChannel) OpenChannelResponse) Hook {
+	return NewHook("openchannel", handler)
+}
+
+func OpenChannelContinue() OpenChannelResponse { return OpenChannelResponse{Result: resultContinue} }
+
+func OpenChannelReject(errorMessage string) OpenChannelResponse {
+	return OpenChannelResponse{Result: resultReject, ErrorMessage: errorMessage}
+}
+
+// openchannel2
+
+type OpenChannel2 struct {
+	OpenChannel2 struct {
+		ID                       string         `json:"id"`
+		ChannelID                string         `json:"channel_id"`
+		TheirFundingMsat         lightning.Msat `json:"their_funding_msat"`
+		DustLimitMsat            lightning.Msat `json:"dust_limit_msat"`
+		MaxHtlcValueInFlightMsat lightning.Msat `json:"max_htlc_value_in_flight_msat"`
+		HtlcMinimumMsat          lightning.Msat `json:"htlc_minimum_msat"`
+		FundingFeeratePerKw      uint32         `json:"funding_feerate_per_kw"`
+		CommitmentFeeratePerKw   uint32         `json:"commitment_feerate_per_kw"`
+		FeerateOurMax            uint32         `json:"feerate_our_max"`
+		FeerateOurMin            uint32         `json:"feerate_our_min"`
+		ToSelfDelay              uint32         `json:"to_self_delay"`
+		MaxAcceptedHtlcs         uint32         `json:"max_accepted_htlcs"`
+		ChannelFlags             uint8          `json:"channel_flags"`
+		Locktime                 uint32         `json:"locktime"`
+		ShutdownScriptpubkey     string         `json:"shutdown_scriptpubkey,omitempty"`
+		ChannelMaxMsat           lightning.Msat `json:"channel_max_msat,omitempty"`
+		RequestedLeaseMsat       lightning.Msat `json:"requested_lease_msat,omitempty"`
+		LeaseBlockheightStart    uint32         `json:"lease_blockheight_start,omitempty"`
+		NodeBlockheight          uint32         `json:"node_blockheight,omitempty"`
+		RequireConfirmedInputs   bool           `json:"require_confirmed_inputs,omitempty"`
+		ChannelType              *ChannelType   `json:"channel_type,omitempty"`
+	} `json:"openchannel2"`
+}
+
+// OpenChannel2Response is also the response to rbf_channel, which doesn't use CloseTo.
+type OpenChannel2Response struct {
+	Result         string         `json:"result"`
+	Psbt           string         `json:"psbt,omitempty"`
+	OurFundingMsat lightning.Msat `json:"our_funding_msat,omitempty"`
+	CloseTo        string         `json:"close_to,omitempty"`
+	ErrorMessage   string         `json:"error_message,omitempty"`
+}
+
+func OpenChannel2Hook(handler func(p *Plugin, req OpenChannel2) OpenChannel2Response) Hook {
+	return NewHook("openchannel2", handler)
+}
+
+// OpenChannel2Continue accepts the channel. To add funds from our side pass the
+// psbt with our inputs and the amount, or "" and 0 to not contribute.
+func OpenChannel2Continue(psbt string, ourFunding lightning.Msat) OpenChannel2Response {
+	return OpenChannel2Response{Result: resultContinue, Psbt: psbt, OurFundingMsat: ourFunding}
+}
+
+func OpenChannel2Reject(errorMessage string) OpenChannel2Response {
+	return OpenChannel2Response{Result: resultReject, ErrorMessage: errorMessage}
+}
+
+// rbf_channel
+
+type RbfChannel struct {
+	RbfChannel struct {
+		ID                     string         `json:"id"`
+		ChannelID              string         `json:"channel_id"`
+		TheirLastFundingMsat   lightning.Msat `json:"their_last_funding_msat"`
+		TheirFundingMsat       lightning.Msat `json:"their_funding_msat"`
+		OurLastFundingMsat     lightning.Msat `json:"our_last_funding_msat"`
+		FundingFeeratePerKw    uint32         `json:"funding_feerate_per_kw"`
+		FeerateOurMax          uint32         `json:"feerate_our_max"`
+		FeerateOurMin          uint32         `json:"feerate_our_min"`
+		ChannelMaxMsat         lightning.Msat `json:"channel_max_msat,omitempty"`
+		Locktime               uint32         `json:"locktime"`
+		RequestedLeaseMsat     lightning.Msat `json:"requested_lease_msat,omitempty"`
+		RequireConfirmedInputs bool           `json:"require_confirmed_inputs,omitempty"`
+	} `json:"rbf_channel"`
+}
+
+func RbfChannelHook(handler func(p *Plugin, req RbfChannel) OpenChannel2Response) Hook {
+	return NewHook("rbf_channel", handler)
+}
+
+// invoice_payment
+
+type InvoicePayment struct {
+	Payment struct {
+		Label    string         `json:"label"`
+		Preimage string         `json:"preimage"`
+		Msat     lightning.Msat `json:"msat"`
+	} `json:"payment"`
+}
+
+type InvoicePaymentResponse struct {
+	Result         string `json:"result"`
+	FailureMessage string `json:"failure_message,omitempty"`
+}
+
+func InvoicePaymentHook(handler func(p *Plugin, req InvoicePayment) InvoicePaymentResponse) Hook {
+	return NewHook("invoice_payment", handler)
+}
+
+func InvoicePaymentContinue() InvoicePaymentResponse {
+	return InvoicePaymentResponse{Result: resultContinue}
+}
+
+// InvoicePaymentReject fails the payment with incorrect_or_unknown_payment_details.
+func InvoicePaymentReject() InvoicePaymentResponse {
+	return InvoicePaymentResponse{Result: resultReject}
+}
+
+// rpc_command
+
+type RPCCommand struct {
+	RPCCommand struct {
+		ID     interface{}     `json:"id"`
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	} `json:"rpc_command"`
+}
+
+// RPCCommandResponse either continues, replaces the request with another one
+// or returns a result or error directly to the caller.
+type RPCCommandResponse struct {
+	Result  string                    `json:"result,omitempty"`
+	Replace *lightning.JSONRPCMessage `json:"replace,omitempty"`
+	Return  *RPCCommandReturn         `json:"return,omitempty"`
+}
+
+type RPCCommandReturn struct {
+	Result interface{}             `json:"result,omitempty"`
+	Error  *lightning.JSONRPCError `json:"error,omitempty"`
+}
+
+func RPCCommandHook(handler func(p *Plugin, req RPCCommand) RPCCommandResponse) Hook {
+	return NewHook("rpc_command", handler)
+}
+
+func RPCCommandContinue() RPCCommandResponse { return RPCCommandResponse{Result: resultContinue} }
+
+// RPCCommandReplace runs another command instead. Its id must be the same as the original's.
+func RPCCommandReplace(message lightning.JSONRPCMessage) RPCCommandResponse {
+	if message.Version == "" {
+		message.Version = "2.0"
+	}
+	return RPCCommandResponse{Replace: &message}
+}
+
+func RPCCommandReturnResult(result interface{}) RPCCommandResponse {
+	return RPCCommandResponse{Return: &RPCCommandReturn{Result: result}}
+}
+
+func RPCCommandReturnError(code int, message string) RPCCommandResponse {
+	return RPCCommandResponse{Return: &RPCCommandReturn{
+		Error: &lightning.JSONRPCError{Code: code, Message: message},
+	}}
+}
+
+// db_write
+
+type DBWrite struct {
+	DataVersion uint32   `json:"data_version"`
+	Writes      []string `json:"writes"`
+}
+
+// DBWriteResponse can only continue, anything else makes lightningd abort.
+type DBWriteResponse struct {
+	Result string `json:"result"`
+}
+
+func DBWriteHook(handler func(p *Plugin, req DBWrite) DBWriteResponse) Hook {
+	return NewHook("db_write", handler)
+}
+
+func DBWriteContinue() DBWriteResponse { return DBWriteResponse{Result: resultContinue} }
+
+// hooks that can only continue
+
+type ContinueResponse struct {
+	Result string `json:"result"`
+}
+
+func Continue() ContinueResponse { return ContinueResponse{Result: resultContinue} }
+
+// custommsg
+
+type CustomMsg struct {
+	PeerID  string `json:"peer_id"`
+	Payload string `json:"payload"`
+}
+
+func CustomMsgHook(handler func(p *Plugin, req CustomMsg) ContinueResponse) Hook {
+	return NewHook("custommsg", handler)
+}
+
+// onion_message_recv
+
+type OnionMessageRecv struct {
+	OnionMessage struct {
+		ReplyBlindedpath *struct {
+			FirstNodeID  string `json:"first_node_id,omitempty"`
+			FirstScid    string `json:"first_scid,omitempty"`
+			FirstScidDir *int   `json:"first_scid_dir,omitempty"`
+			FirstPathKey string `json:"first_path_key,omitempty"`
+			Hops         []struct {
+				BlindedNodeID          string `json:"blinded_node_id"`
+				EncryptedRecipientData string `json:"encrypted_recipient_data"`
+			} `json:"hops"`
+		} `json:"reply_blindedpath,omitempty"`
+		InvoiceRequest string `json:"invoice_request,omitempty"`
+		Invoice        string `json:"invoice,omitempty"`
+		InvoiceError   string `json:"invoice_error,omitempty"`
+		UnknownFields  []struct {
+			Number uint64 `json:"number"`
+			Value  string `json:"value"`
+		} `json:"unknown_fields,omitempty"`
+	} `json:"onion_message"`
+}
+
+func OnionMessageRecvHook(handler func(p *Plugin, req OnionMessageRecv) ContinueResponse) Hook {
+	return NewHook("onion_message_recv", handler)
+}
+
+// commitment_revocation
+
+type CommitmentRevocation struct {
+	CommitmentTxid string `json:"commitment_txid"`
+	PenaltyTx      string `json:"penalty_tx"`
+	ChannelID      string `json:"channel_id"`
+	Commitnum      uint64 `json:"commitnum"`
+}
+
+func CommitmentRevocationHook(handler func(p *Plugin, req CommitmentRevocation) ContinueResponse) Hook {
+	return NewHook("commitment_revocation", handler)
+}
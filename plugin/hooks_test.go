@@ -0,0 +1,121 @@
+package plugin_test
+
+import (
+	"testing"
+
+	lightning "github.com/fiatjaf/lightningd-gjson-rpc"
+	"github.com/fiatjaf/lightningd-gjson-rpc/plugin"
+	"github.com/fiatjaf/lightningd-gjson-rpc/plugin/plugintest"
+)
+
+func TestTypedHooks(t *testing.T) {
+	h := plugintest.New(t, &plugin.Plugin{
+		Name: "hooks",
+		Hooks: []plugin.Hook{
+			plugin.HtlcAcceptedHook(func(p *plugin.Plugin, req plugin.HtlcAccepted) plugin.HtlcAcceptedResponse {
+				switch {
+				case req.Htlc.AmountMsat < 1000:
+					return plugin.HtlcFail("2002")
+				case req.Onion.PaymentSecret == "ss":
+					return plugin.HtlcResolve("00" + req.Htlc.PaymentHash)
+				}
+				return plugin.HtlcContinue()
+			}),
+			plugin.PeerConnectedHook(func(p *plugin.Plugin, req plugin.PeerConnected) plugin.PeerConnectedResponse {
+				return plugin.PeerDisconnect("go away " + req.Peer.ID)
+			}),
+			plugin.RPCCommandHook(func(p *plugin.Plugin, req plugin.RPCCommand) plugin.RPCCommandResponse {
+				switch req.RPCCommand.Method {
+				case "stop":
+					return plugin.RPCCommandReturnError(-1, "not allowed")
+				case "getinfo":
+					return plugin.RPCCommandReplace(lightning.JSONRPCMessage{
+						Id:     req.RPCCommand.ID,
+						Method: "listfunds",
+						Params: map[string]interface{}{},
+					})
+				}
+				return plugin.RPCCommandContinue()
+			}),
+		},
+	})
+	h.Start(t, nil)
+
+	htlc := func(amount interface{}, secret string) map[string]interface{} {
+		return map[string]interface{}{
+			"onion": map[string]interface{}{"payload": "", "payment_secret": secret},
+			"htlc":  map[string]interface{}{"amount_msat": amount, "payment_hash": "ff"},
+		}
+	}
+	for _, c := range []struct {
+		payload  interface{}
+		expected string
+	}{
+		{htlc(999, ""), `{"result":"fail","failure_message":"2002"}`},
+		{htlc("5000msat", "ss"), `{"result":"resolve","payment_key":"00ff"}`},
+		{htlc(5000, ""), `{"result":"continue"}`},
+	} {
+		res, err := h.Hook("htlc_accepted", c.payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Raw != c.expected {
+			t.Errorf("htlc_accepted %v: %s, expected %s", c.payload, res.Raw, c.expected)
+		}
+	}
+
+	res, err := h.Hook("peer_connected", map[string]interface{}{"peer": map[string]interface{}{"id": "02aa"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Raw != `{"result":"disconnect","error_message":"go away 02aa"}` {
+		t.Errorf("peer_connected: %s", res.Raw)
+	}
+
+	command := func(method string) map[string]interface{} {
+		return map[string]interface{}{"rpc_command": map[string]interface{}{"id": 7, "method": method, "params": []interface{}{}}}
+	}
+	for method, expected := range map[string]string{
+		"stop":      `{"return":{"error":{"code":-1,"message":"not allowed","data":null}}}`,
+		"getinfo":   `{"replace":{"jsonrpc":"2.0","id":7,"method":"listfunds","params":{}}}`,
+		"listpeers": `{"result":"continue"}`,
+	} {
+		res, err := h.Hook("rpc_command", command(method))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Raw != expected {
+			t.Errorf("rpc_command %s: %s, expected %s", method, res.Raw, expected)
+		}
+	}
+}
+
+func TestTypedHookBadPayload(t *testing.T) {
+	h := plugintest.New(t, &plugin.Plugin{
+		Name: "hooks",
+		Hooks: []plugin.Hook{
+			plugin.HtlcAcceptedHook(func(p *plugin.Plugin, req plugin.HtlcAccepted) plugin.HtlcAcceptedResponse {
+				t.Error("handler called with a payload that doesn't decode")
+				return plugin.HtlcFail("2002")
+			}),
+		},
+	})
+	h.Start(t, nil)
+
+	res, err := h.Hook("htlc_accepted", map[string]interface{}{"htlc": map[string]interface{}{"amount_msat": "lots"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Raw != `{"result":"continue"}` {
+		t.Errorf("undecodable payload got %s", res.Raw)
+	}
+	broken := false
+	for _, n := range h.Notifications("log") {
+		if n.Params.Get("level").String() == plugin.LogBroken {
+			broken = true
+		}
+	}
+	if !broken {
+		t.Error("decoding failure not logged as broken")
+	}
+}
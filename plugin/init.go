@@ -0,0 +1,111 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	lightning "github.com/fiatjaf/lightningd-gjson-rpc"
+	"github.com/tidwall/gjson"
+)
+
+// InitConfiguration is the "configuration" sent by lightningd on init.
+// Missing fields or fields with unexpected types are left empty.
+type InitConfiguration struct {
+	LightningDir   string
+	RPCFile        string
+	Startup        bool
+	Network        string
+	FeatureSet     FeatureSet
+	Proxy          *Proxy
+	TorV3Enabled   bool
+	AlwaysUseProxy bool
+}
+
+type FeatureSet struct {
+	Init    string
+	Node    string
+	Channel string
+	Invoice string
+}
+
+type Proxy struct {
+	Type    string
+	Address string
+	Port    int
+}
+
+func parseInitConfiguration(conf gjson.Result) InitConfiguration {
+	c := InitConfiguration{
+		LightningDir:   conf.Get("lightning-dir").String(),
+		RPCFile:        conf.Get("rpc-file").String(),
+		Startup:        conf.Get("startup").Bool(),
+		Network:        conf.Get("network").String(),
+		TorV3Enabled:   conf.Get("torv3-enabled").Bool(),
+		AlwaysUseProxy: conf.Get("always_use_proxy").Bool(),
+		FeatureSet: FeatureSet{
+			Init:    conf.Get("feature_set.init").String(),
+			Node:    conf.Get("feature_set.node").String(),
+			Channel: conf.Get("feature_set.channel").String(),
+			Invoice: conf.Get("feature_set.invoice").String(),
+		},
+	}
+	if proxy := conf.Get("proxy"); proxy.IsObject() {
+		c.Proxy = &Proxy{
+			Type:    proxy.Get("type").String(),
+			Address: proxy.Get("address").String(),
+			Port:    int(proxy.Get("port").Int()),
+		}
+	}
+	return c
+}
+
+// RPCPath is the full path of lightningd's RPC socket.
+func (c InitConfiguration) RPCPath() string {
+	if filepath.IsAbs(c.RPCFile) {
+		return c.RPCFile
+	}
+	return filepath.Join(c.LightningDir, c.RPCFile)
+}
+
+// handleInit handles the init call. If the plugin can't run it returns the reason,
+// which is sent to lightningd as {"disable": reason}.
+func (p *Plugin) handleInit(iparams interface{}) (disable string) {
+	j, _ := json.Marshal(iparams)
+	params := gjson.ParseBytes(j)
+
+	conf, _ := params.Get("configuration").Value().(map[string]interface{})
+	p.Configuration = Params(conf)
+	p.InitConfiguration = parseInitConfiguration(params.Get("configuration"))
+	p.Network = p.InitConfiguration.Network
+
+	p.Client = &lightning.Client{
+		Path:         p.InitConfiguration.RPCPath(),
+		LightningDir: p.InitConfiguration.LightningDir,
+	}
+
+	options, _ := params.Get("options").Value().(map[string]interface{})
+	args, err := validateOptions(p.Options, options)
+	if err != nil {
+		return "invalid options: " + err.Error()
+	}
+	p.argsMutex.Lock()
+	p.Args = args
+	p.argsMutex.Unlock()
+
+	if p.CheckInit != nil {
+		if err := p.CheckInit(p); err != nil {
+			return err.Error()
+		}
+	}
+
+	return ""
+}
+
+// ErrorDisabled is returned by Run when the plugin disabled itself on init,
+// because of invalid options or CheckInit.
+type ErrorDisabled struct {
+	Reason string
+}
+
+func (e ErrorDisabled) Error() string { return fmt.Sprintf("plugin disabled: %s", e.Reason) }
@@ -0,0 +1,43 @@
+package plugin_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fiatjaf/lightningd-gjson-rpc/plugin"
+	"github.com/fiatjaf/lightningd-gjson-rpc/plugin/plugintest"
+)
+
+func TestCheckInitDisables(t *testing.T) {
+	initialized := false
+	h := plugintest.New(t, &plugin.Plugin{
+		Name: "picky",
+		CheckInit: func(p *plugin.Plugin) error {
+			if p.Network != "bitcoin" {
+				return errors.New("only runs on mainnet")
+			}
+			return nil
+		},
+		OnInit: func(p *plugin.Plugin) { initialized = true },
+	})
+	if _, err := h.Manifest(); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := h.Init(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reason := res.Get("disable").String(); reason != "only runs on mainnet" {
+		t.Errorf("init returned %s", res.Raw)
+	}
+
+	err = h.Wait()
+	var disabled plugin.ErrorDisabled
+	if !errors.As(err, &disabled) || disabled.Reason != "only runs on mainnet" {
+		t.Errorf("Run returned %v", err)
+	}
+	if initialized {
+		t.Error("OnInit called on a disabled plugin")
+	}
+}
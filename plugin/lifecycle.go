@@ -0,0 +1,56 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DefaultShutdownTimeout is how long we wait for running handlers on shutdown.
+// lightningd kills plugins that take more than 30 seconds to exit.
+var DefaultShutdownTimeout = time.Second * 25
+
+// Context is cancelled when the plugin starts shutting down. Use it to stop
+// whatever was started in OnInit or in handlers.
+func (p *Plugin) Context() context.Context {
+	p.contextMutex.Lock()
+	defer p.contextMutex.Unlock()
+	if p.ctx == nil {
+		p.ctx, p.cancel = context.WithCancel(context.Background())
+	}
+	return p.ctx
+}
+
+// shutdown cancels the context, waits for the handlers still running and
+// calls OnShutdown.
+func (p *Plugin) shutdown() error {
+	p.contextMutex.Lock()
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.contextMutex.Unlock()
+
+	timeout := p.ShutdownTimeout
+	if timeout == 0 {
+		timeout = DefaultShutdownTimeout
+	}
+
+	done := make(chan struct{})
+	go func() {
+		p.inflight.Wait()
+		close(done)
+	}()
+
+	var err error
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		err = fmt.Errorf("handlers still running after %s", timeout)
+		p.Unusualf("shutting down with %s", err)
+	}
+
+	if p.OnShutdown != nil {
+		p.OnShutdown(p)
+	}
+	return err
+}
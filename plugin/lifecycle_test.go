@@ -0,0 +1,75 @@
+package plugin_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fiatjaf/lightningd-gjson-rpc/plugin"
+	"github.com/fiatjaf/lightningd-gjson-rpc/plugin/plugintest"
+)
+
+func TestShutdownSubscription(t *testing.T) {
+	onShutdown := func(p *plugin.Plugin) {}
+	subscribe := func(types ...string) []plugin.Subscription {
+		subs := make([]plugin.Subscription, len(types))
+		for i, typ := range types {
+			subs[i] = plugin.Subscription{Type: typ, Handler: func(p *plugin.Plugin, params plugin.Params) {}}
+		}
+		return subs
+	}
+
+	for _, c := range []struct {
+		plugin   *plugin.Plugin
+		expected string
+	}{
+		{&plugin.Plugin{Name: "nothing"}, `[]`},
+		{&plugin.Plugin{Name: "onshutdown", OnShutdown: onShutdown}, `["shutdown"]`},
+		{&plugin.Plugin{Name: "both", OnShutdown: onShutdown, Subscriptions: subscribe("connect")}, `["connect","shutdown"]`},
+		{&plugin.Plugin{Name: "subscribed", OnShutdown: onShutdown, Subscriptions: subscribe("shutdown")}, `["shutdown"]`},
+	} {
+		h := plugintest.New(t, c.plugin)
+		manifest, err := h.Manifest()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := manifest.Get("subscriptions").Raw; got != c.expected {
+			t.Errorf("%s: subscriptions = %s, expected %s", c.plugin.Name, got, c.expected)
+		}
+	}
+}
+
+func TestShutdownWaitsForCustomMessages(t *testing.T) {
+	started := make(chan struct{})
+	var handled, handledBeforeShutdown int32
+	h := plugintest.New(t, &plugin.Plugin{
+		Name: "slow",
+		CustomMessages: []plugin.CustomMessageHandler{
+			{
+				Type: pingType,
+				Handler: func(p *plugin.Plugin, peer string, body plugin.TLVStream) (plugin.TLVStream, error) {
+					close(started)
+					time.Sleep(time.Millisecond * 100)
+					atomic.StoreInt32(&handled, 1)
+					return nil, nil
+				},
+			},
+		},
+		OnShutdown: func(p *plugin.Plugin) {
+			atomic.StoreInt32(&handledBeforeShutdown, atomic.LoadInt32(&handled))
+		},
+	})
+	h.Start(t, nil)
+
+	if _, err := h.Hook("custommsg", map[string]interface{}{"peer_id": aliceID, "payload": "8001"}); err != nil {
+		t.Fatal(err)
+	}
+	<-started
+
+	if err := h.Shutdown(); err != nil {
+		t.Fatal(err)
+	}
+	if atomic.LoadInt32(&handledBeforeShutdown) != 1 {
+		t.Error("OnShutdown was called before the custom message handler returned")
+	}
+}
@@ -0,0 +1,54 @@
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// log levels understood by lightningd
+const (
+	LogDebug   = "debug"
+	LogInfo    = "info"
+	LogUnusual = "unusual"
+	LogBroken  = "broken"
+)
+
+func (p *Plugin) Debugf(format string, args ...interface{})   { p.logf(LogDebug, format, args...) }
+func (p *Plugin) Infof(format string, args ...interface{})    { p.logf(LogInfo, format, args...) }
+func (p *Plugin) Unusualf(format string, args ...interface{}) { p.logf(LogUnusual, format, args...) }
+func (p *Plugin) Brokenf(format string, args ...interface{})  { p.logf(LogBroken, format, args...) }
+
+// logf sends a log notification to lightningd, so it shows up in its log
+// filtered by log-level. Each line is sent separately.
+func (p *Plugin) logf(level string, format string, args ...interface{}) {
+	message := strings.TrimRight(fmt.Sprintf(format, args...), "\n ")
+
+	if !p.LogToStderr {
+		sent := true
+		for _, line := range strings.Split(message, "\n") {
+			err := p.write(notification{
+				Version: "2.0",
+				Method:  "log",
+				Params:  map[string]string{"level": level, "message": line},
+			})
+			if err != nil {
+				sent = false
+				break
+			}
+		}
+		if sent {
+			return
+		}
+	}
+
+	// stderr mode, or the plugin isn't running yet
+	stderr := p.stderr
+	if stderr == nil {
+		stderr = &LogProxy{prefix: "plugin-" + p.Name, target: os.Stderr}
+	}
+	if level != LogInfo {
+		message = strings.ToUpper(level) + " " + message
+	}
+	fmt.Fprint(stderr, message)
+}
@@ -0,0 +1,67 @@
+package plugin_test
+
+import (
+	"testing"
+
+	"github.com/fiatjaf/lightningd-gjson-rpc/plugin"
+	"github.com/fiatjaf/lightningd-gjson-rpc/plugin/plugintest"
+)
+
+func loggingPlugin(stderr bool) *plugin.Plugin {
+	return &plugin.Plugin{
+		Name:        "logger",
+		LogToStderr: stderr,
+		RPCMethods: []plugin.RPCMethod{{
+			Name: "log",
+			Handler: func(p *plugin.Plugin, params plugin.Params) (interface{}, int, error) {
+				p.Debugf("debug %d", 1)
+				p.Infof("info")
+				p.Unusualf("unusual\nsecond line\n")
+				p.Brokenf("broken")
+				p.Logf("plain %s", "log")
+				return map[string]interface{}{}, 0, nil
+			},
+		}},
+	}
+}
+
+func TestLogLevels(t *testing.T) {
+	h := plugintest.New(t, loggingPlugin(false))
+	h.Start(t, nil)
+
+	before := len(h.Notifications("log"))
+	if _, err := h.Call("log", nil); err != nil {
+		t.Fatal(err)
+	}
+	logs := h.Notifications("log")[before:]
+
+	expected := [][2]string{
+		{plugin.LogDebug, "debug 1"},
+		{plugin.LogInfo, "info"},
+		{plugin.LogUnusual, "unusual"},
+		{plugin.LogUnusual, "second line"},
+		{plugin.LogBroken, "broken"},
+		{plugin.LogInfo, "plain log"},
+	}
+	if len(logs) != len(expected) {
+		t.Fatalf("got %d log notifications: %v", len(logs), logs)
+	}
+	for i, n := range logs {
+		level, message := n.Params.Get("level").String(), n.Params.Get("message").String()
+		if level != expected[i][0] || message != expected[i][1] {
+			t.Errorf("log %d: %s %q, expected %s %q", i, level, message, expected[i][0], expected[i][1])
+		}
+	}
+}
+
+func TestLogToStderr(t *testing.T) {
+	h := plugintest.New(t, loggingPlugin(true))
+	h.Start(t, nil)
+
+	if _, err := h.Call("log", nil); err != nil {
+		t.Fatal(err)
+	}
+	if logs := h.Notifications("log"); len(logs) != 0 {
+		t.Errorf("sent %d log notifications with LogToStderr", len(logs))
+	}
+}
@@ -0,0 +1,83 @@
+package plugin_test
+
+import (
+	"testing"
+
+	"github.com/fiatjaf/lightningd-gjson-rpc/plugin"
+	"github.com/fiatjaf/lightningd-gjson-rpc/plugin/plugintest"
+)
+
+func noop(p *plugin.Plugin, params plugin.Params) interface{} {
+	return map[string]interface{}{"result": "continue"}
+}
+
+func TestManifestHooks(t *testing.T) {
+	for expected, hooks := range map[string][]plugin.Hook{
+		`[{"name":"htlc_accepted","before":["a.py"]}]`: {
+			{Type: "htlc_accepted", Handler: noop, Before: []string{"a.py"}},
+		},
+		`[{"name":"htlc_accepted"}]`: {
+			{Type: "htlc_accepted", Handler: noop, Before: []string{}, After: []string{}},
+		},
+		`[{"name":"htlc_accepted","before":["a.py"],"after":["b.py","c.py"]},{"name":"peer_connected"}]`: {
+			{Type: "htlc_accepted", Handler: noop, Before: []string{"a.py"}, After: []string{"b.py", "c.py"}},
+			{Type: "peer_connected", Handler: noop},
+		},
+	} {
+		h := plugintest.New(t, &plugin.Plugin{Name: "hooks", Hooks: hooks})
+		manifest, err := h.Manifest()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := manifest.Get("hooks").Raw; got != expected {
+			t.Errorf("hooks = %s, expected %s", got, expected)
+		}
+	}
+}
+
+func TestManifest(t *testing.T) {
+	h := plugintest.New(t, &plugin.Plugin{
+		Name:    "manifest",
+		Dynamic: true,
+		Options: []plugin.Option{plugin.StringOption("greeting", "hello", "What to say.")},
+		RPCMethods: []plugin.RPCMethod{
+			{Name: "greet", Usage: "name", Description: "Greets {name}."},
+		},
+		Subscriptions: []plugin.Subscription{
+			{Type: "connect", Handler: func(p *plugin.Plugin, params plugin.Params) {}},
+		},
+		Notifications: []plugin.NotificationTopic{{Method: "greeted"}},
+	})
+	manifest, err := h.Manifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for path, expected := range map[string]string{
+		"options":       `[{"name":"greeting","type":"string","default":"hello","description":"What to say."}]`,
+		"rpcmethods":    `[{"name":"greet","usage":"name","description":"Greets {name}.","long_description":""}]`,
+		"subscriptions": `["connect"]`,
+		"hooks":         `[]`,
+		"notifications": `[{"method":"greeted"}]`,
+		"dynamic":       `true`,
+	} {
+		if got := manifest.Get(path).Raw; got != expected {
+			t.Errorf("%s = %s, expected %s", path, got, expected)
+		}
+	}
+}
+
+func TestManifestFeatures(t *testing.T) {
+	h := plugintest.New(t, &plugin.Plugin{
+		Name:     "features",
+		Features: plugin.Features{Node: "08", Channel: "04", Init: "02", Invoice: "01"},
+	})
+	manifest, err := h.Manifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"node":"08","channel":"04","init":"02","invoice":"01"}`
+	if got := manifest.Get("featurebits").Raw; got != expected {
+		t.Errorf("featurebits = %s, expected %s", got, expected)
+	}
+}
@@ -0,0 +1,35 @@
+package plugin
+
+import (
+	"fmt"
+)
+
+// Notify sends a custom notification to lightningd, which forwards it to the
+// plugins subscribed to topic. The topic must be declared in p.Notifications.
+func (p *Plugin) Notify(topic string, payload interface{}) error {
+	declared := false
+	for _, n := range p.Notifications {
+		if n.Method == topic {
+			declared = true
+			break
+		}
+	}
+	if !declared {
+		return fmt.Errorf("notification topic %q is not declared", topic)
+	}
+	if payload == nil {
+		payload = map[string]interface{}{}
+	}
+	return p.write(notification{
+		Version: "2.0",
+		Method:  topic,
+		Params:  payload,
+	})
+}
+
+// notification is a JSON-RPC message without an id.
+type notification struct {
+	Version string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
@@ -0,0 +1,62 @@
+package plugin_test
+
+import (
+	"testing"
+
+	"github.com/fiatjaf/lightningd-gjson-rpc/plugin"
+	"github.com/fiatjaf/lightningd-gjson-rpc/plugin/plugintest"
+)
+
+func TestNotify(t *testing.T) {
+	var undeclared error
+	h := plugintest.New(t, &plugin.Plugin{
+		Name:          "notifier",
+		Notifications: []plugin.NotificationTopic{{Method: "payment_seen"}},
+		RPCMethods: []plugin.RPCMethod{{
+			Name: "emit",
+			Handler: func(p *plugin.Plugin, params plugin.Params) (interface{}, int, error) {
+				if err := p.Notify("payment_seen", map[string]interface{}{"amount_msat": 1000}); err != nil {
+					return nil, -1, err
+				}
+				if err := p.Notify("payment_seen", nil); err != nil {
+					return nil, -1, err
+				}
+				undeclared = p.Notify("other", map[string]interface{}{})
+				return map[string]interface{}{}, 0, nil
+			},
+		}},
+	})
+
+	manifest, err := h.Manifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if topic := manifest.Get("notifications.0.method").String(); topic != "payment_seen" {
+		t.Errorf("manifest declares %s", manifest.Get("notifications").Raw)
+	}
+	if _, err := h.Init(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// notifications are written before the response, so they're all here
+	if _, err := h.Call("emit", nil); err != nil {
+		t.Fatal(err)
+	}
+	sent := h.Notifications("payment_seen")
+	if len(sent) != 2 {
+		t.Fatalf("got %d notifications", len(sent))
+	}
+	if sent[0].Params.Raw != `{"amount_msat":1000}` {
+		t.Errorf("params sent as %s", sent[0].Params.Raw)
+	}
+	if sent[1].Params.Raw != `{}` {
+		t.Errorf("nil payload sent as %s", sent[1].Params.Raw)
+	}
+
+	if undeclared == nil {
+		t.Error("notified on an undeclared topic")
+	}
+	if n := len(h.Notifications("other")); n != 0 {
+		t.Errorf("%d notifications sent on an undeclared topic", n)
+	}
+}
@@ -0,0 +1,245 @@
+package plugin
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// option types understood by lightningd
+const (
+	OptionString = "string"
+	OptionInt    = "int"
+	OptionBool   = "bool"
+	OptionFlag   = "flag"
+)
+
+func StringOption(name string, defaultValue string, description string) Option {
+	return Option{Name: name, Type: OptionString, Default: defaultValue, Description: description, checked: true}
+}
+
+func IntOption(name string, defaultValue int, description string) Option {
+	return Option{Name: name, Type: OptionInt, Default: defaultValue, Description: description, checked: true}
+}
+
+func BoolOption(name string, defaultValue bool, description string) Option {
+	return Option{Name: name, Type: OptionBool, Default: defaultValue, Description: description, checked: true}
+}
+
+// FlagOption takes no value, it is true when given and false otherwise.
+func FlagOption(name string, description string) Option {
+	return Option{Name: name, Type: OptionFlag, Description: description, checked: true}
+}
+
+// MultiOption is a string option that can be given many times. Get it with p.GetMulti.
+func MultiOption(name string, description string) Option {
+	return Option{Name: name, Type: OptionString, Multi: true, Description: description, checked: true}
+}
+
+// AsDeprecated marks the option as deprecated, lightningd will warn when it is used.
+func (o Option) AsDeprecated() Option {
+	o.Deprecated = true
+	return o
+}
+
+// AsDynamic allows the option to be changed at runtime with setconfig.
+func (o Option) AsDynamic() Option {
+	o.Dynamic = true
+	return o
+}
+
+// validateOptions checks the values given by lightningd on init against the
+// options made with the constructors and converts them to the Go types the
+// getters expect: string, int, bool or []string for multi options. Other
+// options are passed as lightningd gave them.
+func validateOptions(options []Option, given map[string]interface{}) (Params, error) {
+	args := make(Params, len(given))
+	for k, v := range given {
+		args[k] = v
+	}
+
+	for _, option := range options {
+		if !option.checked {
+			continue
+		}
+
+		value, ok := given[option.Name]
+		if !ok || value == nil {
+			if option.Type == OptionFlag {
+				args[option.Name] = false
+			} else if option.Multi {
+				args[option.Name] = []string{}
+			} else if option.Default != nil {
+				args[option.Name] = option.Default
+			}
+			continue
+		}
+
+		converted, err := option.convert(value)
+		if err != nil {
+			return nil, err
+		}
+		args[option.Name] = converted
+	}
+
+	return args, nil
+}
+
+// convert checks a single value given for this option.
+func (o Option) convert(value interface{}) (interface{}, error) {
+	if o.Multi {
+		var values []interface{}
+		switch v := value.(type) {
+		case []interface{}:
+			values = v
+		case []string:
+			return v, nil
+		default:
+			values = []interface{}{v}
+		}
+
+		strs := make([]string, len(values))
+		for i, v := range values {
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("option %s: expected strings, got %v", o.Name, v)
+			}
+			strs[i] = s
+		}
+		return strs, nil
+	}
+
+	switch o.Type {
+	case OptionInt:
+		switch v := value.(type) {
+		case int:
+			return v, nil
+		case int64:
+			return int(v), nil
+		case float64:
+			if v != float64(int(v)) {
+				return nil, fmt.Errorf("option %s: %v is not an integer", o.Name, v)
+			}
+			return int(v), nil
+		case string:
+			i, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("option %s: %q is not an integer", o.Name, v)
+			}
+			return i, nil
+		}
+	case OptionBool, OptionFlag:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("option %s: %q is not true or false", o.Name, v)
+			}
+			return b, nil
+		}
+	default:
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		case bool:
+			return strconv.FormatBool(v), nil
+		}
+	}
+
+	return nil, fmt.Errorf("option %s: invalid value %v for type %s", o.Name, value, o.Type)
+}
+
+// setConfig handles a setconfig call from lightningd, which changes a dynamic option.
+func (p *Plugin) setConfig(iparams interface{}) error {
+	var name string
+	var value interface{}
+	switch params := iparams.(type) {
+	case map[string]interface{}:
+		name, _ = params["config"].(string)
+		value = params["val"]
+	case []interface{}:
+		if len(params) > 0 {
+			name, _ = params[0].(string)
+		}
+		if len(params) > 1 {
+			value = params[1]
+		}
+	}
+
+	var option *Option
+	for i := range p.Options {
+		if p.Options[i].Name == name {
+			option = &p.Options[i]
+			break
+		}
+	}
+	if option == nil {
+		return fmt.Errorf("unknown option %q", name)
+	}
+	if !option.Dynamic {
+		return fmt.Errorf("option %s is not dynamic", name)
+	}
+
+	if value == nil && option.Type == OptionFlag {
+		value = true
+	}
+	converted := value
+	if option.checked {
+		var err error
+		if converted, err = option.convert(value); err != nil {
+			return err
+		}
+	}
+	if option.OnChange != nil {
+		if err := option.OnChange(p, converted); err != nil {
+			return fmt.Errorf("option %s: %w", name, err)
+		}
+	}
+
+	// replace instead of modifying so Params taken before stay the same
+	p.argsMutex.Lock()
+	defer p.argsMutex.Unlock()
+	args := make(Params, len(p.Args)+1)
+	for k, v := range p.Args {
+		args[k] = v
+	}
+	args[name] = converted
+	p.Args = args
+
+	return nil
+}
+
+// GetArgs returns the current options. Use it instead of reading p.Args when
+// there are dynamic options, as they can be changed at any time.
+func (p *Plugin) GetArgs() Params {
+	p.argsMutex.RLock()
+	defer p.argsMutex.RUnlock()
+	return p.Args
+}
+
+// GetString returns the value of a string option, or its default.
+func (p *Plugin) GetString(name string) string {
+	s, _ := p.GetArgs()[name].(string)
+	return s
+}
+
+// GetInt returns the value of an int option, or its default.
+func (p *Plugin) GetInt(name string) int {
+	i, _ := p.GetArgs().Int(name)
+	return i
+}
+
+// GetBool returns the value of a bool or flag option, or its default.
+func (p *Plugin) GetBool(name string) bool {
+	b, _ := p.GetArgs()[name].(bool)
+	return b
+}
+
+// GetMulti returns all the values given to a multi option.
+func (p *Plugin) GetMulti(name string) []string {
+	values, _ := p.GetArgs()[name].([]string)
+	return values
+}
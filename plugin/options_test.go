@@ -0,0 +1,168 @@
+package plugin_test
+
+import (
+	"errors"
+	"testing"
+
+	lightning "github.com/fiatjaf/lightningd-gjson-rpc"
+	"github.com/fiatjaf/lightningd-gjson-rpc/plugin"
+	"github.com/fiatjaf/lightningd-gjson-rpc/plugin/plugintest"
+)
+
+func TestOptionsInit(t *testing.T) {
+	h := plugintest.New(t, &plugin.Plugin{
+		Name: "options",
+		Options: []plugin.Option{
+			plugin.IntOption("limit", 1, "A limit."),
+			plugin.FlagOption("verbose", "Talk more."),
+			plugin.MultiOption("peer", "A peer."),
+			{Name: "untyped", Description: "No type."},
+			{Name: "color", Type: "enum", Description: "Not known here."},
+		},
+	})
+	manifest, err := h.Manifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if typ := manifest.Get(`options.#(name=="untyped").type`).String(); typ != "string" {
+		t.Errorf("untyped option declared as %q", typ)
+	}
+	if typ := manifest.Get(`options.#(name=="color").type`).String(); typ != "enum" {
+		t.Errorf("enum option declared as %q", typ)
+	}
+
+	res, err := h.Init(map[string]interface{}{"limit": "3", "peer": "02aa", "untyped": "x", "color": "red"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Get("disable").Exists() {
+		t.Fatalf("disabled: %s", res.Get("disable"))
+	}
+
+	p := h.Plugin
+	if p.GetInt("limit") != 3 || p.GetBool("verbose") || len(p.GetMulti("peer")) != 1 {
+		t.Errorf("typed options: %v", p.GetArgs())
+	}
+	if p.GetString("untyped") != "x" || p.GetString("color") != "red" {
+		t.Errorf("other options: %v", p.GetArgs())
+	}
+}
+
+func TestOptionsInvalidOnInit(t *testing.T) {
+	h := plugintest.New(t, &plugin.Plugin{
+		Name:    "options",
+		Options: []plugin.Option{plugin.IntOption("limit", 1, "A limit.")},
+	})
+	if _, err := h.Manifest(); err != nil {
+		t.Fatal(err)
+	}
+	res, err := h.Init(map[string]interface{}{"limit": "many"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Get("disable").String() != `invalid options: option limit: "many" is not an integer` {
+		t.Errorf("init returned %s", res.Raw)
+	}
+}
+
+func TestSetconfig(t *testing.T) {
+	h := plugintest.New(t, &plugin.Plugin{
+		Name:    "options",
+		Dynamic: true,
+		Options: []plugin.Option{
+			plugin.IntOption("fixed", 1, "Can't change."),
+			func() plugin.Option {
+				o := plugin.IntOption("limit", 1, "A limit.").AsDynamic()
+				o.OnChange = func(p *plugin.Plugin, value interface{}) error {
+					if value.(int) > 100 {
+						return errors.New("too much")
+					}
+					return nil
+				}
+				return o
+			}(),
+		},
+	})
+	h.Start(t, nil)
+
+	for value, expected := range map[interface{}]string{
+		"many": `option limit: "many" is not an integer`,
+		1.5:    "option limit: 1.5 is not an integer",
+		101:    "option limit: too much",
+	} {
+		if _, err := h.Setconfig("limit", value); !rejected(err, expected) {
+			t.Errorf("setconfig limit %v: %v", value, err)
+		}
+	}
+	if _, err := h.Setconfig("fixed", 2); !rejected(err, "option fixed is not dynamic") {
+		t.Errorf("setconfig fixed: %v", err)
+	}
+	if _, err := h.Setconfig("nothing", 2); err == nil {
+		t.Error("setconfig on an unknown option accepted")
+	}
+	if limit := h.Plugin.GetInt("limit"); limit != 1 {
+		t.Errorf("rejected values changed limit to %d", limit)
+	}
+
+	if _, err := h.Setconfig("limit", 50); err != nil {
+		t.Fatal(err)
+	}
+	if limit := h.Plugin.GetInt("limit"); limit != 50 {
+		t.Errorf("limit = %d", limit)
+	}
+}
+
+func rejected(err error, message string) bool {
+	var cmderr lightning.ErrorCommand
+	return errors.As(err, &cmderr) && cmderr.Code == -32602 && cmderr.Message == message
+}
+
+func TestSetconfigDynamic(t *testing.T) {
+	h := plugintest.New(t, &plugin.Plugin{
+		Name:    "options",
+		Dynamic: true,
+		Options: []plugin.Option{
+			plugin.StringOption("alias", "a", "An alias.").AsDynamic(),
+			plugin.FlagOption("verbose", "Talk more.").AsDynamic(),
+			plugin.StringOption("fixed", "f", "Can't change."),
+		},
+	})
+	manifest, err := h.Manifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !manifest.Get(`options.#(name=="alias").dynamic`).Bool() {
+		t.Errorf("dynamic option declared as %s", manifest.Get(`options.#(name=="alias")`).Raw)
+	}
+	if manifest.Get(`options.#(name=="fixed").dynamic`).Exists() {
+		t.Errorf("static option declared as %s", manifest.Get(`options.#(name=="fixed")`).Raw)
+	}
+	if _, err := h.Init(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	before := h.Plugin.GetArgs()
+	if _, err := h.Setconfig("alias", "b"); err != nil {
+		t.Fatal(err)
+	}
+	if before["alias"] != "a" {
+		t.Errorf("setconfig changed Params taken before it to %v", before["alias"])
+	}
+	if alias := h.Plugin.GetString("alias"); alias != "b" {
+		t.Errorf("alias = %q", alias)
+	}
+
+	// positional params, and a flag set without a value
+	if _, err := h.Call("setconfig", []interface{}{"verbose"}); err != nil {
+		t.Fatal(err)
+	}
+	if !h.Plugin.GetBool("verbose") {
+		t.Error("flag not set")
+	}
+	if _, err := h.Call("setconfig", []interface{}{"alias", "c"}); err != nil {
+		t.Fatal(err)
+	}
+	if alias := h.Plugin.GetString("alias"); alias != "c" {
+		t.Errorf("alias = %q after positional setconfig", alias)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 
+	lightning "github.com/fiatjaf/lightningd-gjson-rpc"
 	"github.com/tidwall/gjson"
 )
 
@@ -87,6 +88,29 @@ func (params Params) Float64(key string) (i float64, err error) {
 	return
 }
 
+func (params Params) Msat(key string) (m lightning.Msat, err error) {
+	v, ok := params[key]
+	if !ok {
+		err = errKey(key)
+		return
+	}
+	switch n := v.(type) {
+	case int:
+		m = lightning.Msat(n)
+	case int64:
+		m = lightning.Msat(n)
+	case float64:
+		m = lightning.Msat(n)
+	case json.Number:
+		m, err = lightning.ParseMsat(n.String())
+	case string:
+		m, err = lightning.ParseMsat(n)
+	default:
+		err = errType(key)
+	}
+	return
+}
+
 func errKey(key string) error {
 	return fmt.Errorf("no such key: %q", key)
 }
@@ -1,15 +1,16 @@
 package plugin
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"os"
-	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	lightning "github.com/fiatjaf/lightningd-gjson-rpc"
@@ -35,13 +36,106 @@ type Plugin struct {
 	Dynamic       bool                `json:"dynamic"`
 	Notifications []NotificationTopic `json:"notifications"`
 
-	Configuration Params        `json:"-"`
-	Args          Params        `json:"-"`
-	OnInit        func(*Plugin) `json:"-"`
+	Configuration     Params            `json:"-"`
+	InitConfiguration InitConfiguration `json:"-"`
+	Args              Params            `json:"-"`
+	OnInit            func(*Plugin)     `json:"-"`
+
+	// CheckInit is called on init, before answering lightningd. Returning an
+	// error disables the plugin, with the error as the reason.
+	CheckInit func(*Plugin) error `json:"-"`
+
+	// OnShutdown is called when lightningd stops the plugin, after the
+	// handlers that were running are done (or ShutdownTimeout passes).
+	// Flush and close things here. Setting it subscribes to shutdown.
+	OnShutdown      func(*Plugin) `json:"-"`
+	ShutdownTimeout time.Duration `json:"-"`
+
+	// argsMutex guards Args, which is replaced when a dynamic option changes
+	argsMutex sync.RWMutex
+
+	// Stdin and Stdout are where lightningd talks to the plugin, they default
+	// to os.Stdin and os.Stdout. Tests can set them to pipes, see plugintest.
+	Stdin  io.Reader `json:"-"`
+	Stdout io.Writer `json:"-"`
+
+	// LogToStderr writes logs to stderr, with colors, instead of sending them
+	// to lightningd as log notifications.
+	LogToStderr bool `json:"-"`
+	stderr      *LogProxy
+
+	// MaxConcurrency is how many RPC calls, hooks and notifications can be
+	// handled at the same time. When all are busy the others wait, but we
+	// keep reading from lightningd. Defaults to DefaultMaxConcurrency.
+	MaxConcurrency int `json:"-"`
+
+	// CustomMessages handle messages sent by peers, see custommsg.go.
+	// FeatureBits are set in the node and init features we announce, so peers
+	// can tell we speak these protocols.
+	CustomMessages []CustomMessageHandler `json:"-"`
+	FeatureBits    []int                  `json:"-"`
+	custom         customMessages
+
+	// everything sent to lightningd goes through write()
+	outgoing   *writer
+	writeMutex sync.Mutex // guards outgoing
+
+	workers    chan struct{}
+	hookQueues map[string]*hookQueue
+
+	// cancelled when the plugin is shutting down, see lifecycle.go
+	ctx          context.Context
+	cancel       context.CancelFunc
+	contextMutex sync.Mutex
+	inflight     sync.WaitGroup
+
+	rpcmethodmap map[string]RPCMethod
+	submap       map[string]Subscription
+	hookmap      map[string]Hook
 }
 
+var DefaultMaxConcurrency = 64
+
+// hookQueue runs the calls to a hook with a Concurrency limit in the order
+// they arrive. It never blocks the reader, calls wait in pending instead.
+type hookQueue struct {
+	mu      sync.Mutex
+	pending []lightning.JSONRPCMessage
+	running int
+	limit   int
+}
+
+func (q *hookQueue) push(p *Plugin, msg lightning.JSONRPCMessage) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.running == q.limit {
+		q.pending = append(q.pending, msg)
+		return
+	}
+
+	q.running++
+	go func() {
+		for {
+			handleMessage(p, msg)
+			p.inflight.Done()
+
+			q.mu.Lock()
+			if len(q.pending) == 0 {
+				q.running--
+				q.mu.Unlock()
+				return
+			}
+			msg = q.pending[0]
+			q.pending = q.pending[1:]
+			q.mu.Unlock()
+		}
+	}()
+}
+
+// Features are hex feature bits, under the names lightningd reads from the
+// manifest's featurebits.
 type Features struct {
-	Node    string `json:"features"`
+	Node    string `json:"node"`
 	Channel string `json:"channel"`
 	Init    string `json:"init"`
 	Invoice string `json:"invoice"`
@@ -51,11 +145,24 @@ type NotificationTopic struct {
 	Method string `json:"method"`
 }
 
+// Option is declared to lightningd, which passes its value on init.
+// Use the constructors (StringOption, IntOption etc.) to have the values
+// checked and converted to the right types. Type defaults to "string".
 type Option struct {
 	Name        string      `json:"name"`
 	Type        string      `json:"type"`
-	Default     interface{} `json:"default"`
+	Default     interface{} `json:"default,omitempty"`
 	Description string      `json:"description"`
+	Deprecated  bool        `json:"deprecated,omitempty"`
+	Multi       bool        `json:"multi,omitempty"`
+	Dynamic     bool        `json:"dynamic,omitempty"`
+
+	// OnChange is called when a dynamic option is changed with setconfig,
+	// before the new value is stored. Returning an error rejects it.
+	OnChange func(p *Plugin, value interface{}) error `json:"-"`
+
+	// set by the constructors, only these options have their values checked
+	checked bool
 }
 
 type RPCMethod struct {
@@ -64,6 +171,9 @@ type RPCMethod struct {
 	Description     string     `json:"description"`
 	LongDescription string     `json:"long_description"`
 	Handler         RPCHandler `json:"-"`
+
+	// set by NewRPCMethod, gets the params as sent when given by position
+	typedHandler func(p *Plugin, params Params, positional []interface{}) (interface{}, int, error)
 }
 
 type Subscription struct {
@@ -76,9 +186,26 @@ func (s Subscription) MarshalJSON() ([]byte, error) { return json.Marshal(s.Type
 type Hook struct {
 	Type    string
 	Handler HookHandler
+
+	// Concurrency, if set, is how many calls to this hook are handled at the
+	// same time, in the order they arrive. 1 handles them one by one, which
+	// keeps htlc_accepted in order, for example. These calls don't count
+	// towards Plugin.MaxConcurrency.
+	Concurrency int
+
+	// Before and After order this hook relative to the same hook in other
+	// plugins, given by their file names (like "clboss" or "summary.py").
+	Before []string
+	After  []string
 }
 
-func (h Hook) MarshalJSON() ([]byte, error) { return json.Marshal(h.Type) }
+func (h Hook) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Name   string   `json:"name"`
+		Before []string `json:"before,omitempty"`
+		After  []string `json:"after,omitempty"`
+	}{h.Type, h.Before, h.After})
+}
 
 type RPCHandler func(p *Plugin, params Params) (resp interface{}, errCode int, err error)
 type NotificationHandler func(p *Plugin, params Params)
@@ -97,17 +224,26 @@ func (lp *LogProxy) Write(p []byte) (n int, err error) {
 	return lp.target.Write([]byte(res + "\n"))
 }
 
-func (p *Plugin) Run() {
-	initialized := make(chan bool)
+// Run listens to lightningd until it tells the plugin to shut down or closes
+// stdin, and returns after the handlers still running are done. It returns an
+// error if the plugin couldn't run or didn't stop cleanly.
+func (p *Plugin) Run() error {
+	initialized := make(chan bool, 1)
+	stopped := make(chan struct{})
+	defer close(stopped)
 
 	go func() {
-		<-initialized
+		select {
+		case <-initialized:
+		case <-stopped:
+			return
+		}
 		if p.OnInit != nil {
 			p.OnInit(p)
 		}
 	}()
 
-	p.Listener(initialized)
+	return p.Listener(initialized)
 }
 
 func (p *Plugin) colorize(text string) string {
@@ -125,50 +261,81 @@ func (p *Plugin) colorize(text string) string {
 	return colors[n%uint64(len(colors))] + text + "\x1B[0m"
 }
 
-var rpcmethodmap = make(map[string]RPCMethod)
-var submap = make(map[string]Subscription)
-var hookmap = make(map[string]Hook)
+func (p *Plugin) Listener(initialized chan<- bool) error {
+	p.contextMutex.Lock()
+	if p.ctx == nil {
+		p.ctx, p.cancel = context.WithCancel(context.Background())
+	}
+	p.contextMutex.Unlock()
 
-func (p *Plugin) Listener(initialized chan<- bool) {
+	if err := p.setupCustomMessages(); err != nil {
+		return err
+	}
+
+	p.rpcmethodmap = make(map[string]RPCMethod)
+	p.submap = make(map[string]Subscription)
+	p.hookmap = make(map[string]Hook)
 	for _, rpcmethod := range p.RPCMethods {
-		rpcmethodmap[rpcmethod.Name] = rpcmethod
+		p.rpcmethodmap[rpcmethod.Name] = rpcmethod
 	}
 	for _, sub := range p.Subscriptions {
-		submap[sub.Type] = sub
+		p.submap[sub.Type] = sub
 	}
 	for _, hook := range p.Hooks {
-		hookmap[hook.Type] = hook
+		p.hookmap[hook.Type] = hook
 	}
 
 	// logging
-	prefix := p.colorize("plugin-" + p.Name)
-	logproxy := &LogProxy{
-		prefix: prefix,
+	p.stderr = &LogProxy{
+		prefix: p.colorize("plugin-" + p.Name),
 		target: os.Stderr,
 	}
-	log.SetOutput(logproxy)
-	log.SetFlags(0)
-
 	p.Log = func(args ...interface{}) {
-		fmt.Fprint(logproxy, args...)
+		p.logf(LogInfo, "%s", fmt.Sprint(args...))
 	}
 	p.Logf = func(b string, args ...interface{}) {
-		fmt.Fprintf(logproxy, b, args...)
+		p.logf(LogInfo, b, args...)
 	}
 
-	var msg lightning.JSONRPCMessage
+	// workers
+	maxConcurrency := p.MaxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = DefaultMaxConcurrency
+	}
+	p.workers = make(chan struct{}, maxConcurrency)
+	p.hookQueues = make(map[string]*hookQueue)
+	for _, hook := range p.Hooks {
+		if hook.Concurrency > 0 {
+			p.hookQueues[hook.Type] = &hookQueue{limit: hook.Concurrency}
+		}
+	}
 
-	incoming := json.NewDecoder(os.Stdin)
-	outgoing := json.NewEncoder(os.Stdout)
+	stdin, stdout := p.Stdin, p.Stdout
+	if stdin == nil {
+		stdin = os.Stdin
+	}
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+
+	incoming := json.NewDecoder(stdin)
+	p.writeMutex.Lock()
+	p.outgoing = newWriter(stdout)
+	p.writeMutex.Unlock()
 	for {
+		// a new one each time, or a notification would keep the id of the
+		// call before it
+		var msg lightning.JSONRPCMessage
 		err := incoming.Decode(&msg)
 		if err == io.EOF {
-			return
+			// lightningd is gone
+			return p.shutdown()
 		}
 
 		if err != nil {
 			p.Log("failed to decode request. killing: " + err.Error())
-			return
+			p.shutdown()
+			return fmt.Errorf("failed to decode request: %w", err)
 		}
 
 		switch msg.Method {
@@ -176,6 +343,11 @@ func (p *Plugin) Listener(initialized chan<- bool) {
 			if p.Options == nil {
 				p.Options = make([]Option, 0)
 			}
+			for i := range p.Options {
+				if p.Options[i].Type == "" {
+					p.Options[i].Type = OptionString
+				}
+			}
 			if p.RPCMethods == nil {
 				p.RPCMethods = make([]RPCMethod, 0)
 			}
@@ -188,6 +360,12 @@ func (p *Plugin) Listener(initialized chan<- bool) {
 			if p.Subscriptions == nil {
 				p.Subscriptions = make([]Subscription, 0)
 			}
+			// lightningd only sends shutdown to plugins subscribed to it
+			if _, ok := p.submap["shutdown"]; !ok && p.OnShutdown != nil {
+				sub := Subscription{Type: "shutdown"}
+				p.Subscriptions = append(p.Subscriptions, sub)
+				p.submap["shutdown"] = sub
+			}
 
 			jmanifest, _ := json.Marshal(p)
 			response := lightning.JSONRPCResponse{
@@ -195,52 +373,92 @@ func (p *Plugin) Listener(initialized chan<- bool) {
 				Id:      msg.Id,
 			}
 			json.Unmarshal([]byte(jmanifest), &response.Result)
-			outgoing.Encode(response)
+			p.write(response)
 		case "init":
-			params := msg.Params.(map[string]interface{})
-
-			iconf := params["configuration"]
-			conf := iconf.(map[string]interface{})
-
-			p.Configuration = Params(conf)
-			p.Network = conf["network"].(string)
-
-			lnpath := conf["lightning-dir"].(string)
-			rpcfile := conf["rpc-file"].(string)
-			rpc := filepath.Join(lnpath, rpcfile)
-			if filepath.IsAbs(rpcfile) {
-				rpc = rpcfile
+			if reason := p.handleInit(msg.Params); reason != "" {
+				p.Unusualf("disabling plugin: %s", reason)
+				p.write(lightning.JSONRPCResponse{
+					Version: msg.Version,
+					Id:      msg.Id,
+					Result:  mustJSON(map[string]string{"disable": reason}),
+				})
+				p.shutdown()
+				return ErrorDisabled{reason}
 			}
-			p.Client = &lightning.Client{
-				Path:         rpc,
-				LightningDir: lnpath,
-			}
-			p.Args = Params(params["options"].(map[string]interface{}))
 
 			p.Log("initialized plugin " + p.Version)
 			initialized <- true
-			outgoing.Encode(lightning.JSONRPCResponse{
+			p.write(lightning.JSONRPCResponse{
 				Version: msg.Version,
 				Id:      msg.Id,
+				Result:  json.RawMessage("{}"),
 			})
+		case "setconfig":
+			response := lightning.JSONRPCResponse{
+				Version: msg.Version,
+				Id:      msg.Id,
+				Result:  json.RawMessage("{}"),
+			}
+			if err := p.setConfig(msg.Params); err != nil {
+				p.Log("setconfig failed: " + err.Error())
+				response.Result = nil
+				response.Error = &lightning.JSONRPCError{
+					Code:    -32602,
+					Message: err.Error(),
+				}
+			}
+			p.write(response)
 		case "shutdown":
-			if shutdown, ok := submap["shutdown"]; ok {
-				shutdown.Handler(p, msg.Params.(map[string]interface{}))
+			if shutdown, ok := p.submap["shutdown"]; ok && shutdown.Handler != nil {
+				params, _ := msg.Params.(map[string]interface{})
+				shutdown.Handler(p, params)
 			}
-			return
+			return p.shutdown()
 		default:
-			go handleMessage(p, outgoing, msg)
+			p.inflight.Add(1)
+			if queue, ok := p.hookQueues[msg.Method]; ok {
+				queue.push(p, msg)
+				continue
+			}
+
+			// wait for a worker in the goroutine, so we keep reading init,
+			// setconfig, shutdown and everything else while all are busy
+			go func(msg lightning.JSONRPCMessage) {
+				p.workers <- struct{}{}
+				defer func() {
+					<-p.workers
+					p.inflight.Done()
+				}()
+				handleMessage(p, msg)
+			}(msg)
 		}
 	}
 }
 
-func handleMessage(p *Plugin, outgoing *json.Encoder, msg lightning.JSONRPCMessage) {
+func mustJSON(v interface{}) json.RawMessage {
+	j, _ := json.Marshal(v)
+	return j
+}
+
+// write sends a message to lightningd, one at a time.
+func (p *Plugin) write(v interface{}) error {
+	p.writeMutex.Lock()
+	outgoing := p.outgoing
+	p.writeMutex.Unlock()
+
+	if outgoing == nil {
+		return errors.New("plugin is not running")
+	}
+	return outgoing.write(v)
+}
+
+func handleMessage(p *Plugin, msg lightning.JSONRPCMessage) {
 	response := lightning.JSONRPCResponse{
 		Version: msg.Version,
 		Id:      msg.Id,
 	}
 
-	if rpcmethod, ok := rpcmethodmap[msg.Method]; ok {
+	if rpcmethod, ok := p.rpcmethodmap[msg.Method]; ok {
 		params, err := GetParams(msg.Params, rpcmethod.Usage)
 		if err != nil {
 			p.Logf("Error decoding params '%s': %s", rpcmethod.Usage, err.Error())
@@ -251,7 +469,14 @@ func handleMessage(p *Plugin, outgoing *json.Encoder, msg lightning.JSONRPCMessa
 			goto end
 		}
 
-		resp, errCode, err := rpcmethod.Handler(p, params)
+		var resp interface{}
+		var errCode int
+		if rpcmethod.typedHandler != nil {
+			positional, _ := msg.Params.([]interface{})
+			resp, errCode, err = rpcmethod.typedHandler(p, params, positional)
+		} else {
+			resp, errCode, err = rpcmethod.Handler(p, params)
+		}
 		if err != nil {
 			if errCode == 0 {
 				errCode = -1
@@ -276,7 +501,7 @@ func handleMessage(p *Plugin, outgoing *json.Encoder, msg lightning.JSONRPCMessa
 		response.Result = jresp
 	}
 
-	if hook, ok := hookmap[msg.Method]; ok {
+	if hook, ok := p.hookmap[msg.Method]; ok {
 		resp := hook.Handler(p, Params(msg.Params.(map[string]interface{})))
 		jresp, err := json.Marshal(resp)
 		if err != nil {
@@ -289,13 +514,13 @@ func handleMessage(p *Plugin, outgoing *json.Encoder, msg lightning.JSONRPCMessa
 		response.Result = jresp
 	}
 
-	if sub, ok := submap[msg.Method]; ok {
+	if sub, ok := p.submap[msg.Method]; ok {
 		sub.Handler(p, Params(msg.Params.(map[string]interface{})))
 		goto noanswer
 	}
 
 end:
-	outgoing.Encode(response)
+	p.write(response)
 
 noanswer:
 }
@@ -0,0 +1,63 @@
+package plugin_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fiatjaf/lightningd-gjson-rpc/plugin"
+	"github.com/fiatjaf/lightningd-gjson-rpc/plugin/plugintest"
+)
+
+func TestBusyWorkersKeepReading(t *testing.T) {
+	release := make(chan struct{})
+	h := plugintest.New(t, &plugin.Plugin{
+		Name:           "busy",
+		MaxConcurrency: 1,
+		Dynamic:        true,
+		Options:        []plugin.Option{plugin.IntOption("limit", 1, "A limit.").AsDynamic()},
+		RPCMethods: []plugin.RPCMethod{
+			{
+				Name: "block",
+				Handler: func(p *plugin.Plugin, params plugin.Params) (interface{}, int, error) {
+					<-release
+					return map[string]interface{}{}, 0, nil
+				},
+			},
+		},
+		Hooks: []plugin.Hook{
+			{
+				Type:        "htlc_accepted",
+				Concurrency: 1,
+				Handler: func(p *plugin.Plugin, params plugin.Params) interface{} {
+					return plugin.Continue()
+				},
+			},
+		},
+	})
+	h.Start(t, nil)
+	h.Timeout = time.Second
+
+	// more calls than workers, all of them waiting
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := h.Call("block", map[string]interface{}{}); err != nil {
+				t.Errorf("block: %s", err)
+			}
+		}()
+	}
+	time.Sleep(time.Millisecond * 50)
+
+	if _, err := h.Setconfig("limit", 2); err != nil {
+		t.Errorf("setconfig while workers are busy: %s", err)
+	}
+	if _, err := h.Hook("htlc_accepted", map[string]interface{}{}); err != nil {
+		t.Errorf("hook with its own workers while workers are busy: %s", err)
+	}
+
+	close(release)
+	wg.Wait()
+}
@@ -0,0 +1,306 @@
+// Package plugintest runs a plugin.Plugin the way lightningd would, over
+// in-memory pipes, so plugins can be tested without a real node. Calls the
+// plugin makes with p.Client go to a lightningtest.Server.
+package plugintest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	lightning "github.com/fiatjaf/lightningd-gjson-rpc"
+	"github.com/fiatjaf/lightningd-gjson-rpc/lightningtest"
+	"github.com/fiatjaf/lightningd-gjson-rpc/plugin"
+	"github.com/tidwall/gjson"
+)
+
+type Harness struct {
+	Plugin *plugin.Plugin
+
+	// RPC is the fake lightningd the plugin's Client talks to.
+	RPC *lightningtest.Server
+
+	// Configuration is sent on Init, it starts with values pointing to RPC.
+	Configuration map[string]interface{}
+
+	// Timeout is how long to wait for each response from the plugin.
+	Timeout time.Duration
+
+	t      testing.TB
+	stdin  *io.PipeWriter
+	stdout *io.PipeReader
+
+	mu            sync.Mutex
+	nextId        int
+	pending       map[string]chan lightning.JSONRPCResponse
+	notifications []Notification
+	done          chan struct{} // closed when the plugin's stdout is closed
+
+	stopped chan struct{} // closed when p.Run returns
+	runErr  error
+}
+
+// Notification is a message sent by the plugin without an id, like a log line
+// or a custom notification.
+type Notification struct {
+	Method string
+	Params gjson.Result
+}
+
+// New starts the plugin with p.Run. It is stopped when the test ends.
+func New(t testing.TB, p *plugin.Plugin) *Harness {
+	rpc := lightningtest.NewServer(t)
+
+	stdinReader, stdinWriter := io.Pipe()
+	stdoutReader, stdoutWriter := io.Pipe()
+	p.Stdin = stdinReader
+	p.Stdout = stdoutWriter
+
+	h := &Harness{
+		Plugin: p,
+		RPC:    rpc,
+		Configuration: map[string]interface{}{
+			"lightning-dir": filepath.Dir(rpc.Path),
+			"rpc-file":      filepath.Base(rpc.Path),
+			"network":       "regtest",
+			"startup":       true,
+			"feature_set":   map[string]interface{}{},
+		},
+		Timeout: time.Second * 5,
+		t:       t,
+		stdin:   stdinWriter,
+		stdout:  stdoutReader,
+		pending: make(map[string]chan lightning.JSONRPCResponse),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+
+	go h.readLoop()
+	go func() {
+		h.runErr = p.Run()
+		stdoutWriter.Close()
+		close(h.stopped)
+	}()
+
+	t.Cleanup(h.Close)
+	return h
+}
+
+// Close closes the plugin's stdin, like lightningd does when it stops, and
+// waits for p.Run to return. It fails the test if the plugin doesn't stop.
+// It is called when the test ends, before the RPC server is closed.
+func (h *Harness) Close() {
+	h.stdin.Close()
+	if err := h.wait(); err != nil {
+		h.t.Errorf("plugintest: %s", err)
+	}
+}
+
+// Shutdown sends the shutdown notification and returns what p.Run returned.
+func (h *Harness) Shutdown() error {
+	if err := h.Notify("shutdown", map[string]interface{}{}); err != nil {
+		return err
+	}
+	return h.Wait()
+}
+
+// Wait waits for p.Run to return, like after the plugin disabled itself on
+// init, and returns what it returned.
+func (h *Harness) Wait() error {
+	if err := h.wait(); err != nil {
+		return err
+	}
+	return h.runErr
+}
+
+// wait waits for p.Run to return and everything it wrote to be read.
+func (h *Harness) wait() error {
+	timeout := time.After(h.Timeout + h.shutdownTimeout())
+	for _, ch := range []chan struct{}{h.stopped, h.done} {
+		select {
+		case <-ch:
+		case <-timeout:
+			return fmt.Errorf("plugin didn't stop, p.Run is still running")
+		}
+	}
+	return nil
+}
+
+func (h *Harness) shutdownTimeout() time.Duration {
+	if h.Plugin.ShutdownTimeout != 0 {
+		return h.Plugin.ShutdownTimeout
+	}
+	return plugin.DefaultShutdownTimeout
+}
+
+// Manifest calls getmanifest.
+func (h *Harness) Manifest() (gjson.Result, error) {
+	return h.request("getmanifest", map[string]interface{}{})
+}
+
+// Init calls init with h.Configuration and the given options.
+func (h *Harness) Init(options map[string]interface{}) (gjson.Result, error) {
+	if options == nil {
+		options = map[string]interface{}{}
+	}
+	return h.request("init", map[string]interface{}{
+		"options":       options,
+		"configuration": h.Configuration,
+	})
+}
+
+// Start calls getmanifest and then init, failing the test on errors.
+func (h *Harness) Start(t testing.TB, options map[string]interface{}) {
+	t.Helper()
+	if _, err := h.Manifest(); err != nil {
+		t.Fatalf("getmanifest failed: %s", err)
+	}
+	if _, err := h.Init(options); err != nil {
+		t.Fatalf("init failed: %s", err)
+	}
+}
+
+// Call calls an RPC method of the plugin. Errors are lightning.ErrorCommand.
+func (h *Harness) Call(method string, params interface{}) (gjson.Result, error) {
+	return h.request(method, params)
+}
+
+// Hook calls a hook and returns the plugin's response.
+func (h *Harness) Hook(name string, payload interface{}) (gjson.Result, error) {
+	return h.request(name, payload)
+}
+
+// Notify sends a notification the plugin is subscribed to. There is no
+// response, so effects have to be checked some other way.
+func (h *Harness) Notify(topic string, payload interface{}) error {
+	return h.send(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  topic,
+		"params":  payload,
+	})
+}
+
+// Setconfig changes a dynamic option.
+func (h *Harness) Setconfig(name string, value interface{}) (gjson.Result, error) {
+	return h.request("setconfig", map[string]interface{}{"config": name, "val": value})
+}
+
+// Notifications returns all notifications sent by the plugin so far, or only
+// the ones with the given method ("log", or a custom topic).
+func (h *Harness) Notifications(method string) []Notification {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	notifications := make([]Notification, 0, len(h.notifications))
+	for _, n := range h.notifications {
+		if method == "" || n.Method == method {
+			notifications = append(notifications, n)
+		}
+	}
+	return notifications
+}
+
+// Logs returns the messages logged by the plugin so far.
+func (h *Harness) Logs() []string {
+	logs := h.Notifications("log")
+	messages := make([]string, len(logs))
+	for i, n := range logs {
+		messages[i] = n.Params.Get("message").String()
+	}
+	return messages
+}
+
+func (h *Harness) request(method string, params interface{}) (gjson.Result, error) {
+	h.mu.Lock()
+	h.nextId++
+	id := fmt.Sprintf("plugintest:%d", h.nextId)
+	respchan := make(chan lightning.JSONRPCResponse, 1)
+	h.pending[id] = respchan
+	h.mu.Unlock()
+
+	defer func() {
+		h.mu.Lock()
+		delete(h.pending, id)
+		h.mu.Unlock()
+	}()
+
+	err := h.send(lightning.JSONRPCMessage{
+		Version: "2.0",
+		Id:      id,
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return gjson.Result{}, err
+	}
+
+	select {
+	case response := <-respchan:
+		if response.Error != nil {
+			return gjson.Result{}, lightning.ErrorCommand{
+				Message: response.Error.Message,
+				Code:    response.Error.Code,
+				Data:    response.Error.Data,
+			}
+		}
+		return gjson.ParseBytes(response.Result), nil
+	case <-h.done:
+		return gjson.Result{}, fmt.Errorf("plugin stopped before answering %s", method)
+	case <-time.After(h.Timeout):
+		return gjson.Result{}, lightning.ErrorTimeout{Seconds: int(h.Timeout.Seconds())}
+	}
+}
+
+// send fails if the plugin doesn't read the message in h.Timeout, like when
+// it is stuck in CheckInit.
+func (h *Harness) send(v interface{}) error {
+	j, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	written := make(chan error, 1)
+	go func() {
+		_, err := h.stdin.Write(append(j, '\n'))
+		written <- err
+	}()
+	select {
+	case err := <-written:
+		return err
+	case <-time.After(h.Timeout):
+		// the write is abandoned, Close unblocks it
+		return lightning.ErrorTimeout{Seconds: int(h.Timeout.Seconds())}
+	}
+}
+
+func (h *Harness) readLoop() {
+	defer close(h.done)
+
+	decoder := json.NewDecoder(h.stdout)
+	for {
+		var msg struct {
+			lightning.JSONRPCResponse
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		if err := decoder.Decode(&msg); err != nil {
+			return
+		}
+
+		h.mu.Lock()
+		if msg.Id == nil {
+			h.notifications = append(h.notifications, Notification{msg.Method, gjson.ParseBytes(msg.Params)})
+		} else if respchan, ok := h.pending[fmt.Sprint(msg.Id)]; ok {
+			select {
+			case respchan <- msg.JSONRPCResponse:
+			default:
+				// answered twice, the test will see the first one
+			}
+		}
+		h.mu.Unlock()
+	}
+}
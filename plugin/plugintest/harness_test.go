@@ -0,0 +1,101 @@
+package plugintest
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	lightning "github.com/fiatjaf/lightningd-gjson-rpc"
+	"github.com/fiatjaf/lightningd-gjson-rpc/plugin"
+)
+
+// recorder keeps the errors a harness reports instead of failing the test.
+type recorder struct {
+	testing.TB
+
+	mu     sync.Mutex
+	errors []string
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Errors() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string{}, r.errors...)
+}
+
+func TestCloseWaitsForRun(t *testing.T) {
+	stopped := false
+	h := New(t, &plugin.Plugin{
+		Name:       "stops",
+		OnShutdown: func(p *plugin.Plugin) { stopped = true },
+	})
+	h.Start(t, nil)
+
+	h.Close()
+	if !stopped {
+		t.Fatal("Close returned before the plugin stopped")
+	}
+}
+
+func TestCloseReportsLeaks(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	r := &recorder{TB: t}
+	h := New(r, &plugin.Plugin{
+		Name:            "leaks",
+		ShutdownTimeout: time.Millisecond * 50,
+		OnShutdown:      func(p *plugin.Plugin) { <-release },
+	})
+	h.Timeout = time.Millisecond * 100
+	h.Start(t, nil)
+
+	h.Close()
+	if errors := r.Errors(); len(errors) != 1 {
+		t.Fatalf("expected the leak to be reported, got %v", errors)
+	}
+}
+
+func TestSendTimeout(t *testing.T) {
+	checking, release := make(chan struct{}), make(chan struct{})
+	h := New(t, &plugin.Plugin{
+		Name: "stuck",
+		// the plugin doesn't read anything else while it checks init
+		CheckInit: func(p *plugin.Plugin) error {
+			close(checking)
+			<-release
+			return nil
+		},
+	})
+	if _, err := h.Manifest(); err != nil {
+		t.Fatal(err)
+	}
+
+	h.Timeout = time.Millisecond * 100
+	initialized := make(chan struct{})
+	go func() {
+		h.Init(nil)
+		close(initialized)
+	}()
+	<-checking
+
+	var err error
+	for i := 0; i < 3 && err == nil; i++ {
+		err = h.Notify("connect", map[string]interface{}{})
+	}
+	if _, ok := err.(lightning.ErrorTimeout); !ok {
+		t.Errorf("expected a timeout, got %v", err)
+	}
+
+	// give the plugin time to stop when the test ends
+	close(release)
+	<-initialized
+	h.Timeout = time.Second * 5
+}
@@ -0,0 +1,135 @@
+package plugin
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// TLVStream is a BOLT#1 type-length-value stream, by record type.
+type TLVStream map[uint64][]byte
+
+// Encode serializes the records in increasing type order.
+func (s TLVStream) Encode() []byte {
+	types := make([]uint64, 0, len(s))
+	for t := range s {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+
+	var out []byte
+	for _, t := range types {
+		out = appendBigSize(out, t)
+		out = appendBigSize(out, uint64(len(s[t])))
+		out = append(out, s[t]...)
+	}
+	return out
+}
+
+// DecodeTLVStream parses a stream, which must have types in strictly increasing order.
+func DecodeTLVStream(data []byte) (TLVStream, error) {
+	s := make(TLVStream)
+	first := true
+	var last uint64
+	for len(data) > 0 {
+		t, n, err := readBigSize(data)
+		if err != nil {
+			return nil, err
+		}
+		data = data[n:]
+
+		length, n, err := readBigSize(data)
+		if err != nil {
+			return nil, err
+		}
+		data = data[n:]
+
+		if !first && t <= last {
+			return nil, fmt.Errorf("tlv record %d out of order", t)
+		}
+		if uint64(len(data)) < length {
+			return nil, fmt.Errorf("tlv record %d truncated", t)
+		}
+
+		s[t] = data[:length]
+		data = data[length:]
+		first, last = false, t
+	}
+	return s, nil
+}
+
+// SetUint64 stores v as a truncated integer (tu64), like BOLT messages do.
+func (s TLVStream) SetUint64(t uint64, v uint64) {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], v)
+	i := 0
+	for i < 8 && buf[i] == 0 {
+		i++
+	}
+	s[t] = append([]byte{}, buf[i:]...)
+}
+
+// Uint64 reads a record stored with SetUint64. Values with leading zeros are
+// not minimal and are rejected.
+func (s TLVStream) Uint64(t uint64) (v uint64, ok bool) {
+	value, ok := s[t]
+	if !ok || len(value) > 8 || (len(value) > 0 && value[0] == 0) {
+		return 0, false
+	}
+	for _, b := range value {
+		v = v<<8 | uint64(b)
+	}
+	return v, true
+}
+
+func appendBigSize(out []byte, v uint64) []byte {
+	switch {
+	case v < 0xfd:
+		return append(out, byte(v))
+	case v <= 0xffff:
+		return binary.BigEndian.AppendUint16(append(out, 0xfd), uint16(v))
+	case v <= 0xffffffff:
+		return binary.BigEndian.AppendUint32(append(out, 0xfe), uint32(v))
+	default:
+		return binary.BigEndian.AppendUint64(append(out, 0xff), v)
+	}
+}
+
+var errBigSize = errors.New("invalid bigsize")
+
+// readBigSize returns the value and how many bytes it took.
+func readBigSize(data []byte) (v uint64, n int, err error) {
+	if len(data) == 0 {
+		return 0, 0, errBigSize
+	}
+	switch data[0] {
+	case 0xfd:
+		if len(data) < 3 {
+			return 0, 0, errBigSize
+		}
+		v, n = uint64(binary.BigEndian.Uint16(data[1:])), 3
+		if v < 0xfd {
+			return 0, 0, errBigSize
+		}
+	case 0xfe:
+		if len(data) < 5 {
+			return 0, 0, errBigSize
+		}
+		v, n = uint64(binary.BigEndian.Uint32(data[1:])), 5
+		if v <= 0xffff {
+			return 0, 0, errBigSize
+		}
+	case 0xff:
+		if len(data) < 9 {
+			return 0, 0, errBigSize
+		}
+		v, n = binary.BigEndian.Uint64(data[1:]), 9
+		if v <= 0xffffffff {
+			return 0, 0, errBigSize
+		}
+	default:
+		v, n = uint64(data[0]), 1
+	}
+	return v, n, nil
+}
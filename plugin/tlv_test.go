@@ -0,0 +1,154 @@
+package plugin
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// test vectors from BOLT #1, appendix A
+func TestBigSize(t *testing.T) {
+	for _, c := range []struct {
+		name  string
+		value uint64
+		bytes string
+	}{
+		{"zero", 0, "00"},
+		{"one byte high", 252, "fc"},
+		{"two byte low", 253, "fd00fd"},
+		{"two byte high", 65535, "fdffff"},
+		{"four byte low", 65536, "fe00010000"},
+		{"four byte high", 4294967295, "feffffffff"},
+		{"eight byte low", 4294967296, "ff0000000100000000"},
+		{"eight byte high", 18446744073709551615, "ffffffffffffffffff"},
+	} {
+		data, _ := hex.DecodeString(c.bytes)
+		v, n, err := readBigSize(data)
+		if err != nil || v != c.value || n != len(data) {
+			t.Errorf("%s: decoded %d (%d bytes), %v", c.name, v, n, err)
+		}
+		if encoded := appendBigSize(nil, c.value); !bytes.Equal(encoded, data) {
+			t.Errorf("%s: encoded %x", c.name, encoded)
+		}
+	}
+
+	for _, c := range []struct {
+		name  string
+		bytes string
+	}{
+		{"two byte not canonical", "fd00fc"},
+		{"four byte not canonical", "fe0000ffff"},
+		{"eight byte not canonical", "ff00000000ffffffff"},
+		{"two byte short read", "fd00"},
+		{"four byte short read", "feffff"},
+		{"eight byte short read", "ffffffffff"},
+		{"one byte no read", ""},
+		{"two byte no read", "fd"},
+		{"four byte no read", "fe"},
+		{"eight byte no read", "ff"},
+	} {
+		data, _ := hex.DecodeString(c.bytes)
+		if v, _, err := readBigSize(data); err == nil {
+			t.Errorf("%s: decoded %d", c.name, v)
+		}
+	}
+}
+
+// test vectors from BOLT #1, appendix B
+func TestDecodeTLVStream(t *testing.T) {
+	for _, stream := range []string{
+		"",
+		"2100",
+		"fd020100",
+		"fd00fd00",
+		"fd00ff00",
+		"fe0200000100",
+		"ff020000000000000100",
+		// n1 records
+		"0100",
+		"010101",
+		"01020100",
+		"0103010000",
+		"010401000000",
+		"01050100000000",
+		"0106010000000000",
+		"010701000000000000",
+		"01080100000000000000",
+		"02080000000000000226",
+		"0331023da092f6980e58d2c037173180e9a465476026ee50f96695963e8efe436f54eb00000000000000010000000000000002",
+		"fd00fe020226",
+		"01020100fd00fe020226",
+	} {
+		data, _ := hex.DecodeString(stream)
+		s, err := DecodeTLVStream(data)
+		if err != nil {
+			t.Errorf("%q: %s", stream, err)
+			continue
+		}
+		if encoded := s.Encode(); !bytes.Equal(encoded, data) {
+			t.Errorf("%q: encoded back as %x", stream, encoded)
+		}
+	}
+
+	for name, stream := range map[string]string{
+		"type truncated":                   "fd",
+		"type truncated, two bytes":        "fd01",
+		"type not minimally encoded":       "fd000100",
+		"missing length":                   "fd0101",
+		"length truncated":                 "0ffd",
+		"length truncated, two bytes":      "0ffd26",
+		"missing value":                    "0ffd2602",
+		"length not minimally encoded":     "0ffd000100",
+		"value truncated":                  "0ffd0201" + strings.Repeat("00", 256),
+		"duplicate":                        "0208000000000000023102080000000000000451",
+		"out of order":                     "1f000f012a",
+		"duplicate, empty values":          "1f001f012a",
+		"out of order, with a larger type": "ffffffffffffffffff000000",
+		"n1 records out of order":          "02080000000000000226" + "0100",
+		"n1 record after a larger n1 type": "fd00fe020226" + "02080000000000000226",
+	} {
+		data, _ := hex.DecodeString(stream)
+		if s, err := DecodeTLVStream(data); err == nil {
+			t.Errorf("%s: decoded %v", name, s)
+		}
+	}
+}
+
+func TestTLVUint64(t *testing.T) {
+	for stream, expected := range map[string]uint64{
+		"0100":                 0,
+		"010101":               1,
+		"01020100":             256,
+		"0103010000":           65536,
+		"010401000000":         16777216,
+		"01050100000000":       4294967296,
+		"0106010000000000":     1099511627776,
+		"010701000000000000":   281474976710656,
+		"01080100000000000000": 72057594037927936,
+	} {
+		data, _ := hex.DecodeString(stream)
+		s, err := DecodeTLVStream(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v, ok := s.Uint64(1); !ok || v != expected {
+			t.Errorf("%s: %d, %v", stream, v, ok)
+		}
+
+		encoded := TLVStream{}
+		encoded.SetUint64(1, expected)
+		if !bytes.Equal(encoded.Encode(), data) {
+			t.Errorf("%d: encoded as %x", expected, encoded.Encode())
+		}
+	}
+
+	// tu64 must be minimal
+	for _, stream := range []string{"010100", "01020001", "0109010000000000000000"} {
+		data, _ := hex.DecodeString(stream)
+		s, _ := DecodeTLVStream(data)
+		if v, ok := s.Uint64(1); ok {
+			t.Errorf("%s: decoded %d", stream, v)
+		}
+	}
+}
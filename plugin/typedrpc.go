@@ -0,0 +1,157 @@
+package plugin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	lightning "github.com/fiatjaf/lightningd-gjson-rpc"
+)
+
+// NewRPCMethod creates an RPC method whose params are decoded into a struct.
+// The usage string comes from the fields of Req, in order: the name is taken
+// from the json tag (or the lowercased field name) and fields that are pointers
+// or have omitempty are optional. Params can be given by position or by name,
+// and values of the wrong type are rejected before handler is called.
+//
+//	type PayReq struct {
+//		Bolt11 string          `json:"bolt11"`
+//		Amount *lightning.Msat `json:"amount_msat"`
+//	}
+//	// usage: "bolt11 [amount_msat]"
+//
+// An error returned by handler is sent with code -1, or with its code if it
+// is a lightning.ErrorCommand.
+func NewRPCMethod[Req interface{}, Resp interface{}](
+	name string,
+	description string,
+	handler func(p *Plugin, req Req) (Resp, error),
+) RPCMethod {
+	var req Req
+	fields := paramFields(reflect.TypeOf(req))
+
+	usage := make([]string, len(fields))
+	for i, field := range fields {
+		if field.optional {
+			usage[i] = "[" + field.name + "]"
+		} else {
+			usage[i] = field.name
+		}
+	}
+
+	call := func(p *Plugin, params Params, positional []interface{}) (interface{}, int, error) {
+		var req Req
+		if err := decodeParams(params, fields, positional, &req); err != nil {
+			return nil, 400, err
+		}
+
+		resp, err := handler(p, req)
+		if err != nil {
+			var cmderr lightning.ErrorCommand
+			if errors.As(err, &cmderr) {
+				return nil, cmderr.Code, errors.New(cmderr.Message)
+			}
+			return nil, -1, err
+		}
+		return resp, 0, nil
+	}
+
+	return RPCMethod{
+		Name:        name,
+		Usage:       strings.Join(usage, " "),
+		Description: description,
+		Handler: func(p *Plugin, params Params) (interface{}, int, error) {
+			return call(p, params, nil)
+		},
+		typedHandler: call,
+	}
+}
+
+type paramField struct {
+	name     string
+	optional bool
+	isString bool
+}
+
+func paramFields(t reflect.Type) []paramField {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	fields := make([]paramField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			// unexported
+			continue
+		}
+
+		name := strings.ToLower(f.Name)
+		optional := f.Type.Kind() == reflect.Ptr
+		if tag, ok := f.Tag.Lookup("json"); ok {
+			parts := strings.Split(tag, ",")
+			if parts[0] == "-" {
+				continue
+			}
+			if parts[0] != "" {
+				name = parts[0]
+			}
+			for _, opt := range parts[1:] {
+				if opt == "omitempty" {
+					optional = true
+				}
+			}
+		}
+
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+
+		fields = append(fields, paramField{
+			name:     name,
+			optional: optional,
+			isString: ft.Kind() == reflect.String,
+		})
+	}
+	return fields
+}
+
+// decodeParams fills req from params as given by GetParams. positional holds
+// the params as they came in the request, if they were given by position.
+func decodeParams(params Params, fields []paramField, positional []interface{}, req interface{}) error {
+	values := make(map[string]interface{}, len(params))
+	for k, v := range params {
+		values[k] = v
+	}
+
+	// GetParams parses positional strings as JSON, because they may come from
+	// the command line, so `lightning-cli method 123` gives a number even if
+	// we wanted "123". For string fields we take the string as it was sent.
+	for i, field := range fields {
+		if i >= len(positional) || !field.isString {
+			continue
+		}
+		if s, ok := positional[i].(string); ok {
+			values[field.name] = s
+		}
+	}
+
+	j, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(j, req); err != nil {
+		var typeerr *json.UnmarshalTypeError
+		if errors.As(err, &typeerr) {
+			return fmt.Errorf("invalid value for %s: expected %s, got %s", typeerr.Field, typeerr.Type, typeerr.Value)
+		}
+		return fmt.Errorf("invalid params: %w", err)
+	}
+	return nil
+}
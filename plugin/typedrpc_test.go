@@ -0,0 +1,91 @@
+package plugin_test
+
+import (
+	"errors"
+	"testing"
+
+	lightning "github.com/fiatjaf/lightningd-gjson-rpc"
+	"github.com/fiatjaf/lightningd-gjson-rpc/plugin"
+	"github.com/fiatjaf/lightningd-gjson-rpc/plugin/plugintest"
+)
+
+type greetParams struct {
+	Name  string `json:"name"`
+	Times *int   `json:"times"`
+}
+
+func greetPlugin() *plugin.Plugin {
+	return &plugin.Plugin{
+		Name: "greeter",
+		RPCMethods: []plugin.RPCMethod{
+			plugin.NewRPCMethod("greet", "Greets {name}.",
+				func(p *plugin.Plugin, params greetParams) (map[string]interface{}, error) {
+					times := 1
+					if params.Times != nil {
+						times = *params.Times
+					}
+					return map[string]interface{}{"name": params.Name, "times": times}, nil
+				}),
+		},
+	}
+}
+
+func TestRPCMethodUsage(t *testing.T) {
+	if usage := greetPlugin().RPCMethods[0].Usage; usage != "name [times]" {
+		t.Errorf("usage = %q", usage)
+	}
+}
+
+func TestRPCMethodParams(t *testing.T) {
+	h := plugintest.New(t, greetPlugin())
+	h.Start(t, nil)
+
+	for _, c := range []struct {
+		params interface{}
+		name   string
+		times  int64
+	}{
+		{[]interface{}{"bob"}, "bob", 1},
+		{[]interface{}{"bob", "3"}, "bob", 3},
+		// lightning-cli greet 123 sends "123", which GetParams parses as a number
+		{[]interface{}{"123"}, "123", 1},
+		{[]interface{}{"true"}, "true", 1},
+		// strings that look like JSON must arrive as they were sent
+		{[]interface{}{"12345678901234567890"}, "12345678901234567890", 1},
+		{[]interface{}{"0.10"}, "0.10", 1},
+		{[]interface{}{"1e3"}, "1e3", 1},
+		{[]interface{}{"null"}, "null", 1},
+		{[]interface{}{"\"quoted\""}, "\"quoted\"", 1},
+		{map[string]interface{}{"name": "bob", "times": 2}, "bob", 2},
+	} {
+		res, err := h.Call("greet", c.params)
+		if err != nil {
+			t.Errorf("%v: %s", c.params, err)
+			continue
+		}
+		if res.Get("name").String() != c.name || res.Get("times").Int() != c.times {
+			t.Errorf("%v: got %s", c.params, res.Raw)
+		}
+	}
+}
+
+func TestRPCMethodRejectsWrongTypes(t *testing.T) {
+	h := plugintest.New(t, greetPlugin())
+	h.Start(t, nil)
+
+	for expected, params := range map[string]interface{}{
+		"invalid value for name: expected string, got number": map[string]interface{}{"name": 123},
+		"invalid value for name: expected string, got bool":   map[string]interface{}{"name": true},
+		"invalid value for times: expected int, got string":   map[string]interface{}{"name": "bob", "times": "x"},
+	} {
+		_, err := h.Call("greet", params)
+		var cmderr lightning.ErrorCommand
+		if !errors.As(err, &cmderr) || cmderr.Code != 400 || cmderr.Message != expected {
+			t.Errorf("%v: got %v", params, err)
+		}
+	}
+
+	if _, err := h.Call("greet", []interface{}{}); err == nil {
+		t.Error("missing required param accepted")
+	}
+}
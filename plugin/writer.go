@@ -0,0 +1,35 @@
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// writer sends messages to lightningd. Each message is encoded fully before
+// being written, and writes happen one at a time, so responses from handlers
+// running concurrently never get mixed. A slow reader on the other side makes
+// write block, which in turn stops new handlers from being started.
+type writer struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+func newWriter(out io.Writer) *writer {
+	return &writer{out: out}
+}
+
+func (w *writer) write(v interface{}) error {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, err := w.out.Write(buffer.Bytes())
+	return err
+}
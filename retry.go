@@ -0,0 +1,135 @@
+package lightning
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// RetryPolicy controls how failed calls are retried, for both socket and spark
+// clients. All attempts and the waits between them fit in the call timeout.
+type RetryPolicy struct {
+	// MaxAttempts counts the first try, so 1 disables retries.
+	MaxAttempts int
+
+	// InitialBackoff is multiplied by Multiplier after each attempt, up to MaxBackoff.
+	// Jitter randomizes each wait by up to that fraction, 0.2 means ±20%.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	Jitter         float64
+
+	// RetryOn lists the error kinds (see ErrorKind) that are retried.
+	RetryOn []string
+	// RetryCodes lists lightningd error codes that are retried, for "command" errors.
+	RetryCodes []int
+
+	// IdempotentMethods are safe to call again even if lightningd may have
+	// received the first request. Other methods are only retried when we
+	// couldn't connect to the socket at all. A trailing * matches a prefix.
+	IdempotentMethods []string
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    7,
+	InitialBackoff: time.Millisecond * 500,
+	MaxBackoff:     time.Second * 8,
+	Multiplier:     2,
+	Jitter:         0.2,
+	RetryOn:        []string{"connect", "broken"},
+	IdempotentMethods: []string{
+		"getinfo", "list*", "decode*", "getroute", "feerates",
+		"waitanyinvoice", "waitinvoice", "waitsendpay", "checkmessage",
+	},
+}
+
+func (ln *Client) retryPolicy() *RetryPolicy {
+	if ln.RetryPolicy != nil {
+		return ln.RetryPolicy
+	}
+	return &DefaultRetryPolicy
+}
+
+// backoff is the wait before the given attempt (1 is the first retry).
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	d := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		d *= 1 + p.Jitter*(rand.Float64()*2-1)
+	}
+	return time.Duration(d)
+}
+
+// shouldRetry tells if a call that failed with err can be made again.
+// delivered is false when we know the request never reached lightningd.
+func (p *RetryPolicy) shouldRetry(method string, err error, delivered bool) bool {
+	kind := ErrorKind(err)
+
+	retryable := false
+	for _, k := range p.RetryOn {
+		if k == kind {
+			retryable = true
+			break
+		}
+	}
+	if cmderr, ok := err.(ErrorCommand); ok {
+		for _, code := range p.RetryCodes {
+			if code == cmderr.Code {
+				retryable = true
+				break
+			}
+		}
+	}
+	if !retryable {
+		return false
+	}
+
+	return !delivered || p.isIdempotent(method)
+}
+
+func (p *RetryPolicy) isIdempotent(method string) bool {
+	for _, m := range p.IdempotentMethods {
+		if m == method || (strings.HasSuffix(m, "*") && strings.HasPrefix(method, m[:len(m)-1])) {
+			return true
+		}
+	}
+	return false
+}
+
+// withRetries calls attempt until it succeeds, the policy gives up or the
+// deadline given by timeout doesn't leave time for another try.
+func (ln *Client) withRetries(
+	timeout time.Duration,
+	method string,
+	attempt func(timeout time.Duration) (res []byte, delivered bool, err error),
+) (res []byte, err error) {
+	policy := ln.retryPolicy()
+	deadline := time.Now().Add(timeout)
+
+	for n := 1; ; n++ {
+		var delivered bool
+		res, delivered, err = attempt(time.Until(deadline))
+		if err == nil {
+			return res, nil
+		}
+		if n >= policy.MaxAttempts || !policy.shouldRetry(method, err, delivered) {
+			return nil, err
+		}
+
+		wait := policy.backoff(n)
+		if time.Now().Add(wait).After(deadline) {
+			return nil, err
+		}
+		if ln.Metrics != nil {
+			ln.Metrics.observeReconnect()
+		}
+		time.Sleep(wait)
+	}
+}
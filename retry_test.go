@@ -0,0 +1,141 @@
+package lightning_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	lightning "github.com/fiatjaf/lightningd-gjson-rpc"
+	"github.com/fiatjaf/lightningd-gjson-rpc/lightningtest"
+)
+
+func fastRetries(retryOn ...string) *lightning.RetryPolicy {
+	return &lightning.RetryPolicy{
+		MaxAttempts:       5,
+		InitialBackoff:    time.Millisecond,
+		Multiplier:        1,
+		RetryOn:           retryOn,
+		IdempotentMethods: []string{"getinfo"},
+	}
+}
+
+var reconnectsLine = regexp.MustCompile(`(?m)^lightning_rpc_reconnects_total (\d+)$`)
+
+func reconnects(t *testing.T, m *lightning.Metrics) int {
+	t.Helper()
+	var buf bytes.Buffer
+	m.WriteTo(&buf)
+	match := reconnectsLine.FindStringSubmatch(buf.String())
+	if match == nil {
+		t.Fatal("no reconnects metric")
+	}
+	n, _ := strconv.Atoi(match[1])
+	return n
+}
+
+func TestRetryDeadline(t *testing.T) {
+	ln := &lightning.Client{
+		Path:        filepath.Join(t.TempDir(), "missing"),
+		CallTimeout: time.Millisecond * 200,
+		Metrics:     lightning.NewMetrics(),
+		RetryPolicy: &lightning.RetryPolicy{
+			MaxAttempts:    100,
+			InitialBackoff: time.Millisecond * 50,
+			Multiplier:     1,
+			RetryOn:        []string{"connect"},
+		},
+	}
+
+	start := time.Now()
+	_, err := ln.Call("getinfo")
+	elapsed := time.Since(start)
+	if _, ok := err.(lightning.ErrorConnect); !ok {
+		t.Errorf("expected a connect error, got %v", err)
+	}
+	if elapsed > time.Millisecond*300 {
+		t.Errorf("retried for %s with a timeout of 200ms", elapsed)
+	}
+	// a wait of 50ms fits 3 times in 200ms, not the 99 retries the policy allows
+	if n := reconnects(t, ln.Metrics); n < 1 || n > 4 {
+		t.Errorf("%d retries", n)
+	}
+}
+
+func TestRetryOnlyBeforeDelivery(t *testing.T) {
+	// nothing listening, so pay never reached lightningd and can be retried
+	ln := &lightning.Client{
+		Path:        filepath.Join(t.TempDir(), "missing"),
+		Metrics:     lightning.NewMetrics(),
+		RetryPolicy: fastRetries("connect"),
+	}
+	if _, err := ln.Call("pay", "lnbc1"); lightning.ErrorKind(err) != "connect" {
+		t.Errorf("pay: %v", err)
+	}
+	if n := reconnects(t, ln.Metrics); n != 4 {
+		t.Errorf("pay retried %d times before reaching lightningd", n)
+	}
+
+	// lightningd got it and hung up, only getinfo is safe to send again
+	rpc := lightningtest.NewServer(t)
+	rpc.HangUp("pay", true)
+	rpc.HangUp("getinfo", true)
+	ln = rpc.Client()
+	ln.RetryPolicy = fastRetries("broken")
+
+	ln.Call("pay", "lnbc1")
+	if n := len(rpc.Calls("pay")); n != 1 {
+		t.Errorf("pay sent %d times", n)
+	}
+	ln.Call("getinfo")
+	if n := len(rpc.Calls("getinfo")); n != 5 {
+		t.Errorf("getinfo sent %d times", n)
+	}
+}
+
+func TestRetrySpark(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// hang up on the first two requests
+		if atomic.AddInt32(&requests, 1) <= 2 {
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		w.Write([]byte(`{"id":"02aa"}`))
+	}))
+	defer server.Close()
+
+	ln := &lightning.Client{SparkURL: server.URL, RetryPolicy: fastRetries("connect")}
+	res, err := ln.Call("getinfo")
+	if err != nil || res.Get("id").String() != "02aa" {
+		t.Errorf("getinfo: %s, %v", res.Raw, err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 3 {
+		t.Errorf("%d requests", n)
+	}
+
+	// pay was delivered before the hang up, so it isn't sent again
+	atomic.StoreInt32(&requests, 0)
+	if _, err := ln.Call("pay", "lnbc1"); err == nil {
+		t.Error("pay didn't fail")
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("pay sent %d times", n)
+	}
+
+	// but it is when the server can't be reached at all
+	server.Close()
+	ln.Metrics = lightning.NewMetrics()
+	if _, err := ln.Call("pay", "lnbc1"); lightning.ErrorKind(err) != "connect" {
+		t.Errorf("pay: %v", err)
+	}
+	if n := reconnects(t, ln.Metrics); n != 4 {
+		t.Errorf("pay retried %d times before reaching the server", n)
+	}
+}
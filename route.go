@@ -3,11 +3,11 @@ package lightning
 import (
 	"errors"
 	"fmt"
-	"math"
 	"math/rand"
 	"strconv"
-	"strings"
 	"time"
+
+	"github.com/tidwall/gjson"
 )
 
 var (
@@ -24,8 +24,17 @@ type Graph struct {
 	channelMap   map[string]*Channel
 
 	maxhops       int
-	maxchannelfee int64
-	msatoshi      int64
+	maxchannelfee Msat
+	amount        Msat
+}
+
+// usable tells if g.amount can go through the channel.
+func (g *Graph) usable(channel *Channel) bool {
+	if g.amount < channel.HtlcMinimumMsat || g.amount > channel.HtlcMaximumMsat {
+		return false
+	}
+	fee, err := channel.Fee(g.amount, 0, 0)
+	return err == nil && fee <= g.maxchannelfee
 }
 
 func (g *Graph) SearchDualBFS(start string, end string) (path []*Channel) {
@@ -41,9 +50,7 @@ func (g *Graph) SearchDualBFS(start string, end string) (path []*Channel) {
 		fromEndNext := make(map[string][]*Channel)
 		for node, routeFrom := range fromEnd {
 			for _, channel := range g.channelsTo[node] {
-				if g.msatoshi < channel.HtlcMinimumMsat ||
-					g.msatoshi > channel.HtlcMaximumMsat ||
-					channel.Fee(g.msatoshi, 0, 0) > g.maxchannelfee {
+				if !g.usable(channel) {
 					continue
 				}
 
@@ -57,9 +64,7 @@ func (g *Graph) SearchDualBFS(start string, end string) (path []*Channel) {
 		fromStartNext := make(map[string][]*Channel)
 		for node, routeUntil := range fromStart {
 			for _, channel := range g.channelsFrom[node] {
-				if g.msatoshi < channel.HtlcMinimumMsat ||
-					g.msatoshi > channel.HtlcMaximumMsat ||
-					channel.Fee(g.msatoshi, 0, 0) > g.maxchannelfee {
+				if !g.usable(channel) {
 					continue
 				}
 
@@ -87,16 +92,20 @@ func (g *Graph) Sync() error {
 	g.channelsTo = make(map[string][]*Channel)
 	g.channelMap = make(map[string]*Channel)
 
-	// get channels data
-	res, err := g.client.CallWithCustomTimeout(time.Second*30, "listchannels")
-	if err != nil {
-		return err
-	}
-
-	for _, ch := range res.Get("channels").Array() {
-		htlcmin, _ := strconv.ParseInt(strings.Split(ch.Get("htlc_minimum_msat").String(), "m")[0], 10, 64)
-		htlcmax, _ := strconv.ParseInt(strings.Split(ch.Get("htlc_maximum_msat").String(), "m")[0], 10, 64)
-
+	// get channels data, one channel at a time so we don't hold the whole response
+	err := g.client.CallStream(time.Second*30, JSONRPCMessage{
+		Method: "listchannels",
+		Filter: FilterFromPaths(
+			"channels.#.source",
+			"channels.#.destination",
+			"channels.#.short_channel_id",
+			"channels.#.base_fee_millisatoshi",
+			"channels.#.fee_per_millionth",
+			"channels.#.delay",
+			"channels.#.htlc_minimum_msat",
+			"channels.#.htlc_maximum_msat",
+		),
+	}, "channels", func(ch gjson.Result) error {
 		source := ch.Get("source").String()
 		destination := ch.Get("destination").String()
 		direction := 0
@@ -113,13 +122,17 @@ func (g *Graph) Sync() error {
 			FeePerMillionth:     ch.Get("fee_per_millionth").Int(),
 			Delay:               ch.Get("delay").Int(),
 			Direction:           direction,
-			HtlcMinimumMsat:     htlcmin,
-			HtlcMaximumMsat:     htlcmax,
+			HtlcMinimumMsat:     GetMsat(ch, "htlc_minimum_msat"),
+			HtlcMaximumMsat:     GetMsat(ch, "htlc_maximum_msat"),
 		}
 
 		g.channelsFrom[channel.Source] = append(g.channelsFrom[channel.Source], channel)
 		g.channelsTo[channel.Destination] = append(g.channelsTo[channel.Destination], channel)
 		g.channelMap[channel.ShortChannelID+"/"+strconv.Itoa(channel.Direction)] = channel
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	// reset counter
@@ -138,22 +151,24 @@ type Channel struct {
 	FeePerMillionth     int64  `json:"fee_per_millionth"`
 	Delay               int64  `json:"delay"`
 	Direction           int    `json:"direction"`
-	HtlcMinimumMsat     int64  `json:"htlc_minimum_msat"`
-	HtlcMaximumMsat     int64  `json:"htlc_maximum_msat"`
+	HtlcMinimumMsat     Msat   `json:"htlc_minimum_msat"`
+	HtlcMaximumMsat     Msat   `json:"htlc_maximum_msat"`
 }
 
-func (c *Channel) Fee(msatoshi, riskfactor int64, fuzzpercent float64) int64 {
-	fee := int64(math.Ceil(
-		float64(c.BaseFeeMillisatoshi) + float64(c.FeePerMillionth*msatoshi)/1000000,
-	))
-	fuzz := int64(rand.Float64() * fuzzpercent * float64(fee) / 100)
-	riskfee := c.Delay * msatoshi * riskfactor / 5259600
-	return fee + fuzz + riskfee
+func (c *Channel) Fee(amount Msat, riskfactor int64, fuzzpercent float64) (Msat, error) {
+	ppmfee, err := amount.PPM(c.FeePerMillionth)
+	if err != nil {
+		return 0, err
+	}
+	fee := Msat(c.BaseFeeMillisatoshi) + ppmfee
+	fuzz := Msat(rand.Float64() * fuzzpercent * float64(fee) / 100)
+	riskfee := Msat(c.Delay * int64(amount) * riskfactor / 5259600)
+	return fee + fuzz + riskfee, nil
 }
 
 func (ln *Client) GetRoute(
 	id string,
-	msatoshi int64,
+	amount Msat,
 	riskfactor int64,
 	cltv int64,
 	fromid string,
@@ -167,20 +182,18 @@ func (ln *Client) GetRoute(
 		return nil, errors.New("start == end")
 	}
 
-	path, err := ln.GetPath(id, msatoshi, fromid, exclude, maxhops, maxchannelfeepercent)
+	path, err := ln.GetPath(id, amount, fromid, exclude, maxhops, maxchannelfeepercent)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query path: %w", err)
 	}
 
 	// turn the path into a lightning route
-	route = PathToRoute(path, msatoshi, cltv, riskfactor, fuzzpercent)
-
-	return
+	return PathToRoute(path, amount, cltv, riskfactor, fuzzpercent)
 }
 
 func (ln *Client) GetPath(
 	id string,
-	msatoshi int64,
+	amount Msat,
 	fromid string,
 	exclude []string,
 	maxhops int,
@@ -207,9 +220,9 @@ func (ln *Client) GetPath(
 	}
 
 	// set globals
-	g.msatoshi = msatoshi
+	g.amount = amount
 	g.maxhops = maxhops
-	g.maxchannelfee = int64(maxchannelfeepercent * float64(msatoshi) / 100)
+	g.maxchannelfee = Msat(maxchannelfeepercent * float64(amount) / 100)
 
 	// get the best path
 	path = g.SearchDualBFS(fromid, id)
@@ -223,65 +236,76 @@ func (ln *Client) GetPath(
 
 func PathToRoute(
 	path []*Channel,
-	msatoshi int64,
+	amount Msat,
 	cltv int64,
 	riskfactor int64,
 	fuzzpercent float64,
-) (route []RouteHop) {
+) (route []RouteHop, err error) {
 	plen := len(path)
 	route = make([]RouteHop, plen)
 
 	// the last hop
 	channel := path[plen-1]
+	fee, err := channel.Fee(amount, riskfactor, fuzzpercent)
+	if err != nil {
+		return nil, err
+	}
 	route[plen-1] = RouteHop{
-		Channel:   channel.ShortChannelID,
-		Direction: channel.Direction,
-		Id:        channel.Destination,
-		Msatoshi:  msatoshi, // no fees for the last channel
-		Delay:     cltv,
+		Channel:    channel.ShortChannelID,
+		Direction:  channel.Direction,
+		Id:         channel.Destination,
+		AmountMsat: amount, // no fees for the last channel
+		Delay:      cltv,
 
-		arrivingFee:   channel.Fee(msatoshi, riskfactor, fuzzpercent),
+		arrivingFee:   fee,
 		arrivingDelay: channel.Delay,
 	}
 
 	if plen == 1 {
 		// single-hop payment, end here
-		return route
+		return route, nil
 	}
 
 	// build the route from the ante-last hop backwards
 	for i := plen - 2; i >= 0; i-- {
 		nexthop := route[i+1]
 		channel := path[i]
-		amount := nexthop.Msatoshi + nexthop.arrivingFee
+		amount, err := nexthop.AmountMsat.Add(nexthop.arrivingFee)
+		if err != nil {
+			return nil, err
+		}
+		fee, err := channel.Fee(amount, riskfactor, fuzzpercent)
+		if err != nil {
+			return nil, err
+		}
 		route[i] = RouteHop{
-			Channel:   channel.ShortChannelID,
-			Direction: channel.Direction,
-			Id:        channel.Destination,
-			Msatoshi:  amount,
-			Delay:     nexthop.Delay + nexthop.arrivingDelay,
+			Channel:    channel.ShortChannelID,
+			Direction:  channel.Direction,
+			Id:         channel.Destination,
+			AmountMsat: amount,
+			Delay:      nexthop.Delay + nexthop.arrivingDelay,
 
-			arrivingFee:   channel.Fee(amount, riskfactor, fuzzpercent),
+			arrivingFee:   fee,
 			arrivingDelay: channel.Delay,
 		}
 	}
 
-	return route
+	return route, nil
 }
 
 type RouteHop struct {
-	Id        string `json:"id"`
-	Channel   string `json:"channel"`
-	Direction int    `json:"direction"`
-	Msatoshi  int64  `json:"msatoshi"`
-	Delay     int64  `json:"delay"`
+	Id         string `json:"id"`
+	Channel    string `json:"channel"`
+	Direction  int    `json:"direction"`
+	AmountMsat Msat   `json:"amount_msat"`
+	Delay      int64  `json:"delay"`
 
 	// fee and delay that must arrive here, so must be applied at the previous hop
-	arrivingFee   int64
+	arrivingFee   Msat
 	arrivingDelay int64
 }
 
-func unexclude(channel *Channel, htlcmax int64) {
+func unexclude(channel *Channel, htlcmax Msat) {
 	if channel == nil {
 		return
 	}
@@ -0,0 +1,5673 @@
+// Code generated by schemagen from testdata/schemas; DO NOT EDIT.
+
+package lightning
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// AddGossip calls `addgossip`: Command for injecting a gossip message (low-level).
+func (ln *Client) AddGossip(ctx context.Context, req AddGossipRequest) (resp AddGossipResponse, err error) {
+	err = ln.CallTyped(ctx, "addgossip", req, &resp)
+	return
+}
+
+// AddGossipRequest are the parameters for `addgossip`.
+type AddGossipRequest struct {
+	// The raw, hex-encoded, gossip message to add to the local gossip view.
+	Message string `json:"message"`
+}
+
+// AddGossipResponse is the result of `addgossip`.
+type AddGossipResponse struct {
+}
+
+// AddPsbtOutput calls `addpsbtoutput`: Command to populate PSBT outputs from the wallet.
+func (ln *Client) AddPsbtOutput(ctx context.Context, req AddPsbtOutputRequest) (resp AddPsbtOutputResponse, err error) {
+	err = ln.CallTyped(ctx, "addpsbtoutput", req, &resp)
+	return
+}
+
+// AddPsbtOutputRequest are the parameters for `addpsbtoutput`.
+type AddPsbtOutputRequest struct {
+	// The satoshi value of the output.
+	Satoshi uint64 `json:"satoshi"`
+	// Base 64 encoded PSBT to add the output to. If not specified, one will be generated automatically.
+	Initialpsbt *string `json:"initialpsbt,omitempty"`
+	// If not set, it is set to a recent block height (if no initial psbt is specified).
+	Locktime *uint32 `json:"locktime,omitempty"`
+	// If it is not set, an internal address is generated.
+	Destination *string `json:"destination,omitempty"`
+}
+
+// AddPsbtOutputResponse is the result of `addpsbtoutput`.
+type AddPsbtOutputResponse struct {
+	// Unsigned PSBT which fulfills the parameters given.
+	Psbt string `json:"psbt"`
+	// The estimated weight (in weight units) added to fulfill your parameters.
+	EstimatedAddedWeight uint32 `json:"estimated_added_weight"`
+	// The 0-based output number where your funds were assigned.
+	Outnum uint32 `json:"outnum"`
+}
+
+// AutoCleanOnce calls `autoclean-once`: A single deletion of old invoices/payments/forwards.
+func (ln *Client) AutoCleanOnce(ctx context.Context, req AutoCleanOnceRequest) (resp AutoCleanOnceResponse, err error) {
+	err = ln.CallTyped(ctx, "autoclean-once", req, &resp)
+	return
+}
+
+// AutoCleanOnceRequest are the parameters for `autoclean-once`.
+type AutoCleanOnceRequest struct {
+	// What type of object to clean.
+	Subsystem string `json:"subsystem"`
+	// Non-zero number in seconds. How old should objects be before deletion.
+	Age uint64 `json:"age"`
+}
+
+// AutoCleanOnceResponse is the result of `autoclean-once`.
+type AutoCleanOnceResponse struct {
+	Autoclean AutoCleanOnceAutoclean `json:"autoclean"`
+}
+
+type AutoCleanOnceAutoclean struct {
+	Succeededforwards AutoCleanOnceAutocleanSucceededforwards `json:"succeededforwards,omitempty"`
+	Failedforwards    AutoCleanOnceAutocleanFailedforwards    `json:"failedforwards,omitempty"`
+	Succeededpays     AutoCleanOnceAutocleanSucceededpays     `json:"succeededpays,omitempty"`
+	Failedpays        AutoCleanOnceAutocleanFailedpays        `json:"failedpays,omitempty"`
+	Paidinvoices      AutoCleanOnceAutocleanPaidinvoices      `json:"paidinvoices,omitempty"`
+	Expiredinvoices   AutoCleanOnceAutocleanExpiredinvoices   `json:"expiredinvoices,omitempty"`
+}
+
+type AutoCleanOnceAutocleanSucceededforwards struct {
+	Cleaned   uint64 `json:"cleaned"`
+	Uncleaned uint64 `json:"uncleaned"`
+}
+
+type AutoCleanOnceAutocleanFailedforwards struct {
+	Cleaned   uint64 `json:"cleaned"`
+	Uncleaned uint64 `json:"uncleaned"`
+}
+
+type AutoCleanOnceAutocleanSucceededpays struct {
+	Cleaned   uint64 `json:"cleaned"`
+	Uncleaned uint64 `json:"uncleaned"`
+}
+
+type AutoCleanOnceAutocleanFailedpays struct {
+	Cleaned   uint64 `json:"cleaned"`
+	Uncleaned uint64 `json:"uncleaned"`
+}
+
+type AutoCleanOnceAutocleanPaidinvoices struct {
+	Cleaned   uint64 `json:"cleaned"`
+	Uncleaned uint64 `json:"uncleaned"`
+}
+
+type AutoCleanOnceAutocleanExpiredinvoices struct {
+	Cleaned   uint64 `json:"cleaned"`
+	Uncleaned uint64 `json:"uncleaned"`
+}
+
+// AutoCleanStatus calls `autoclean-status`: Examine auto-delete of old invoices/payments/forwards.
+func (ln *Client) AutoCleanStatus(ctx context.Context, req AutoCleanStatusRequest) (resp AutoCleanStatusResponse, err error) {
+	err = ln.CallTyped(ctx, "autoclean-status", req, &resp)
+	return
+}
+
+// AutoCleanStatusRequest are the parameters for `autoclean-status`.
+type AutoCleanStatusRequest struct {
+	// What subsystem to ask about. Currently supported subsystems are: `failedforwards`, `succeededforwards`, `failedpays`, `succeededpays`, `paidinvoices` and `expiredinvoices`.
+	Subsystem *string `json:"subsystem,omitempty"`
+}
+
+// AutoCleanStatusResponse is the result of `autoclean-status`.
+type AutoCleanStatusResponse struct {
+	Autoclean AutoCleanStatusAutoclean `json:"autoclean"`
+}
+
+type AutoCleanStatusAutoclean struct {
+	Succeededforwards AutoCleanStatusAutocleanSucceededforwards `json:"succeededforwards,omitempty"`
+	Failedforwards    AutoCleanStatusAutocleanFailedforwards    `json:"failedforwards,omitempty"`
+	Succeededpays     AutoCleanStatusAutocleanSucceededpays     `json:"succeededpays,omitempty"`
+	Failedpays        AutoCleanStatusAutocleanFailedpays        `json:"failedpays,omitempty"`
+	Paidinvoices      AutoCleanStatusAutocleanPaidinvoices      `json:"paidinvoices,omitempty"`
+	Expiredinvoices   AutoCleanStatusAutocleanExpiredinvoices   `json:"expiredinvoices,omitempty"`
+}
+
+type AutoCleanStatusAutocleanSucceededforwards struct {
+	// Whether autocleaning is enabled for successful listforwards.
+	Enabled bool `json:"enabled"`
+	// Age (in seconds) to delete successful listforwards.
+	Age uint64 `json:"age,omitempty"`
+	// Total number of deletions done (ever).
+	Cleaned uint64 `json:"cleaned"`
+}
+
+type AutoCleanStatusAutocleanFailedforwards struct {
+	// Whether autocleaning is enabled for failed listforwards.
+	Enabled bool `json:"enabled"`
+	// Age (in seconds) to delete failed listforwards.
+	Age uint64 `json:"age,omitempty"`
+	// Total number of deletions done (ever).
+	Cleaned uint64 `json:"cleaned"`
+}
+
+type AutoCleanStatusAutocleanSucceededpays struct {
+	// Whether autocleaning is enabled for successful listpays/listsendpays.
+	Enabled bool `json:"enabled"`
+	// Age (in seconds) to delete successful listpays/listsendpays.
+	Age uint64 `json:"age,omitempty"`
+	// Total number of deletions done (ever).
+	Cleaned uint64 `json:"cleaned"`
+}
+
+type AutoCleanStatusAutocleanFailedpays struct {
+	// Whether autocleaning is enabled for failed listpays/listsendpays.
+	Enabled bool `json:"enabled"`
+	// Age (in seconds) to delete failed listpays/listsendpays.
+	Age uint64 `json:"age,omitempty"`
+	// Total number of deletions done (ever).
+	Cleaned uint64 `json:"cleaned"`
+}
+
+type AutoCleanStatusAutocleanPaidinvoices struct {
+	// Whether autocleaning is enabled for paid listinvoices.
+	Enabled bool `json:"enabled"`
+	// Age (in seconds) to paid listinvoices.
+	Age uint64 `json:"age,omitempty"`
+	// Total number of deletions done (ever).
+	Cleaned uint64 `json:"cleaned"`
+}
+
+type AutoCleanStatusAutocleanExpiredinvoices struct {
+	// Whether autocleaning is enabled for expired (unpaid) listinvoices.
+	Enabled bool `json:"enabled"`
+	// Age (in seconds) to expired listinvoices.
+	Age uint64 `json:"age,omitempty"`
+	// Total number of deletions done (ever).
+	Cleaned uint64 `json:"cleaned"`
+}
+
+// Batching calls `batching`: Command to allow database batching.
+func (ln *Client) Batching(ctx context.Context, req BatchingRequest) (resp BatchingResponse, err error) {
+	err = ln.CallTyped(ctx, "batching", req, &resp)
+	return
+}
+
+// BatchingRequest are the parameters for `batching`.
+type BatchingRequest struct {
+	// Whether to enable or disable transaction batching.
+	Enable bool `json:"enable"`
+}
+
+// BatchingResponse is the result of `batching`.
+type BatchingResponse struct {
+}
+
+// BkprChannelsApy calls `bkpr-channelsapy`: Command to list stats on channel earnings.
+func (ln *Client) BkprChannelsApy(ctx context.Context, req BkprChannelsApyRequest) (resp BkprChannelsApyResponse, err error) {
+	err = ln.CallTyped(ctx, "bkpr-channelsapy", req, &resp)
+	return
+}
+
+// BkprChannelsApyRequest are the parameters for `bkpr-channelsapy`.
+type BkprChannelsApyRequest struct {
+	// UNIX timestamp (in seconds) that filters events after the provided timestamp.
+	StartTime *uint64 `json:"start_time,omitempty"`
+	// UNIX timestamp (in seconds) that filters events up to and at the provided timestamp.
+	EndTime *uint64 `json:"end_time,omitempty"`
+}
+
+// BkprChannelsApyResponse is the result of `bkpr-channelsapy`.
+type BkprChannelsApyResponse struct {
+	ChannelsApy []BkprChannelsApyChannelsApy `json:"channels_apy"`
+}
+
+type BkprChannelsApyChannelsApy struct {
+	// The account name. If the account is a channel, the channel_id. The 'net' entry is the rollup of all channel accounts.
+	Account string `json:"account"`
+	// Sats routed (outbound).
+	RoutedOutMsat Msat `json:"routed_out_msat"`
+	// Sats routed (inbound).
+	RoutedInMsat Msat `json:"routed_in_msat"`
+	// Sats paid for leasing inbound (liquidity ads).
+	LeaseFeePaidMsat Msat `json:"lease_fee_paid_msat"`
+	// Sats earned for leasing outbound (liquidity ads).
+	LeaseFeeEarnedMsat Msat `json:"lease_fee_earned_msat"`
+	// Sats pushed to peer at open.
+	PushedOutMsat Msat `json:"pushed_out_msat"`
+	// Sats pushed in from peer at open.
+	PushedInMsat Msat `json:"pushed_in_msat"`
+	// Starting balance in channel at funding. Note that if our start balance is zero, any _initial field will be omitted (can't divide by zero).
+	OurStartBalanceMsat Msat `json:"our_start_balance_msat"`
+	// Total starting balance at funding.
+	ChannelStartBalanceMsat Msat `json:"channel_start_balance_msat"`
+	// Fees earned on routed outbound.
+	FeesOutMsat Msat `json:"fees_out_msat"`
+	// Fees earned on routed inbound.
+	FeesInMsat Msat `json:"fees_in_msat,omitempty"`
+	// Sats routed outbound / total start balance.
+	UtilizationOut string `json:"utilization_out"`
+	// Sats routed outbound / our start balance.
+	UtilizationOutInitial string `json:"utilization_out_initial,omitempty"`
+	// Sats routed inbound / total start balance.
+	UtilizationIn string `json:"utilization_in"`
+	// Sats routed inbound / our start balance.
+	UtilizationInInitial string `json:"utilization_in_initial,omitempty"`
+	// Fees earned on outbound routed payments / total start balance for the length of time this channel has been open amortized to a year (APY).
+	ApyOut string `json:"apy_out"`
+	// Fees earned on outbound routed payments / our start balance for the length of time this channel has been open amortized to a year (APY).
+	ApyOutInitial string `json:"apy_out_initial,omitempty"`
+	// Fees earned on inbound routed payments / total start balance for the length of time this channel has been open amortized to a year (APY).
+	ApyIn string `json:"apy_in"`
+	// Fees earned on inbound routed payments / our start balance for the length of time this channel has been open amortized to a year (APY).
+	ApyInInitial string `json:"apy_in_initial,omitempty"`
+	// Total fees earned on routed payments / total start balance for the length of time this channel has been open amortized to a year (APY).
+	ApyTotal string `json:"apy_total"`
+	// Total fees earned on routed payments / our start balance for the length of time this channel has been open amortized to a year (APY).
+	ApyTotalInitial string `json:"apy_total_initial,omitempty"`
+	// Lease fees earned over total amount leased for the lease term, amortized to a year (APY). Only appears if channel was leased out by us.
+	ApyLease string `json:"apy_lease,omitempty"`
+}
+
+// BkprDumpIncomeCsv calls `bkpr-dumpincomecsv`: Command to emit a CSV of income events.
+func (ln *Client) BkprDumpIncomeCsv(ctx context.Context, req BkprDumpIncomeCsvRequest) (resp BkprDumpIncomeCsvResponse, err error) {
+	err = ln.CallTyped(ctx, "bkpr-dumpincomecsv", req, &resp)
+	return
+}
+
+// BkprDumpIncomeCsvRequest are the parameters for `bkpr-dumpincomecsv`.
+type BkprDumpIncomeCsvRequest struct {
+	// CSV format to use. See RETURN VALUE for options.
+	CsvFormat string `json:"csv_format"`
+	// Filename to write to.
+	CsvFile *string `json:"csv_file,omitempty"`
+	// If true, we emit a single, consolidated event for any onchain-fees for a txid and account. Otherwise, events for every update to the onchain fee calculation for this account and txid will be printed. Note that this means that the events emitted are non-stable, i.e. calling dumpincomecsv twice may result in different onchain fee events being emitted, depending on how much information we've logged for that transaction.
+	ConsolidateFees *bool `json:"consolidate_fees,omitempty"`
+	// UNIX timestamp (in seconds) that filters events after the provided timestamp.
+	StartTime *uint64 `json:"start_time,omitempty"`
+	// UNIX timestamp (in seconds) that filters events up to and at the provided timestamp.
+	EndTime *uint64 `json:"end_time,omitempty"`
+}
+
+// BkprDumpIncomeCsvResponse is the result of `bkpr-dumpincomecsv`.
+type BkprDumpIncomeCsvResponse struct {
+	// File that the csv was generated to.
+	CsvFile string `json:"csv_file"`
+	// Format to print csv as.
+	CsvFormat string `json:"csv_format"`
+}
+
+// BkprEditDescriptionByOutpoint calls `bkpr-editdescriptionbyoutpoint`: Command to change the description for events with {outpoint}.
+func (ln *Client) BkprEditDescriptionByOutpoint(ctx context.Context, req BkprEditDescriptionByOutpointRequest) (resp BkprEditDescriptionByOutpointResponse, err error) {
+	err = ln.CallTyped(ctx, "bkpr-editdescriptionbyoutpoint", req, &resp)
+	return
+}
+
+// BkprEditDescriptionByOutpointRequest are the parameters for `bkpr-editdescriptionbyoutpoint`.
+type BkprEditDescriptionByOutpointRequest struct {
+	// The outpoint to update the description for.
+	Outpoint string `json:"outpoint"`
+	// The description to update to.
+	Description string `json:"description"`
+}
+
+// BkprEditDescriptionByOutpointResponse is the result of `bkpr-editdescriptionbyoutpoint`.
+type BkprEditDescriptionByOutpointResponse struct {
+	Updated []BkprEditDescriptionByOutpointUpdated `json:"updated"`
+}
+
+type BkprEditDescriptionByOutpointUpdated struct {
+	// The account name. If the account is a channel, the channel_id.
+	Account string `json:"account"`
+	// Coin movement type.
+	Type string `json:"type"`
+	// Description of movement.
+	Tag string `json:"tag"`
+	// Amount credited.
+	CreditMsat Msat `json:"credit_msat"`
+	// Amount debited.
+	DebitMsat Msat `json:"debit_msat"`
+	// Human-readable bech32 part for this coin type.
+	Currency string `json:"currency"`
+	// Timestamp this event was recorded by the node. For consolidated events such as onchain_fees, the most recent timestamp.
+	Timestamp uint32 `json:"timestamp"`
+	// The description of this event.
+	Description string `json:"description"`
+	// The txid:outnum for this event.
+	Outpoint string `json:"outpoint,omitempty"`
+	// For chain events, blockheight this occured at.
+	Blockheight uint32 `json:"blockheight,omitempty"`
+	// The account this movement originated from.
+	Origin string `json:"origin,omitempty"`
+	// Lightning payment identifier. For an htlc, this will be the preimage.
+	PaymentID string `json:"payment_id,omitempty"`
+	// The txid of the transaction that created this event.
+	Txid string `json:"txid,omitempty"`
+	// Amount paid in fees.
+	FeesMsat Msat `json:"fees_msat,omitempty"`
+	// Is this payment part of a rebalance.
+	IsRebalance bool `json:"is_rebalance,omitempty"`
+	// Counter for multi-part payments.
+	PartID uint32 `json:"part_id,omitempty"`
+}
+
+// BkprEditDescriptionByPaymentID calls `bkpr-editdescriptionbypaymentid`: Command to change the description for events with {payment_id}.
+func (ln *Client) BkprEditDescriptionByPaymentID(ctx context.Context, req BkprEditDescriptionByPaymentIDRequest) (resp BkprEditDescriptionByPaymentIDResponse, err error) {
+	err = ln.CallTyped(ctx, "bkpr-editdescriptionbypaymentid", req, &resp)
+	return
+}
+
+// BkprEditDescriptionByPaymentIDRequest are the parameters for `bkpr-editdescriptionbypaymentid`.
+type BkprEditDescriptionByPaymentIDRequest struct {
+	// The payment hash of the invoice, or the id of the payment, to update the description for.
+	PaymentID string `json:"payment_id"`
+	// The description to update to.
+	Description string `json:"description"`
+}
+
+// BkprEditDescriptionByPaymentIDResponse is the result of `bkpr-editdescriptionbypaymentid`.
+type BkprEditDescriptionByPaymentIDResponse struct {
+	Updated []BkprEditDescriptionByPaymentIDUpdated `json:"updated"`
+}
+
+type BkprEditDescriptionByPaymentIDUpdated struct {
+	// The account name. If the account is a channel, the channel_id.
+	Account string `json:"account"`
+	// Coin movement type.
+	Type string `json:"type"`
+	// Description of movement.
+	Tag string `json:"tag"`
+	// Amount credited.
+	CreditMsat Msat `json:"credit_msat"`
+	// Amount debited.
+	DebitMsat Msat `json:"debit_msat"`
+	// Human-readable bech32 part for this coin type.
+	Currency string `json:"currency"`
+	// Timestamp this event was recorded by the node. For consolidated events such as onchain_fees, the most recent timestamp.
+	Timestamp uint32 `json:"timestamp"`
+	// The description of this event.
+	Description string `json:"description"`
+	// The txid:outnum for this event.
+	Outpoint string `json:"outpoint,omitempty"`
+	// For chain events, blockheight this occured at.
+	Blockheight uint32 `json:"blockheight,omitempty"`
+	// The account this movement originated from.
+	Origin string `json:"origin,omitempty"`
+	// Lightning payment identifier. For an htlc, this will be the preimage.
+	PaymentID string `json:"payment_id,omitempty"`
+	// The txid of the transaction that created this event.
+	Txid string `json:"txid,omitempty"`
+	// Amount paid in fees.
+	FeesMsat Msat `json:"fees_msat,omitempty"`
+	// Is this payment part of a rebalance.
+	IsRebalance bool `json:"is_rebalance,omitempty"`
+	// Counter for multi-part payments.
+	PartID uint32 `json:"part_id,omitempty"`
+}
+
+// BkprInspect calls `bkpr-inspect`: Command to show onchain footprint of a channel.
+func (ln *Client) BkprInspect(ctx context.Context, req BkprInspectRequest) (resp BkprInspectResponse, err error) {
+	err = ln.CallTyped(ctx, "bkpr-inspect", req, &resp)
+	return
+}
+
+// BkprInspectRequest are the parameters for `bkpr-inspect`.
+type BkprInspectRequest struct {
+	// Channel account to inspect.
+	Account string `json:"account"`
+}
+
+// BkprInspectResponse is the result of `bkpr-inspect`.
+type BkprInspectResponse struct {
+	Txs []BkprInspectTxs `json:"txs"`
+}
+
+type BkprInspectTxs struct {
+	// Transaction id.
+	Txid string `json:"txid"`
+	// Blockheight of transaction.
+	Blockheight uint32 `json:"blockheight,omitempty"`
+	// Amount paid in sats for this tx.
+	FeesPaidMsat Msat                    `json:"fees_paid_msat"`
+	Outputs      []BkprInspectTxsOutputs `json:"outputs"`
+}
+
+type BkprInspectTxsOutputs struct {
+	// Account this output affected.
+	Account string `json:"account"`
+	// Index of output.
+	Outnum uint32 `json:"outnum"`
+	// Value of the output.
+	OutputValueMsat Msat `json:"output_value_msat"`
+	// Human-readable bech32 part for this coin type.
+	Currency string `json:"currency"`
+	// Amount credited to account.
+	CreditMsat Msat `json:"credit_msat,omitempty"`
+	// Amount debited from account.
+	DebitMsat Msat `json:"debit_msat,omitempty"`
+	// Account this output originated from.
+	OriginatingAccount string `json:"originating_account,omitempty"`
+	// Description of output creation event.
+	OutputTag string `json:"output_tag,omitempty"`
+	// Description of output spend event.
+	SpendTag string `json:"spend_tag,omitempty"`
+	// Transaction this output was spent in.
+	SpendingTxid string `json:"spending_txid,omitempty"`
+	// Lightning payment identifier. For an htlc, this will be the preimage.
+	PaymentID string `json:"payment_id,omitempty"`
+}
+
+// BkprListAccountEvents calls `bkpr-listaccountevents`: Command for listing recorded bookkeeping events.
+func (ln *Client) BkprListAccountEvents(ctx context.Context, req BkprListAccountEventsRequest) (resp BkprListAccountEventsResponse, err error) {
+	err = ln.CallTyped(ctx, "bkpr-listaccountevents", req, &resp)
+	return
+}
+
+// BkprListAccountEventsRequest are the parameters for `bkpr-listaccountevents`.
+type BkprListAccountEventsRequest struct {
+	// Receive events for the specified account.
+	Account *string `json:"account,omitempty"`
+	// Receive events for the specified payment id.
+	PaymentID *string `json:"payment_id,omitempty"`
+}
+
+// BkprListAccountEventsResponse is the result of `bkpr-listaccountevents`.
+type BkprListAccountEventsResponse struct {
+	Events []BkprListAccountEventsEvents `json:"events"`
+}
+
+type BkprListAccountEventsEvents struct {
+	// The account name. If the account is a channel, the channel_id.
+	Account string `json:"account"`
+	// Coin movement type.
+	Type string `json:"type"`
+	// Description of movement.
+	Tag string `json:"tag"`
+	// Amount credited.
+	CreditMsat Msat `json:"credit_msat"`
+	// Amount debited.
+	DebitMsat Msat `json:"debit_msat"`
+	// Human-readable bech32 part for this coin type.
+	Currency string `json:"currency"`
+	// Timestamp this event was recorded by the node. For consolidated events such as onchain_fees, the most recent timestamp.
+	Timestamp uint32 `json:"timestamp"`
+	// The txid:outnum for this event.
+	Outpoint string `json:"outpoint,omitempty"`
+	// For chain events, blockheight this occured at.
+	Blockheight uint32 `json:"blockheight,omitempty"`
+	// The account this movement originated from.
+	Origin string `json:"origin,omitempty"`
+	// Lightning payment identifier. For an htlc, this will be the preimage.
+	PaymentID string `json:"payment_id,omitempty"`
+	// The txid of the transaction that created this event.
+	Txid string `json:"txid,omitempty"`
+	// The description of this event.
+	Description string `json:"description,omitempty"`
+	// Amount paid in fees.
+	FeesMsat Msat `json:"fees_msat,omitempty"`
+	// Is this payment part of a rebalance.
+	IsRebalance bool `json:"is_rebalance,omitempty"`
+	// Counter for multi-part payments.
+	PartID uint32 `json:"part_id,omitempty"`
+}
+
+// BkprListBalances calls `bkpr-listbalances`: Command for listing current channel + wallet balances.
+func (ln *Client) BkprListBalances(ctx context.Context) (resp BkprListBalancesResponse, err error) {
+	err = ln.CallTyped(ctx, "bkpr-listbalances", nil, &resp)
+	return
+}
+
+// BkprListBalancesResponse is the result of `bkpr-listbalances`.
+type BkprListBalancesResponse struct {
+	Accounts []BkprListBalancesAccounts `json:"accounts"`
+}
+
+type BkprListBalancesAccounts struct {
+	// The account name. If the account is a channel, the channel_id.
+	Account  string                             `json:"account"`
+	Balances []BkprListBalancesAccountsBalances `json:"balances"`
+	// Node id for the peer this account is with.
+	PeerID string `json:"peer_id,omitempty"`
+	// Did we initiate this account's open.
+	WeOpened bool `json:"we_opened,omitempty"`
+	// Has the on-chain transaction for this account been confirmed.
+	AccountClosed bool `json:"account_closed,omitempty"`
+	// Has all on-chain activity for this account been resolved.
+	AccountResolved bool `json:"account_resolved,omitempty"`
+	// Blockheight account was resolved.
+	ResolvedAtBlock uint32 `json:"resolved_at_block,omitempty"`
+}
+
+type BkprListBalancesAccountsBalances struct {
+	// Current account balance.
+	BalanceMsat Msat `json:"balance_msat"`
+	// Coin type, same as HRP for bech32.
+	CoinType string `json:"coin_type"`
+}
+
+// BkprListIncome calls `bkpr-listincome`: Command for listing all income impacting events.
+func (ln *Client) BkprListIncome(ctx context.Context, req BkprListIncomeRequest) (resp BkprListIncomeResponse, err error) {
+	err = ln.CallTyped(ctx, "bkpr-listincome", req, &resp)
+	return
+}
+
+// BkprListIncomeRequest are the parameters for `bkpr-listincome`.
+type BkprListIncomeRequest struct {
+	// If true, we emit a single, consolidated event for any onchain-fees for a txid and account. Otherwise, events for every update to the onchain fee calculation for this account and txid will be printed.
+	ConsolidateFees *bool `json:"consolidate_fees,omitempty"`
+	// UNIX timestamp (in seconds) that filters events after the provided timestamp.
+	StartTime *uint32 `json:"start_time,omitempty"`
+	// UNIX timestamp (in seconds) that filters events up to and at the provided timestamp.
+	EndTime *uint32 `json:"end_time,omitempty"`
+}
+
+// BkprListIncomeResponse is the result of `bkpr-listincome`.
+type BkprListIncomeResponse struct {
+	IncomeEvents []BkprListIncomeIncomeEvents `json:"income_events"`
+}
+
+type BkprListIncomeIncomeEvents struct {
+	// The account name. If the account is a channel, the channel_id.
+	Account string `json:"account"`
+	// Type of income event.
+	Tag string `json:"tag"`
+	// Amount earned (income).
+	CreditMsat Msat `json:"credit_msat"`
+	// Amount spent (expenses).
+	DebitMsat Msat `json:"debit_msat"`
+	// Human-readable bech32 part for this coin type.
+	Currency string `json:"currency"`
+	// Timestamp this event was recorded by the node. For consolidated events such as onchain_fees, the most recent timestamp.
+	Timestamp uint32 `json:"timestamp"`
+	// More information about this event. If a `invoice` type, typically the bolt11/bolt12 description.
+	Description string `json:"description,omitempty"`
+	// The txid:outnum for this event, if applicable.
+	Outpoint string `json:"outpoint,omitempty"`
+	// The txid of the transaction that created this event, if applicable.
+	Txid string `json:"txid,omitempty"`
+	// Lightning payment identifier. For an htlc, this will be the preimage.
+	PaymentID string `json:"payment_id,omitempty"`
+}
+
+// BlacklistRune calls `blacklistrune`: Command to prevent a rune from working.
+func (ln *Client) BlacklistRune(ctx context.Context, req BlacklistRuneRequest) (resp BlacklistRuneResponse, err error) {
+	err = ln.CallTyped(ctx, "blacklistrune", req, &resp)
+	return
+}
+
+// BlacklistRuneRequest are the parameters for `blacklistrune`.
+type BlacklistRuneRequest struct {
+	// First rune unique id to blacklist.
+	Start *uint64 `json:"start,omitempty"`
+	// Final rune unique id to blacklist (defaults to start).
+	End *uint64 `json:"end,omitempty"`
+}
+
+// BlacklistRuneResponse is the result of `blacklistrune`.
+type BlacklistRuneResponse struct {
+	// The resulting blacklist ranges after the command.
+	Blacklist []BlacklistRuneBlacklist `json:"blacklist"`
+}
+
+type BlacklistRuneBlacklist struct {
+	// Unique id of first rune in this blacklist range.
+	Start uint64 `json:"start"`
+	// Unique id of last rune in this blacklist range.
+	End uint64 `json:"end"`
+}
+
+// Check calls `check`: Command for verifying parameters.
+func (ln *Client) Check(ctx context.Context, req CheckRequest) (resp CheckResponse, err error) {
+	err = ln.CallTyped(ctx, "check", req, &resp)
+	return
+}
+
+// CheckRequest are the parameters for `check`.
+type CheckRequest struct {
+	// Name of the relevant command.
+	CommandToCheck string `json:"command_to_check"`
+}
+
+// CheckResponse is the result of `check`.
+type CheckResponse struct {
+	// The *command_to_check* argument.
+	CommandToCheck string `json:"command_to_check"`
+}
+
+// CheckMessage calls `checkmessage`: Command to check if a signature is from a node.
+func (ln *Client) CheckMessage(ctx context.Context, req CheckMessageRequest) (resp CheckMessageResponse, err error) {
+	err = ln.CallTyped(ctx, "checkmessage", req, &resp)
+	return
+}
+
+// CheckMessageRequest are the parameters for `checkmessage`.
+type CheckMessageRequest struct {
+	// Message to be checked against the signature.
+	Message string `json:"message"`
+	// The zbase32 encoded signature to verify.
+	Zbase string `json:"zbase"`
+	// The public key of the node that signed the message. If omitted, the node must be in our local gossip store.
+	Pubkey *string `json:"pubkey,omitempty"`
+}
+
+// CheckMessageResponse is the result of `checkmessage`.
+type CheckMessageResponse struct {
+	// Whether the signature was valid.
+	Verified bool `json:"verified"`
+	// The *pubkey* parameter, or the pubkey found by looking for known nodes.
+	Pubkey string `json:"pubkey"`
+}
+
+// CheckRune calls `checkrune`: Command to Validate Rune.
+func (ln *Client) CheckRune(ctx context.Context, req CheckRuneRequest) (resp CheckRuneResponse, err error) {
+	err = ln.CallTyped(ctx, "checkrune", req, &resp)
+	return
+}
+
+// CheckRuneRequest are the parameters for `checkrune`.
+type CheckRuneRequest struct {
+	// Rune to check for authorization.
+	Rune string `json:"rune"`
+	// Node id of requesting node *(required until v23.11)*.
+	Nodeid *string `json:"nodeid,omitempty"`
+	// Method for which rune needs to be validated *(required until v23.11)*.
+	Method *string `json:"method,omitempty"`
+	// Parameters for method.
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// CheckRuneResponse is the result of `checkrune`.
+type CheckRuneResponse struct {
+	// True if the rune is valid.
+	Valid bool `json:"valid"`
+}
+
+// Close calls `close`: Command for closing channels with direct peers.
+func (ln *Client) Close(ctx context.Context, req CloseRequest) (resp CloseResponse, err error) {
+	err = ln.CallTyped(ctx, "close", req, &resp)
+	return
+}
+
+// CloseRequest are the parameters for `close`.
+type CloseRequest struct {
+	// Peer id, channel id or short_channel_id. If the given *id* is a peer ID (66 hex digits as a string), then it applies to the active channel of the direct peer corresponding to the given peer ID.
+	ID string `json:"id"`
+	// If it is not zero, the command will unilaterally close the channel when that number of seconds is reached. If *unilateraltimeout* is zero, then the command will wait indefinitely until the peer is online and can negotiate a mutual close.
+	Unilateraltimeout *uint32 `json:"unilateraltimeout,omitempty"`
+	// Any Bitcoin bech32 type. If the peer hasn't offered the option_shutdown_anysegwit feature, then taproot addresses (or other v1+ segwit) are not allowed.
+	Destination *string `json:"destination,omitempty"`
+	// It controls how closing fee negotiation is performed assuming the peer proposes a fee that is different than our estimate.
+	FeeNegotiationStep *string `json:"fee_negotiation_step,omitempty"`
+	// It can only be specified if both sides have offered the `shutdown_wrong_funding` feature (enabled by the **experimental-shutdown-wrong-funding** option).
+	WrongFunding *string `json:"wrong_funding,omitempty"`
+	// If the channel has funds leased to the peer (option_will_fund), we prevent initiation of a mutual close unless this flag is passed in.
+	ForceLeaseClosed *bool `json:"force_lease_closed,omitempty"`
+	// Optional feerates to offer the peer.
+	Feerange []string `json:"feerange,omitempty"`
+}
+
+// CloseResponse is the result of `close`.
+type CloseResponse struct {
+	// Whether we successfully negotiated a mutual close, closed without them, or discarded not-yet-opened channel.
+	Type string `json:"type"`
+	// The raw bitcoin transaction used to close the channel (if it was open).
+	//
+	// Deprecated: removed in newer lightningd versions.
+	Tx string `json:"tx,omitempty"`
+	// The transaction id of the *tx* field.
+	//
+	// Deprecated: removed in newer lightningd versions.
+	Txid  string   `json:"txid,omitempty"`
+	Txs   []string `json:"txs,omitempty"`
+	Txids []string `json:"txids,omitempty"`
+}
+
+// Commando calls `commando`: Command to Send a Command to a Remote Peer.
+func (ln *Client) Commando(ctx context.Context, req CommandoRequest) (resp CommandoResponse, err error) {
+	err = ln.CallTyped(ctx, "commando", req, &resp)
+	return
+}
+
+// CommandoRequest are the parameters for `commando`.
+type CommandoRequest struct {
+	// Peer to command.
+	PeerID string `json:"peer_id"`
+	// Method to invoke on peer.
+	Method string `json:"method"`
+	// Array or object containing parameters for the method.
+	Params json.RawMessage `json:"params,omitempty"`
+	// Rune to authorize the command.
+	Rune *string `json:"rune,omitempty"`
+	// Filter to peer to apply to any successful result.
+	Filter json.RawMessage `json:"filter,omitempty"`
+}
+
+// CommandoResponse is the result of `commando`.
+type CommandoResponse struct {
+}
+
+// Connect calls `connect`: Command for connecting to another lightning node.
+func (ln *Client) Connect(ctx context.Context, req ConnectRequest) (resp ConnectResponse, err error) {
+	err = ln.CallTyped(ctx, "connect", req, &resp)
+	return
+}
+
+// ConnectRequest are the parameters for `connect`.
+type ConnectRequest struct {
+	// The target node's public key. As a convenience, *id* may be of the form *id@host* or *id@host:port*. In this case, the *host* and *port* parameters must be omitted.
+	ID string `json:"id"`
+	// The peer's hostname or IP address.
+	Host *string `json:"host,omitempty"`
+	// The peer's port number defaults to the networks default ports if not specified.
+	Port *uint16 `json:"port,omitempty"`
+}
+
+// ConnectResponse is the result of `connect`.
+type ConnectResponse struct {
+	// The peer we connected to.
+	ID string `json:"id"`
+	// BOLT 9 features bitmap offered by peer.
+	Features string `json:"features"`
+	// Whether they initiated connection or we did.
+	Direction string `json:"direction"`
+	// Address information (mainly useful if **direction** is *out*).
+	Address ConnectAddress `json:"address"`
+}
+
+type ConnectAddress struct {
+	// Type of connection (*torv2*/*torv3* only if **direction** is *out*).
+	Type string `json:"type"`
+	// Socket filename (only if **type** is "local socket").
+	Socket string `json:"socket,omitempty"`
+	// Address in expected format for **type**.
+	Address string `json:"address,omitempty"`
+	// Port number.
+	Port uint16 `json:"port,omitempty"`
+}
+
+// CreateInvoice calls `createinvoice`: Low-level invoice creation.
+func (ln *Client) CreateInvoice(ctx context.Context, req CreateInvoiceRequest) (resp CreateInvoiceResponse, err error) {
+	err = ln.CallTyped(ctx, "createinvoice", req, &resp)
+	return
+}
+
+// CreateInvoiceRequest are the parameters for `createinvoice`.
+type CreateInvoiceRequest struct {
+	// The bolt11/bolt12 invoice, without a signature.
+	Invstring string `json:"invstring"`
+	// A unique string or number (which is treated as a string, so `01` is different from `1`); it is never revealed to other nodes on the lightning network, but it can be used to query the status of this invoice.
+	Label string `json:"label"`
+	// The preimage to supply upon successful payment of the invoice.
+	Preimage string `json:"preimage"`
+}
+
+// CreateInvoiceResponse is the result of `createinvoice`.
+type CreateInvoiceResponse struct {
+	// The label for the invoice.
+	Label string `json:"label"`
+	// The bolt11 string (always present unless **bolt12** is).
+	Bolt11 string `json:"bolt11,omitempty"`
+	// The bolt12 string instead of **bolt11** (**experimental-offers** only).
+	Bolt12 string `json:"bolt12,omitempty"`
+	// The hash of the *payment_preimage* which will prove payment.
+	PaymentHash string `json:"payment_hash"`
+	// The value of the invoice, if it has one.
+	AmountMsat Msat `json:"amount_msat,omitempty"`
+	// Whether it has been paid, or can no longer be paid.
+	Status string `json:"status"`
+	// Description extracted from **bolt11** or **bolt12**.
+	Description string `json:"description"`
+	// UNIX timestamp of when invoice expires (or expired).
+	ExpiresAt uint64 `json:"expires_at"`
+	// 1-based index indicating order this invoice was created in.
+	CreatedIndex uint64 `json:"created_index"`
+	// Incrementing id for when this was paid (**status** *paid* only).
+	PayIndex uint64 `json:"pay_index,omitempty"`
+	// Amount actually received (**status** *paid* only).
+	AmountReceivedMsat Msat `json:"amount_received_msat,omitempty"`
+	// UNIX timestamp of when invoice was paid (**status** *paid* only).
+	PaidAt uint64 `json:"paid_at,omitempty"`
+	// Outpoint this invoice was paid with (**status** *paid* only).
+	PaidOutpoint CreateInvoicePaidOutpoint `json:"paid_outpoint,omitempty"`
+	// The proof of payment: SHA256 of this **payment_hash**.
+	PaymentPreimage string `json:"payment_preimage,omitempty"`
+	// The *id* of our offer which created this invoice (**experimental-offers** only).
+	LocalOfferID string `json:"local_offer_id,omitempty"`
+	// The optional *invreq_payer_note* from invoice_request which created this invoice (**experimental-offers** only).
+	InvreqPayerNote string `json:"invreq_payer_note,omitempty"`
+}
+
+type CreateInvoicePaidOutpoint struct {
+	// ID of the transaction that paid the invoice.
+	Txid string `json:"txid"`
+	// The 0-based output number of the transaction that paid the invoice.
+	Outnum uint32 `json:"outnum"`
+}
+
+// CreateOnion calls `createonion`: Low-level command to create a custom onion.
+func (ln *Client) CreateOnion(ctx context.Context, req CreateOnionRequest) (resp CreateOnionResponse, err error) {
+	err = ln.CallTyped(ctx, "createonion", req, &resp)
+	return
+}
+
+// CreateOnionRequest are the parameters for `createonion`.
+type CreateOnionRequest struct {
+	// A JSON list of dicts, each specifying a node and the payload destined for that node.
+	Hops []CreateOnionHops `json:"hops"`
+	// The associated data that the onion should commit to.
+	Assocdata string `json:"assocdata"`
+	// Can be used to specify a secret that is used to generate the shared secrets used to encrypt the onion for each hop.
+	SessionKey *string `json:"session_key,omitempty"`
+	// Size of the onion.
+	OnionSize *uint16 `json:"onion_size,omitempty"`
+}
+
+type CreateOnionHops struct {
+	// The node id of the hop.
+	Pubkey string `json:"pubkey"`
+	// The payload for the hop.
+	Payload string `json:"payload"`
+}
+
+// CreateOnionResponse is the result of `createonion`.
+type CreateOnionResponse struct {
+	// The onion packet (*onion_size* bytes).
+	Onion string `json:"onion"`
+	// One shared secret for each node in the *hops* parameter.
+	SharedSecrets []string `json:"shared_secrets"`
+}
+
+// CreateRune calls `createrune`: Command to Create/Update Rune for Authorizing Remote Peer Access.
+func (ln *Client) CreateRune(ctx context.Context, req CreateRuneRequest) (resp CreateRuneResponse, err error) {
+	err = ln.CallTyped(ctx, "createrune", req, &resp)
+	return
+}
+
+// CreateRuneRequest are the parameters for `createrune`.
+type CreateRuneRequest struct {
+	// If supplied, the restrictions are simple appended to that *rune* (it doesn't need to be a rune belonging to this node). If not supplied, a new rune is constructed, with a new unique id.
+	Rune *string `json:"rune,omitempty"`
+	// It can be the string `readonly`, or an array of restrictions. Each restriction is an array of one or more alternatives, such as "method is listpeers", or "method is listpeers OR time is before 2023".
+	Restrictions json.RawMessage `json:"restrictions,omitempty"`
+}
+
+// CreateRuneResponse is the result of `createrune`.
+type CreateRuneResponse struct {
+	// The resulting rune.
+	Rune string `json:"rune"`
+	// The id of this rune: this is set at creation and cannot be changed (even as restrictions are added).
+	UniqueID string `json:"unique_id"`
+	// A warning shown when runes are created with powers that could drain your node.
+	WarningUnrestrictedRune string `json:"warning_unrestricted_rune,omitempty"`
+}
+
+// Datastore calls `datastore`: Command for storing (plugin) data.
+func (ln *Client) Datastore(ctx context.Context, req DatastoreRequest) (resp DatastoreResponse, err error) {
+	err = ln.CallTyped(ctx, "datastore", req, &resp)
+	return
+}
+
+// DatastoreRequest are the parameters for `datastore`.
+type DatastoreRequest struct {
+	// A key can either have children or a value, never both: parents are created and removed automatically.
+	Key json.RawMessage `json:"key"`
+	// Data to be saved in string format.
+	String *string `json:"string,omitempty"`
+	// Data to be saved in hex format.
+	Hex *string `json:"hex,omitempty"`
+	// Write mode to determine how the record is updated in the datastore.
+	Mode *string `json:"mode,omitempty"`
+	// If specified, means that the update will fail if the previously-existing data is not exactly that generation.
+	Generation *uint64 `json:"generation,omitempty"`
+}
+
+// DatastoreResponse is the result of `datastore`.
+type DatastoreResponse struct {
+	Key []string `json:"key"`
+	// The number of times this has been updated.
+	Generation uint64 `json:"generation,omitempty"`
+	// The hex data which has been added to the datastore.
+	Hex string `json:"hex,omitempty"`
+	// The data as a string, if it's valid utf-8.
+	String string `json:"string,omitempty"`
+}
+
+// DatastoreUsage calls `datastoreusage`: Command for listing datastore usage info.
+func (ln *Client) DatastoreUsage(ctx context.Context, req DatastoreUsageRequest) (resp DatastoreUsageResponse, err error) {
+	err = ln.CallTyped(ctx, "datastoreusage", req, &resp)
+	return
+}
+
+// DatastoreUsageRequest are the parameters for `datastoreusage`.
+type DatastoreUsageRequest struct {
+	// Key is an array of values (though a single value is treated as a one-element array). Only return usage information for this key and its children.
+	Key json.RawMessage `json:"key,omitempty"`
+}
+
+// DatastoreUsageResponse is the result of `datastoreusage`.
+type DatastoreUsageResponse struct {
+	Datastoreusage DatastoreUsageDatastoreusage `json:"datastoreusage"`
+}
+
+type DatastoreUsageDatastoreusage struct {
+	// The key from which the database was traversed.
+	Key string `json:"key"`
+	// The total bytes that are stored under this *key*.
+	TotalBytes uint64 `json:"total_bytes"`
+}
+
+// Decode calls `decode`: Command for decoding an invoice string (low-level).
+func (ln *Client) Decode(ctx context.Context, req DecodeRequest) (resp DecodeResponse, err error) {
+	err = ln.CallTyped(ctx, "decode", req, &resp)
+	return
+}
+
+// DecodeRequest are the parameters for `decode`.
+type DecodeRequest struct {
+	// Value to be decoded: a bolt11 or bolt12 string (optionally prefixed by `lightning:` or `LIGHTNING:`), a rune or an emergency.recover backup.
+	String string `json:"string"`
+}
+
+// DecodeResponse is the result of `decode`.
+type DecodeResponse struct {
+	// What kind of object it decoded to.
+	Type string `json:"type"`
+	// If this is false, you *MUST* not use the result except for diagnostics!
+	Valid bool `json:"valid"`
+	// The id we use to identify this offer.
+	OfferID string `json:"offer_id,omitempty"`
+	// Which blockchains this offer is for (missing implies bitcoin mainnet only).
+	OfferChains []string `json:"offer_chains,omitempty"`
+	// The description of the purpose of the offer.
+	OfferDescription string `json:"offer_description,omitempty"`
+	// The description of the creator of the offer.
+	OfferIssuer string `json:"offer_issuer,omitempty"`
+	// The amount in bitcoin.
+	OfferAmountMsat Msat `json:"offer_amount_msat,omitempty"`
+	// Public key of the offering node.
+	OfferNodeID string `json:"offer_node_id,omitempty"`
+	// The currency of a bolt11 invoice, or of the offer amount.
+	Currency string `json:"currency,omitempty"`
+	// The UNIX-style timestamp of the invoice.
+	CreatedAt uint64 `json:"created_at,omitempty"`
+	// The number of seconds this is valid after *created_at*.
+	Expiry uint64 `json:"expiry,omitempty"`
+	// The public key of the recipient of a bolt11 invoice.
+	Payee string `json:"payee,omitempty"`
+	// Amount the invoice asked for.
+	AmountMsat Msat `json:"amount_msat,omitempty"`
+	// The hash of the *payment_preimage*.
+	PaymentHash string `json:"payment_hash,omitempty"`
+	// Signature of the *payee* on this invoice.
+	Signature string `json:"signature,omitempty"`
+	// The description of the purpose of the purchase.
+	Description string `json:"description,omitempty"`
+	// The hash of the description, in place of *description*.
+	DescriptionHash string `json:"description_hash,omitempty"`
+	// The minimum CLTV delay for the final node.
+	MinFinalCltvExpiry uint32 `json:"min_final_cltv_expiry,omitempty"`
+	// The secret to hand to the payee node.
+	PaymentSecret string `json:"payment_secret,omitempty"`
+	// The features bitmap for this invoice.
+	Features string `json:"features,omitempty"`
+	// The payment_metadata to put in the payment.
+	PaymentMetadata string `json:"payment_metadata,omitempty"`
+	// Onchain addresses.
+	Fallbacks []DecodeFallbacks `json:"fallbacks,omitempty"`
+	// Route hints to the *payee*.
+	Routes [][]DecodeRoutes `json:"routes,omitempty"`
+	// Unique id (always a numeric id on runes we create).
+	UniqueID string `json:"unique_id,omitempty"`
+	// Rune version, not currently set on runes we create.
+	Version string `json:"version,omitempty"`
+	// The string encoding of the rune.
+	String       string               `json:"string,omitempty"`
+	Restrictions []DecodeRestrictions `json:"restrictions,omitempty"`
+	// The decrypted value of the provided emergency.recover backup.
+	Decrypted string `json:"decrypted,omitempty"`
+	// The offer has an amount but no description.
+	WarningMissingOfferDescription string `json:"warning_missing_offer_description,omitempty"`
+	// The rune contains invalid UTF-8 strings.
+	WarningRuneInvalidUtf8 string `json:"warning_rune_invalid_utf8,omitempty"`
+}
+
+type DecodeFallbacks struct {
+	// The address type (if known).
+	Type string `json:"type"`
+	// The address in appropriate format for *type*.
+	Addr string `json:"addr,omitempty"`
+	// Raw encoded address.
+	Hex string `json:"hex"`
+}
+
+type DecodeRoutes struct {
+	// The public key of the node.
+	Pubkey string `json:"pubkey"`
+	// A channel to the next peer.
+	ShortChannelID string `json:"short_channel_id"`
+	// The base fee for payments.
+	FeeBaseMsat Msat `json:"fee_base_msat"`
+	// The parts-per-million fee for payments.
+	FeeProportionalMillionths uint32 `json:"fee_proportional_millionths"`
+	// The CLTV delta across this hop.
+	CltvExpiryDelta uint32 `json:"cltv_expiry_delta"`
+}
+
+type DecodeRestrictions struct {
+	Alternatives []string `json:"alternatives"`
+	// A human-readable summary of this restriction.
+	Summary string `json:"summary"`
+}
+
+// DecodePay calls `decodepay`: Command for decoding a bolt11 string (low-level).
+func (ln *Client) DecodePay(ctx context.Context, req DecodePayRequest) (resp DecodePayResponse, err error) {
+	err = ln.CallTyped(ctx, "decodepay", req, &resp)
+	return
+}
+
+// DecodePayRequest are the parameters for `decodepay`.
+type DecodePayRequest struct {
+	// Bolt11 invoice to decode.
+	Bolt11 string `json:"bolt11"`
+	// Description of the invoice to decode.
+	Description *string `json:"description,omitempty"`
+}
+
+// DecodePayResponse is the result of `decodepay`.
+type DecodePayResponse struct {
+	// The BIP173 name for the currency.
+	Currency string `json:"currency"`
+	// The UNIX-style timestamp of the invoice.
+	CreatedAt uint64 `json:"created_at"`
+	// The number of seconds this is valid after *timestamp*.
+	Expiry uint64 `json:"expiry"`
+	// The public key of the recipient.
+	Payee string `json:"payee"`
+	// Amount the invoice asked for.
+	AmountMsat Msat `json:"amount_msat,omitempty"`
+	// The hash of the *payment_preimage*.
+	PaymentHash string `json:"payment_hash"`
+	// Signature of the *payee* on this invoice.
+	Signature string `json:"signature"`
+	// The description of the purpose of the purchase.
+	Description string `json:"description,omitempty"`
+	// The hash of the description, in place of *description*.
+	DescriptionHash string `json:"description_hash,omitempty"`
+	// The minimum CLTV delay for the final node.
+	MinFinalCltvExpiry uint32 `json:"min_final_cltv_expiry"`
+	// The secret to hand to the payee node.
+	PaymentSecret string `json:"payment_secret,omitempty"`
+	// The features bitmap for this invoice.
+	Features string `json:"features,omitempty"`
+	// The payment_metadata to put in the payment.
+	PaymentMetadata string `json:"payment_metadata,omitempty"`
+	// Onchain addresses.
+	Fallbacks []DecodePayFallbacks `json:"fallbacks,omitempty"`
+	// Route hints to the *payee*.
+	Routes [][]DecodePayRoutes `json:"routes,omitempty"`
+}
+
+type DecodePayFallbacks struct {
+	// The address type (if known).
+	Type string `json:"type"`
+	// The address in appropriate format for *type*.
+	Addr string `json:"addr,omitempty"`
+	// Raw encoded address.
+	Hex string `json:"hex"`
+}
+
+type DecodePayRoutes struct {
+	// The public key of the node.
+	Pubkey string `json:"pubkey"`
+	// A channel to the next peer.
+	ShortChannelID string `json:"short_channel_id"`
+	// The base fee for payments.
+	FeeBaseMsat Msat `json:"fee_base_msat"`
+	// The parts-per-million fee for payments.
+	FeeProportionalMillionths uint32 `json:"fee_proportional_millionths"`
+	// The CLTV delta across this hop.
+	CltvExpiryDelta uint32 `json:"cltv_expiry_delta"`
+}
+
+// DelDatastore calls `deldatastore`: Command for removing (plugin) datastore entries.
+func (ln *Client) DelDatastore(ctx context.Context, req DelDatastoreRequest) (resp DelDatastoreResponse, err error) {
+	err = ln.CallTyped(ctx, "deldatastore", req, &resp)
+	return
+}
+
+// DelDatastoreRequest are the parameters for `deldatastore`.
+type DelDatastoreRequest struct {
+	// Key is an array of values (though a single value is treated as a one-element array).
+	Key json.RawMessage `json:"key"`
+	// If specified, means that the update will fail if the previously-existing data is not exactly that generation.
+	Generation *uint64 `json:"generation,omitempty"`
+}
+
+// DelDatastoreResponse is the result of `deldatastore`.
+type DelDatastoreResponse struct {
+	Key []string `json:"key"`
+	// The number of times this has been updated.
+	Generation uint64 `json:"generation,omitempty"`
+	// The hex data from the datastore.
+	Hex string `json:"hex,omitempty"`
+	// The data as a string, if it's valid utf-8.
+	String string `json:"string,omitempty"`
+}
+
+// DelForward calls `delforward`: Command for removing a forwarding entry.
+func (ln *Client) DelForward(ctx context.Context, req DelForwardRequest) (resp DelForwardResponse, err error) {
+	err = ln.CallTyped(ctx, "delforward", req, &resp)
+	return
+}
+
+// DelForwardRequest are the parameters for `delforward`.
+type DelForwardRequest struct {
+	// Only the matching forwards on the given inbound channel are deleted.
+	InChannel string `json:"in_channel"`
+	// The unique HTLC id the sender gave this (not present if incoming channel was closed before upgrade to v22.11).
+	InHtlcID uint64 `json:"in_htlc_id"`
+	// The status of the forward to delete. You cannot delete forwards which have status *offered* (i.e. are currently active).
+	Status string `json:"status"`
+}
+
+// DelForwardResponse is the result of `delforward`.
+type DelForwardResponse struct {
+}
+
+// DelInvoice calls `delinvoice`: Command for removing an invoice (or just its description).
+func (ln *Client) DelInvoice(ctx context.Context, req DelInvoiceRequest) (resp DelInvoiceResponse, err error) {
+	err = ln.CallTyped(ctx, "delinvoice", req, &resp)
+	return
+}
+
+// DelInvoiceRequest are the parameters for `delinvoice`.
+type DelInvoiceRequest struct {
+	// Label of the invoice to be deleted.
+	Label string `json:"label"`
+	// Label of the invoice to be deleted. The caller should be particularly aware of the error case caused by the *status* changing just before this command is invoked!
+	Status string `json:"status"`
+	// If set to True, the invoice is not deleted, but has its description removed (this can save space with very large descriptions, as would be used with lightning-invoice(7)::description_hash being true).
+	Desconly *bool `json:"desconly,omitempty"`
+}
+
+// DelInvoiceResponse is the result of `delinvoice`.
+type DelInvoiceResponse struct {
+	// Unique label given at creation time.
+	Label string `json:"label"`
+	// BOLT11 string.
+	Bolt11 string `json:"bolt11,omitempty"`
+	// BOLT12 string.
+	Bolt12 string `json:"bolt12,omitempty"`
+	// The amount required to pay this invoice.
+	AmountMsat Msat `json:"amount_msat,omitempty"`
+	// Description used in the invoice.
+	Description string `json:"description,omitempty"`
+	// The hash of the *payment_preimage* which will prove payment.
+	PaymentHash string `json:"payment_hash"`
+	// 1-based index indicating order this invoice was created in.
+	CreatedIndex uint64 `json:"created_index"`
+	// 1-based index indicating order this invoice was changed (only present if it has changed since creation).
+	UpdatedIndex uint64 `json:"updated_index,omitempty"`
+	// State of invoice.
+	Status string `json:"status"`
+	// UNIX timestamp when invoice expires (or expired).
+	ExpiresAt uint64 `json:"expires_at,omitempty"`
+	// Offer for which this invoice was created.
+	LocalOfferID string `json:"local_offer_id,omitempty"`
+	// The optional *invreq_payer_note* from invoice_request which created this invoice.
+	InvreqPayerNote string `json:"invreq_payer_note,omitempty"`
+	// Unique index for this invoice payment (**status** *paid* only).
+	PayIndex uint64 `json:"pay_index,omitempty"`
+	// How much was actually received (**status** *paid* only).
+	AmountReceivedMsat Msat `json:"amount_received_msat,omitempty"`
+	// UNIX timestamp of when payment was received (**status** *paid* only).
+	PaidAt uint64 `json:"paid_at,omitempty"`
+	// SHA256 of this is the *payment_hash* offered in the invoice (**status** *paid* only).
+	PaymentPreimage string `json:"payment_preimage,omitempty"`
+}
+
+// DelPay calls `delpay`: Command for removing a completed or failed payment.
+func (ln *Client) DelPay(ctx context.Context, req DelPayRequest) (resp DelPayResponse, err error) {
+	err = ln.CallTyped(ctx, "delpay", req, &resp)
+	return
+}
+
+// DelPayRequest are the parameters for `delpay`.
+type DelPayRequest struct {
+	// The unique identifier of a payment.
+	PaymentHash string `json:"payment_hash"`
+	// Expected status of the payment. Only deletes if the payment status matches. Deleting a `pending` payment will return an error.
+	Status string `json:"status"`
+	// Specific partid to delete (must be paired with *groupid*).
+	Partid *uint64 `json:"partid,omitempty"`
+	// Specific groupid to delete (must be paired with *partid*).
+	Groupid *uint64 `json:"groupid,omitempty"`
+}
+
+// DelPayResponse is the result of `delpay`.
+type DelPayResponse struct {
+	Payments []DelPayPayments `json:"payments"`
+}
+
+type DelPayPayments struct {
+	// 1-based index indicating order this payment was created in.
+	CreatedIndex uint64 `json:"created_index"`
+	// Old synonym for created_index.
+	ID uint64 `json:"id"`
+	// The hash of the *payment_preimage* which will prove payment.
+	PaymentHash string `json:"payment_hash"`
+	// Status of the payment.
+	Status string `json:"status"`
+	// The amount we actually sent, including fees.
+	AmountSentMsat Msat `json:"amount_sent_msat"`
+	// Unique ID within this (multi-part) payment.
+	Partid uint64 `json:"partid,omitempty"`
+	// The final destination of the payment if known.
+	Destination string `json:"destination,omitempty"`
+	// The amount the destination received, if known.
+	AmountMsat Msat `json:"amount_msat,omitempty"`
+	// The UNIX timestamp showing when this payment was initiated.
+	CreatedAt uint64 `json:"created_at"`
+	// 1-based index indicating order this payment was changed (only present if it has changed since creation).
+	UpdatedIndex uint64 `json:"updated_index,omitempty"`
+	// The UNIX timestamp showing when this payment was completed.
+	CompletedAt uint64 `json:"completed_at,omitempty"`
+	// Grouping key to disambiguate multiple attempts to pay an invoice or the same payment_hash.
+	Groupid uint64 `json:"groupid,omitempty"`
+	// Proof of payment.
+	PaymentPreimage string `json:"payment_preimage,omitempty"`
+	// The label, if given to sendpay.
+	Label string `json:"label,omitempty"`
+	// The bolt11 string (if pay supplied one).
+	Bolt11 string `json:"bolt11,omitempty"`
+	// The bolt12 string (if supplied for pay: **experimental-offers** only).
+	Bolt12 string `json:"bolt12,omitempty"`
+	// The error onion returned on failure, if any.
+	Erroronion string `json:"erroronion,omitempty"`
+}
+
+// DevForgetChannel calls `dev-forget-channel`: Command to remove the DB entries from the database after a close.
+func (ln *Client) DevForgetChannel(ctx context.Context, req DevForgetChannelRequest) (resp DevForgetChannelResponse, err error) {
+	err = ln.CallTyped(ctx, "dev-forget-channel", req, &resp)
+	return
+}
+
+// DevForgetChannelRequest are the parameters for `dev-forget-channel`.
+type DevForgetChannelRequest struct {
+	// The peer id of the channel to be forgotten.
+	ID string `json:"id"`
+	// The short channel id of the channel you want to remove.
+	ShortChannelID *string `json:"short_channel_id,omitempty"`
+	// The channel id of the channel you want to remove.
+	ChannelID *string `json:"channel_id,omitempty"`
+	// Ignores UTXO check for forced removal.
+	Force *bool `json:"force,omitempty"`
+}
+
+// DevForgetChannelResponse is the result of `dev-forget-channel`.
+type DevForgetChannelResponse struct {
+	// If the command was forced or not.
+	Forced bool `json:"forced"`
+	// If the funding is unspent or not.
+	FundingUnspent bool `json:"funding_unspent"`
+	// The id of the funding transaction.
+	FundingTxid string `json:"funding_txid"`
+}
+
+// DisableInvoiceRequest calls `disableinvoicerequest`: Command for removing an invoice request.
+func (ln *Client) DisableInvoiceRequest(ctx context.Context, req DisableInvoiceRequestRequest) (resp DisableInvoiceRequestResponse, err error) {
+	err = ln.CallTyped(ctx, "disableinvoicerequest", req, &resp)
+	return
+}
+
+// DisableInvoiceRequestRequest are the parameters for `disableinvoicerequest`.
+type DisableInvoiceRequestRequest struct {
+	// The id we use to identify this invoicerequest.
+	InvreqID string `json:"invreq_id"`
+}
+
+// DisableInvoiceRequestResponse is the result of `disableinvoicerequest`.
+type DisableInvoiceRequestResponse struct {
+	// The merkle hash of the invoicerequest.
+	InvreqID string `json:"invreq_id"`
+	// Whether the invoicerequest can produce invoices/payments.
+	Active bool `json:"active"`
+	// Whether the invoicerequest will become inactive after use.
+	SingleUse bool `json:"single_use"`
+	// The bolt12 string representing this invoicerequest.
+	Bolt12 string `json:"bolt12"`
+	// Whether the invoicerequest has had an invoice paid / payment made.
+	Used bool `json:"used"`
+	// The label provided when creating the invoicerequest.
+	Label string `json:"label,omitempty"`
+}
+
+// DisableOffer calls `disableoffer`: Command for removing an offer.
+func (ln *Client) DisableOffer(ctx context.Context, req DisableOfferRequest) (resp DisableOfferResponse, err error) {
+	err = ln.CallTyped(ctx, "disableoffer", req, &resp)
+	return
+}
+
+// DisableOfferRequest are the parameters for `disableoffer`.
+type DisableOfferRequest struct {
+	// The id we use to identify this offer.
+	OfferID string `json:"offer_id"`
+}
+
+// DisableOfferResponse is the result of `disableoffer`.
+type DisableOfferResponse struct {
+	// The merkle hash of the offer.
+	OfferID string `json:"offer_id"`
+	// Whether the offer can produce invoices/payments.
+	Active bool `json:"active"`
+	// Whether the offer will become inactive after use.
+	SingleUse bool `json:"single_use"`
+	// The bolt12 string representing this offer.
+	Bolt12 string `json:"bolt12"`
+	// Whether the offer has had an invoice paid / payment made.
+	Used bool `json:"used"`
+	// The label provided when creating the offer.
+	Label string `json:"label,omitempty"`
+}
+
+// Disconnect calls `disconnect`: Command for disconnecting from another lightning node.
+func (ln *Client) Disconnect(ctx context.Context, req DisconnectRequest) (resp DisconnectResponse, err error) {
+	err = ln.CallTyped(ctx, "disconnect", req, &resp)
+	return
+}
+
+// DisconnectRequest are the parameters for `disconnect`.
+type DisconnectRequest struct {
+	// The public key of the peer to terminate the connection. It can be discovered in the output of the listpeers command, which returns a set of peers.
+	ID string `json:"id"`
+	// If set to True, it will disconnect even with an active channel.
+	Force *bool `json:"force,omitempty"`
+}
+
+// DisconnectResponse is the result of `disconnect`.
+type DisconnectResponse struct {
+}
+
+// EmergencyRecover calls `emergencyrecover`: Command for recovering channels from the emergency.recovery file in the lightning directory.
+func (ln *Client) EmergencyRecover(ctx context.Context) (resp EmergencyRecoverResponse, err error) {
+	err = ln.CallTyped(ctx, "emergencyrecover", nil, &resp)
+	return
+}
+
+// EmergencyRecoverResponse is the result of `emergencyrecover`.
+type EmergencyRecoverResponse struct {
+	Stubs []string `json:"stubs"`
+}
+
+// FeeRates calls `feerates`: Command for querying recommended onchain feerates.
+func (ln *Client) FeeRates(ctx context.Context, req FeeRatesRequest) (resp FeeRatesResponse, err error) {
+	err = ln.CallTyped(ctx, "feerates", req, &resp)
+	return
+}
+
+// FeeRatesRequest are the parameters for `feerates`.
+type FeeRatesRequest struct {
+	// Fee rate style to use. This can be: *perkw* - provide feerate in units of satoshis per 1000 weight (e.g. the minimum fee is usually `253perkw`). *perkb* - provide feerate in units of satoshis per 1000 virtual bytes (eg. the minimum fee is usually `1000perkb`).
+	Style string `json:"style"`
+}
+
+// FeeRatesResponse is the result of `feerates`.
+type FeeRatesResponse struct {
+	// Some fee estimates are missing.
+	WarningMissingFeerates string `json:"warning_missing_feerates,omitempty"`
+	// If *style* parameter was perkb.
+	Perkb FeeRatesPerkb `json:"perkb,omitempty"`
+	// If *style* parameter was perkw.
+	Perkw               FeeRatesPerkw               `json:"perkw,omitempty"`
+	OnchainFeeEstimates FeeRatesOnchainFeeEstimates `json:"onchain_fee_estimates,omitempty"`
+}
+
+type FeeRatesPerkb struct {
+	// The smallest feerate that we allow peers to specify: half the 100-block estimate.
+	MinAcceptable uint32 `json:"min_acceptable"`
+	// The largest feerate we will accept from remote negotiations. If a peer attempts to set the feerate higher than this we will unilaterally close the channel (or simply forget it if it's not open yet).
+	MaxAcceptable uint32 `json:"max_acceptable"`
+	// The smallest feerate that our backend tells us it will accept (i.e. minrelayfee or mempoolminfee).
+	Floor uint32 `json:"floor"`
+	// Feerate estimates from plugin which we are using (usuallly bcli).
+	Estimates []FeeRatesPerkbEstimates `json:"estimates,omitempty"`
+	// Default feerate for lightning-fundchannel(7) and lightning-withdraw(7).
+	Opening uint32 `json:"opening,omitempty"`
+	// Feerate to aim for in cooperative shutdown. Note that since mutual close is a **negotiation**, the actual feerate used in mutual close will be somewhere between this and the corresponding mutual close feerate of the peer.
+	MutualClose uint32 `json:"mutual_close,omitempty"`
+	// Feerate for commitment_transaction in a live channel which we originally funded.
+	UnilateralClose uint32 `json:"unilateral_close,omitempty"`
+	// Feerate for commitment_transaction in a live channel which we originally funded (if anchor_outputs was negotiated).
+	UnilateralAnchorClose uint32 `json:"unilateral_anchor_close,omitempty"`
+	// Feerate to start at when penalizing a cheat attempt.
+	Penalty uint32 `json:"penalty,omitempty"`
+}
+
+type FeeRatesPerkbEstimates struct {
+	// The number of blocks the feerate is expected to get a transaction in.
+	Blockcount uint32 `json:"blockcount"`
+	// The feerate for this estimate, in given *style*.
+	Feerate uint32 `json:"feerate"`
+	// The feerate, smoothed over time (useful for coordinating with other nodes).
+	SmoothedFeerate uint32 `json:"smoothed_feerate"`
+}
+
+type FeeRatesPerkw struct {
+	// The smallest feerate that you can use, usually the minimum relayed feerate of the backend.
+	MinAcceptable uint32 `json:"min_acceptable"`
+	// The largest feerate we will accept from remote negotiations. If a peer attempts to set the feerate higher than this we will unilaterally close the channel (or simply forget it if it's not open yet).
+	MaxAcceptable uint32 `json:"max_acceptable"`
+	// The smallest feerate that our backend tells us it will accept (i.e. minrelayfee or mempoolminfee).
+	Floor uint32 `json:"floor"`
+	// Feerate estimates from plugin which we are using (usuallly bcli).
+	Estimates []FeeRatesPerkwEstimates `json:"estimates,omitempty"`
+	// Default feerate for lightning-fundchannel(7) and lightning-withdraw(7).
+	Opening uint32 `json:"opening,omitempty"`
+	// Feerate to aim for in cooperative shutdown. Note that since mutual close is a **negotiation**, the actual feerate used in mutual close will be somewhere between this and the corresponding mutual close feerate of the peer.
+	MutualClose uint32 `json:"mutual_close,omitempty"`
+	// Feerate for commitment_transaction in a live channel which we originally funded.
+	UnilateralClose uint32 `json:"unilateral_close,omitempty"`
+	// Feerate for commitment_transaction in a live channel which we originally funded (if anchor_outputs was negotiated).
+	UnilateralAnchorClose uint32 `json:"unilateral_anchor_close,omitempty"`
+	// Feerate to start at when penalizing a cheat attempt.
+	Penalty uint32 `json:"penalty,omitempty"`
+}
+
+type FeeRatesPerkwEstimates struct {
+	// The number of blocks the feerate is expected to get a transaction in.
+	Blockcount uint32 `json:"blockcount"`
+	// The feerate for this estimate, in given *style*.
+	Feerate uint32 `json:"feerate"`
+	// The feerate, smoothed over time (useful for coordinating with other nodes).
+	SmoothedFeerate uint32 `json:"smoothed_feerate"`
+}
+
+type FeeRatesOnchainFeeEstimates struct {
+	// Estimated cost of typical channel open.
+	OpeningChannelSatoshis uint64 `json:"opening_channel_satoshis"`
+	// Estimated cost of typical channel close.
+	MutualCloseSatoshis uint64 `json:"mutual_close_satoshis"`
+	// Estimated cost of typical unilateral close (without HTLCs). If anchors are supported, this assumes a channel with anchors.
+	UnilateralCloseSatoshis uint64 `json:"unilateral_close_satoshis"`
+	// Estimated cost of non-anchor typical unilateral close (without HTLCs).
+	UnilateralCloseNonanchorSatoshis uint64 `json:"unilateral_close_nonanchor_satoshis,omitempty"`
+	// Estimated cost of typical HTLC timeout transaction (non-anchors).
+	HtlcTimeoutSatoshis uint64 `json:"htlc_timeout_satoshis"`
+	// Estimated cost of typical HTLC fulfillment transaction (non-anchors).
+	HtlcSuccessSatoshis uint64 `json:"htlc_success_satoshis"`
+}
+
+// FetchInvoice calls `fetchinvoice`: Command for fetch an invoice for an offer.
+func (ln *Client) FetchInvoice(ctx context.Context, req FetchInvoiceRequest) (resp FetchInvoiceResponse, err error) {
+	err = ln.CallTyped(ctx, "fetchinvoice", req, &resp)
+	return
+}
+
+// FetchInvoiceRequest are the parameters for `fetchinvoice`.
+type FetchInvoiceRequest struct {
+	// Offer string to get an actual invoice that can be paid.
+	Offer string `json:"offer"`
+	// Required if the offer does not specify an amount at all, otherwise it is optional (but presumably if you set it to less than the offer, you will get an error from the issuer).
+	AmountMsat *Msat `json:"amount_msat,omitempty"`
+	// Required if the offer specifies quantity_max, otherwise it is not allowed.
+	Quantity *uint64 `json:"quantity,omitempty"`
+	// Required if the offer specifies recurrence, otherwise it is not allowed. recurrence_counter should first be set to 0, and incremented for each successive invoice in a given series.
+	RecurrenceCounter *uint64 `json:"recurrence_counter,omitempty"`
+	// Required if the offer specifies recurrence_base with start_any_period set, otherwise it is not allowed. It indicates what period number to start at.
+	RecurrenceStart *float64 `json:"recurrence_start,omitempty"`
+	// Required if recurrence_counter is set, and otherwise is not allowed. It must be the same as prior fetchinvoice calls for the same recurrence, as it is used to link them together.
+	RecurrenceLabel *string `json:"recurrence_label,omitempty"`
+	// If we don't get a reply before this we fail (default, 60 seconds).
+	Timeout *float64 `json:"timeout,omitempty"`
+	// To ask the issuer to include in the fetched invoice.
+	PayerNote *string `json:"payer_note,omitempty"`
+}
+
+// FetchInvoiceResponse is the result of `fetchinvoice`.
+type FetchInvoiceResponse struct {
+	// The BOLT12 invoice we fetched.
+	Invoice string `json:"invoice"`
+	// Summary of changes from offer.
+	Changes FetchInvoiceChanges `json:"changes"`
+	// Only for recurring invoices if the next period is under the *recurrence_limit*.
+	NextPeriod FetchInvoiceNextPeriod `json:"next_period,omitempty"`
+}
+
+type FetchInvoiceChanges struct {
+	// Extra characters appended to the *description* field.
+	DescriptionAppended string `json:"description_appended,omitempty"`
+	// A completely replaced *description* field.
+	Description string `json:"description,omitempty"`
+	// The *vendor* from the offer, which is missing in the invoice.
+	VendorRemoved string `json:"vendor_removed,omitempty"`
+	// A completely replaced *vendor* field.
+	Vendor string `json:"vendor,omitempty"`
+	// The amount, if different from the offer amount multiplied by any *quantity* (or the offer had no amount, or was not in BTC).
+	AmountMsat Msat `json:"amount_msat,omitempty"`
+}
+
+type FetchInvoiceNextPeriod struct {
+	// The index of the next period to fetchinvoice.
+	Counter uint64 `json:"counter"`
+	// UNIX timestamp that the next period starts.
+	Starttime uint64 `json:"starttime"`
+	// UNIX timestamp that the next period ends.
+	Endtime uint64 `json:"endtime"`
+	// UNIX timestamp of the earliest time that the next invoice can be fetched.
+	PaywindowStart uint64 `json:"paywindow_start"`
+	// UNIX timestamp of the latest time that the next invoice can be fetched.
+	PaywindowEnd uint64 `json:"paywindow_end"`
+}
+
+// FundChannel calls `fundchannel`: Command for establishing a lightning channel.
+func (ln *Client) FundChannel(ctx context.Context, req FundChannelRequest) (resp FundChannelResponse, err error) {
+	err = ln.CallTyped(ctx, "fundchannel", req, &resp)
+	return
+}
+
+// FundChannelRequest are the parameters for `fundchannel`.
+type FundChannelRequest struct {
+	// Id is the peer id obtained from connect.
+	ID string `json:"id"`
+	// The amount in satoshis taken from the internal wallet to fund the channel (but if we have any anchor channels, this will always leave at least `min-emergency-msat` as change). The string *all* can be used to specify all available funds (or 16777215 satoshi if more is available and large channels were not negotiated with the peer).
+	Amount string `json:"amount"`
+	// Used for the opening transaction and (unless *option_anchors* is negotiated), as initial feerate for commitment and HTLC transactions (see NOTES in lightning-feerates(7)).
+	Feerate *string `json:"feerate,omitempty"`
+	// Whether to announce this channel or not. An unannounced channel is considered private.
+	Announce *bool `json:"announce,omitempty"`
+	// The minimum number of confirmations that used outputs should have.
+	Minconf *uint32 `json:"minconf,omitempty"`
+	// The amount of millisatoshis to push to the channel peer at open. Note that this is a gift to the peer -- these satoshis are added to the initial balance of the peer at channel start and are largely unrecoverable once pushed.
+	PushMsat *Msat `json:"push_msat,omitempty"`
+	// A Bitcoin address to which the channel funds should be sent to on close. Only valid if both peers have negotiated `option_upfront_shutdown_script`.
+	CloseTo *string `json:"close_to,omitempty"`
+	// An amount of liquidity you'd like to lease from the peer. If peer supports `option_will_fund`, indicates to them to include this much liquidity into the channel. Must also pass in *compact_lease*.
+	RequestAmt *uint64 `json:"request_amt,omitempty"`
+	// A compact represenation of the peer's expected channel lease terms. If the peer's terms don't match this set, we will fail to open the channel.
+	CompactLease *string `json:"compact_lease,omitempty"`
+	// The utxos to be used to fund the channel, as an array of `txid:vout`.
+	Utxos []string `json:"utxos,omitempty"`
+	// Number of confirmations required before we consider the channel active.
+	Mindepth *uint32 `json:"mindepth,omitempty"`
+	// The amount we want the peer to maintain on its side of the channel. It can be a whole number, a whole number ending in *sat*, a whole number ending in *000msat*, or a number with 1 to 8 decimal places ending in *btc*.
+	Reserve *uint64 `json:"reserve,omitempty"`
+	// Each bit set in this channel_type.
+	ChannelType []uint32 `json:"channel_type,omitempty"`
+}
+
+// FundChannelResponse is the result of `fundchannel`.
+type FundChannelResponse struct {
+	// The raw transaction which funded the channel.
+	Tx string `json:"tx"`
+	// The txid of the transaction which funded the channel.
+	Txid string `json:"txid"`
+	// The 0-based output index showing which output funded the channel.
+	Outnum uint32 `json:"outnum"`
+	// The channel_id of the resulting channel.
+	ChannelID string `json:"channel_id"`
+	// Channel_type as negotiated with peer.
+	ChannelType FundChannelChannelType `json:"channel_type"`
+	// The raw scriptPubkey which mutual close will go to; only present if *close_to* parameter was specified and peer supports `option_upfront_shutdown_script`.
+	CloseTo string `json:"close_to,omitempty"`
+	// Number of confirmations before we consider the channel active.
+	Mindepth uint32 `json:"mindepth,omitempty"`
+}
+
+type FundChannelChannelType struct {
+	// Each bit set in this channel_type.
+	Bits []uint32 `json:"bits"`
+	// Feature name for each bit set in this channel_type. Note that *anchors_zero_fee_htlc_tx* is a deprecated synonym for *anchors*.
+	Names []string `json:"names"`
+}
+
+// FundChannelCancel calls `fundchannel_cancel`: Command for completing channel establishment.
+func (ln *Client) FundChannelCancel(ctx context.Context, req FundChannelCancelRequest) (resp FundChannelCancelResponse, err error) {
+	err = ln.CallTyped(ctx, "fundchannel_cancel", req, &resp)
+	return
+}
+
+// FundChannelCancelRequest are the parameters for `fundchannel_cancel`.
+type FundChannelCancelRequest struct {
+	// Node id of the remote peer with which to cancel the channel.
+	ID string `json:"id"`
+}
+
+// FundChannelCancelResponse is the result of `fundchannel_cancel`.
+type FundChannelCancelResponse struct {
+	// A message indicating it was cancelled by RPC.
+	Cancelled string `json:"cancelled"`
+}
+
+// FundChannelComplete calls `fundchannel_complete`: Command for completing channel establishment.
+func (ln *Client) FundChannelComplete(ctx context.Context, req FundChannelCompleteRequest) (resp FundChannelCompleteResponse, err error) {
+	err = ln.CallTyped(ctx, "fundchannel_complete", req, &resp)
+	return
+}
+
+// FundChannelCompleteRequest are the parameters for `fundchannel_complete`.
+type FundChannelCompleteRequest struct {
+	// Node id of the remote peer.
+	ID string `json:"id"`
+	// Transaction to use for funding (does not need to be signed but must be otherwise complete).
+	Psbt string `json:"psbt"`
+}
+
+// FundChannelCompleteResponse is the result of `fundchannel_complete`.
+type FundChannelCompleteResponse struct {
+	// The channel_id of the resulting channel.
+	ChannelID string `json:"channel_id"`
+	// Indication that channel is safe to use.
+	CommitmentsSecured bool `json:"commitments_secured"`
+}
+
+// FundChannelStart calls `fundchannel_start`: Command for initiating channel establishment for a lightning channel.
+func (ln *Client) FundChannelStart(ctx context.Context, req FundChannelStartRequest) (resp FundChannelStartResponse, err error) {
+	err = ln.CallTyped(ctx, "fundchannel_start", req, &resp)
+	return
+}
+
+// FundChannelStartRequest are the parameters for `fundchannel_start`.
+type FundChannelStartRequest struct {
+	// The peer id obtained from connect.
+	ID string `json:"id"`
+	// Satoshi value that the channel will be funded at. This value MUST be accurate, otherwise the negotiated commitment transactions will not encompass the correct channel value.
+	Amount uint64 `json:"amount"`
+	// Feerate for subsequent commitment transactions: see **fundchannel**. Note that this is ignored for channels with *option_anchors* (we simply use the minimum, which must be agreed to by the peer).
+	Feerate *string `json:"feerate,omitempty"`
+	// Whether or not to announce this channel.
+	Announce *bool `json:"announce,omitempty"`
+	// Bitcoin address to which the channel funds should be sent on close. Only valid if both peers have negotiated `option_upfront_shutdown_script`.
+	CloseTo *string `json:"close_to,omitempty"`
+	// Amount of millisatoshis to push to the channel peer at open. Note that this is a gift to the peer -- these satoshis are added to the initial balance of the peer at channel start and are largely unrecoverable once pushed.
+	PushMsat *Msat `json:"push_msat,omitempty"`
+	// Number of confirmations required before we consider the channel active.
+	Mindepth *uint32 `json:"mindepth,omitempty"`
+	// The amount we want the peer to maintain on its side.
+	Reserve *uint64 `json:"reserve,omitempty"`
+	// Each bit set in this channel_type.
+	ChannelType []uint32 `json:"channel_type,omitempty"`
+}
+
+// FundChannelStartResponse is the result of `fundchannel_start`.
+type FundChannelStartResponse struct {
+	// The address to send funding to for the channel. DO NOT SEND COINS TO THIS ADDRESS YET.
+	FundingAddress string `json:"funding_address"`
+	// The raw scriptPubkey for the address.
+	Scriptpubkey string `json:"scriptpubkey"`
+	// Channel_type as negotiated with peer.
+	ChannelType FundChannelStartChannelType `json:"channel_type"`
+	// The raw scriptPubkey which mutual close will go to; only present if *close_to* parameter was specified and peer supports `option_upfront_shutdown_script`.
+	CloseTo string `json:"close_to,omitempty"`
+	// A warning not to prematurely broadcast the funding transaction (always present!).
+	WarningUsage string `json:"warning_usage"`
+	// Number of confirmations before we consider the channel active.
+	Mindepth uint32 `json:"mindepth,omitempty"`
+}
+
+type FundChannelStartChannelType struct {
+	// Each bit set in this channel_type.
+	Bits []uint32 `json:"bits"`
+	// Feature name for each bit set in this channel_type. Note that *anchors_zero_fee_htlc_tx* is a deprecated synonym for *anchors*.
+	Names []string `json:"names"`
+}
+
+// FunderUpdate calls `funderupdate`: Command for adjusting node funding v2 channels.
+func (ln *Client) FunderUpdate(ctx context.Context, req FunderUpdateRequest) (resp FunderUpdateResponse, err error) {
+	err = ln.CallTyped(ctx, "funderupdate", req, &resp)
+	return
+}
+
+// FunderUpdateRequest are the parameters for `funderupdate`.
+type FunderUpdateRequest struct {
+	// Funder plugin will use to decide how much capital to commit to a v2 open channel request.
+	Policy *string `json:"policy,omitempty"`
+	// The **policy_mod** is the number or 'modification' to apply to the policy.
+	PolicyMod *Msat `json:"policy_mod,omitempty"`
+	// Only contribute funds to `option_will_fund` requests which pay to lease funds.
+	LeasesOnly *bool `json:"leases_only,omitempty"`
+	// Minimum funding sats that we require in order to activate our contribution policy to the v2 open.
+	MinTheirFundingMsat *Msat `json:"min_their_funding_msat,omitempty"`
+	// Maximum funding sats that we will consider to activate our contribution policy to the v2 open.
+	MaxTheirFundingMsat *Msat `json:"max_their_funding_msat,omitempty"`
+	// Minimum amount that we will contribute to a channel open.
+	PerChannelMinMsat *Msat `json:"per_channel_min_msat,omitempty"`
+	// Maximum amount that we will contribute to a channel open.
+	PerChannelMaxMsat *Msat `json:"per_channel_max_msat,omitempty"`
+	// Amount of sats to leave available in the node wallet.
+	ReserveTankMsat *Msat `json:"reserve_tank_msat,omitempty"`
+	// A percentage to fuzz the resulting contribution amount by. Valid values are 0 to 100.
+	FuzzPercent *uint32 `json:"fuzz_percent,omitempty"`
+	// The percent of v2 channel open requests to apply our policy to. Valid values are integers from 0 (fund 0% of all open requests) to 100 (fund every request).
+	FundProbability *uint32 `json:"fund_probability,omitempty"`
+	// Flat fee for a channel lease. Node will receive this much extra msat from the peer for each leased channel.
+	LeaseFeeBaseMsat *Msat `json:"lease_fee_base_msat,omitempty"`
+	// A basis fee that's calculated as 1/10k of the total requested funds the peer is asking for.
+	LeaseFeeBasis *uint32 `json:"lease_fee_basis,omitempty"`
+	// To calculate the fee the peer will compensate your node for its contributing inputs to the funding transaction.
+	FundingWeight *uint32 `json:"funding_weight,omitempty"`
+	// A commitment to a maximum `channel_fee_base_msat` that your node will charge for routing payments over this leased channel during the lease duration.
+	ChannelFeeMaxBaseMsat *Msat `json:"channel_fee_max_base_msat,omitempty"`
+	// A commitment to a maximum `channel_fee_proportional_millionths` that your node will charge for routing payments over this leased channel during the lease duration.
+	ChannelFeeMaxProportionalThousandths *uint32 `json:"channel_fee_max_proportional_thousandths,omitempty"`
+	// A compact description of the channel lease params. When opening a channel, passed in to `fundchannel` to indicate the terms we expect from the peer.
+	CompactLease *string `json:"compact_lease,omitempty"`
+}
+
+// FunderUpdateResponse is the result of `funderupdate`.
+type FunderUpdateResponse struct {
+	// Summary of the current funding policy e.g. (match 100).
+	Summary string `json:"summary"`
+	// Policy funder plugin will use to decide how much capital to commit to a v2 open channel request.
+	Policy string `json:"policy"`
+	// The *policy_mod* is the number or 'modification' to apply to the policy.
+	PolicyMod uint32 `json:"policy_mod"`
+	// Only contribute funds to `option_will_fund` lease requests.
+	LeasesOnly bool `json:"leases_only"`
+	// The minimum funding sats that we require from peer to activate our funding policy.
+	MinTheirFundingMsat Msat `json:"min_their_funding_msat"`
+	// The maximum funding sats that we'll allow from peer to activate our funding policy.
+	MaxTheirFundingMsat Msat `json:"max_their_funding_msat"`
+	// The minimum amount that we will fund a channel open with.
+	PerChannelMinMsat Msat `json:"per_channel_min_msat"`
+	// The maximum amount that we will fund a channel open with.
+	PerChannelMaxMsat Msat `json:"per_channel_max_msat"`
+	// Amount of sats to leave available in the node wallet.
+	ReserveTankMsat Msat `json:"reserve_tank_msat"`
+	// Percentage to fuzz our funding amount by.
+	FuzzPercent uint32 `json:"fuzz_percent"`
+	// Percent of opens to consider funding. 100 means we'll consider funding every requested open channel request.
+	FundProbability uint32 `json:"fund_probability"`
+	// Flat fee to charge for a channel lease.
+	LeaseFeeBaseMsat Msat `json:"lease_fee_base_msat,omitempty"`
+	// Proportional fee to charge for a channel lease, calculated as 1/10,000th of requested funds.
+	LeaseFeeBasis uint32 `json:"lease_fee_basis,omitempty"`
+	// Transaction weight the channel opener will pay us for a leased funding transaction.
+	FundingWeight uint32 `json:"funding_weight,omitempty"`
+	// Maximum channel_fee_base_msat we'll charge for routing funds leased on this channel.
+	ChannelFeeMaxBaseMsat Msat `json:"channel_fee_max_base_msat,omitempty"`
+	// Maximum channel_fee_proportional_millitionths we'll charge for routing funds leased on this channel, in thousandths.
+	ChannelFeeMaxProportionalThousandths uint32 `json:"channel_fee_max_proportional_thousandths,omitempty"`
+	// Compact description of the channel lease parameters.
+	CompactLease string `json:"compact_lease,omitempty"`
+}
+
+// FundPsbt calls `fundpsbt`: Command to populate PSBT inputs from the wallet.
+func (ln *Client) FundPsbt(ctx context.Context, req FundPsbtRequest) (resp FundPsbtResponse, err error) {
+	err = ln.CallTyped(ctx, "fundpsbt", req, &resp)
+	return
+}
+
+// FundPsbtRequest are the parameters for `fundpsbt`.
+type FundPsbtRequest struct {
+	// The minimum satoshi value of the output(s) needed (or the string `all` meaning use all available inputs).
+	Satoshi string `json:"satoshi"`
+	// Used for the transaction as initial feerate.
+	Feerate string `json:"feerate"`
+	// The weight of the transaction before *fundpsbt* has added any inputs.
+	Startweight uint32 `json:"startweight"`
+	// The minimum number of confirmations that used outputs should have.
+	Minconf *uint32 `json:"minconf,omitempty"`
+	// If not zero, then *reserveinputs* is called (successfully, with *exclusive* true) on the returned PSBT for this number of blocks.
+	Reserve *uint32 `json:"reserve,omitempty"`
+	// The locktime of the transaction. if not set, it is set to a recent block height.
+	Locktime *uint32 `json:"locktime,omitempty"`
+	// Minimum weight to use for a UTXO's witness. If the actual witness weight is greater than the provided minimum, the actual witness weight will be used.
+	MinWitnessWeight *uint32 `json:"min_witness_weight,omitempty"`
+	// Flag to add a change output for the excess sats.
+	ExcessAsChange *bool `json:"excess_as_change,omitempty"`
+	// To signal to filter out any p2sh-wrapped inputs from funding this PSBT.
+	Nonwrapped *bool `json:"nonwrapped,omitempty"`
+	// To signel that it needs emergency reserve for anchors so that we can lowball our commitment tx fees, and min-emergency-msat for reserving some sats for closing anchor channels.
+	OpeningAnchorChannel *bool `json:"opening_anchor_channel,omitempty"`
+}
+
+// FundPsbtResponse is the result of `fundpsbt`.
+type FundPsbtResponse struct {
+	// Unsigned PSBT which fulfills the parameters given.
+	Psbt string `json:"psbt"`
+	// The feerate used to create the PSBT, in satoshis-per-kiloweight.
+	FeeratePerKw uint32 `json:"feerate_per_kw"`
+	// The estimated weight of the transaction once fully signed.
+	EstimatedFinalWeight uint32 `json:"estimated_final_weight"`
+	// The amount above *satoshi* which is available. This could be zero, or dust; it will be zero if *change_outnum* is also returned.
+	ExcessMsat Msat `json:"excess_msat"`
+	// The 0-based output number where change was placed (only if parameter *excess_as_change* was true and there was sufficient funds).
+	ChangeOutnum uint32 `json:"change_outnum,omitempty"`
+	// If *reserve* was true or a non-zero number, just as per lightning- reserveinputs(7).
+	Reservations []FundPsbtReservations `json:"reservations,omitempty"`
+}
+
+type FundPsbtReservations struct {
+	// The txid of the transaction.
+	Txid string `json:"txid"`
+	// The 0-based output number.
+	Vout uint32 `json:"vout"`
+	// Whether this output was previously reserved.
+	WasReserved bool `json:"was_reserved"`
+	// Whether this output is now reserved.
+	Reserved bool `json:"reserved"`
+	// The blockheight the reservation will expire.
+	ReservedToBlock uint32 `json:"reserved_to_block"`
+}
+
+// GetEmergencyRecoverData calls `getemergencyrecoverdata`: Command to fetch data from the emergency.recover file.
+func (ln *Client) GetEmergencyRecoverData(ctx context.Context) (resp GetEmergencyRecoverDataResponse, err error) {
+	err = ln.CallTyped(ctx, "getemergencyrecoverdata", nil, &resp)
+	return
+}
+
+// GetEmergencyRecoverDataResponse is the result of `getemergencyrecoverdata`.
+type GetEmergencyRecoverDataResponse struct {
+	// The raw, hex-encoded, emergency.recover file.
+	Filedata string `json:"filedata"`
+}
+
+// GetInfo calls `getinfo`: Command to receive all information about the Core Lightning node.
+func (ln *Client) GetInfo(ctx context.Context) (resp GetInfoResponse, err error) {
+	err = ln.CallTyped(ctx, "getinfo", nil, &resp)
+	return
+}
+
+// GetInfoResponse is the result of `getinfo`.
+type GetInfoResponse struct {
+	// The public key unique to this node.
+	ID string `json:"id"`
+	// The fun alias this node will advertize.
+	Alias string `json:"alias,omitempty"`
+	// The favorite RGB color this node will advertize.
+	Color string `json:"color"`
+	// The total count of peers, connected or with channels.
+	NumPeers uint32 `json:"num_peers"`
+	// The total count of channels being opened.
+	NumPendingChannels uint32 `json:"num_pending_channels"`
+	// The total count of channels in normal state.
+	NumActiveChannels uint32 `json:"num_active_channels"`
+	// The total count of channels waiting for opening or closing transactions to be mined.
+	NumInactiveChannels uint32 `json:"num_inactive_channels"`
+	// Identifies what bugs you are running into.
+	Version string `json:"version"`
+	// Identifies where you can find the configuration and other related files.
+	LightningDir string `json:"lightning-dir"`
+	// Our BOLT #9 feature bits (as hexstring) for various contexts.
+	OurFeatures GetInfoOurFeatures `json:"our_features,omitempty"`
+	// The highest block height we've learned.
+	Blockheight uint32 `json:"blockheight"`
+	// Represents the type of network on the node are working (e.g: `bitcoin`, `testnet`, or `regtest`).
+	Network string `json:"network"`
+	// Total routing fees collected by this node.
+	FeesCollectedMsat Msat `json:"fees_collected_msat"`
+	// The addresses we announce to the world.
+	Address []GetInfoAddress `json:"address"`
+	// The addresses we are listening on.
+	Binding []GetInfoBinding `json:"binding,omitempty"`
+	// Bitcoind is not up-to-date with network.
+	WarningBitcoindSync string `json:"warning_bitcoind_sync,omitempty"`
+	// Lightningd is still loading latest blocks from bitcoind.
+	WarningLightningdSync string `json:"warning_lightningd_sync,omitempty"`
+}
+
+type GetInfoOurFeatures struct {
+	// Features (incl. globalfeatures) in our init message, these also restrict what we offer in open_channel or accept in accept_channel.
+	Init string `json:"init"`
+	// Features in our node_announcement message.
+	Node string `json:"node"`
+	// Negotiated channel features we (as channel initiator) publish in the channel_announcement message.
+	Channel string `json:"channel"`
+	// Features in our BOLT11 invoices.
+	Invoice string `json:"invoice"`
+}
+
+type GetInfoAddress struct {
+	// Type of connection (until 23.08, `websocket` was also allowed).
+	Type string `json:"type"`
+	// Port number.
+	Port uint16 `json:"port"`
+	// Address in expected format for **type**.
+	Address string `json:"address,omitempty"`
+}
+
+type GetInfoBinding struct {
+	// Type of connection.
+	Type string `json:"type"`
+	// Address in expected format for **type**.
+	Address string `json:"address,omitempty"`
+	// Port number.
+	Port uint16 `json:"port,omitempty"`
+	// Socket filename (only if **type** is `local socket`).
+	Socket string `json:"socket,omitempty"`
+}
+
+// GetLog calls `getlog`: Command to show logs.
+func (ln *Client) GetLog(ctx context.Context, req GetLogRequest) (resp GetLogResponse, err error) {
+	err = ln.CallTyped(ctx, "getlog", req, &resp)
+	return
+}
+
+// GetLogRequest are the parameters for `getlog`.
+type GetLogRequest struct {
+	// A string that represents the log level.
+	Level *string `json:"level,omitempty"`
+}
+
+// GetLogResponse is the result of `getlog`.
+type GetLogResponse struct {
+	// UNIX timestamp with 9 decimal places, when logging was initialized.
+	CreatedAt string `json:"created_at"`
+	// The number of bytes used by logging records.
+	BytesUsed uint32 `json:"bytes_used"`
+	// The bytes_used values at which records will be trimmed.
+	BytesMax uint32      `json:"bytes_max"`
+	Log      []GetLogLog `json:"log"`
+}
+
+type GetLogLog struct {
+	Type string `json:"type"`
+	// Number of unprinted log entries (deleted or below *level* parameter).
+	NumSkipped uint32 `json:"num_skipped,omitempty"`
+	// UNIX timestamp with 9 decimal places.
+	Time string `json:"time,omitempty"`
+	// The particular logbook this was found in.
+	Source string `json:"source,omitempty"`
+	// The actual log message.
+	Log string `json:"log,omitempty"`
+	// The peer this is associated with.
+	NodeID string `json:"node_id,omitempty"`
+	// The IO which occurred.
+	Data string `json:"data,omitempty"`
+}
+
+// GetRouteTyped calls `getroute`: Command for routing a payment (low-level).
+func (ln *Client) GetRouteTyped(ctx context.Context, req GetRouteTypedRequest) (resp GetRouteTypedResponse, err error) {
+	err = ln.CallTyped(ctx, "getroute", req, &resp)
+	return
+}
+
+// GetRouteTypedRequest are the parameters for `getroute`.
+type GetRouteTypedRequest struct {
+	// Node pubkey to find the best route for the payment.
+	ID string `json:"id"`
+	// Amount to send. It can be a whole number, or a whole number ending in *msat* or *sat*, or a number with three decimal places ending in *sat*, or a number with 1 to 11 decimal places ending in *btc*. The 0 value is special: it ignores any *htlc_minimum_msat* setting on channels, and simply returns a possible route (if any) which is useful for simple probing.
+	AmountMsat Msat `json:"amount_msat"`
+	// A non-negative floating-point field controls how tradeoff between fees and the timelock on the payment. Use 10 for a reasonable default.
+	Riskfactor uint64 `json:"riskfactor"`
+	// Cltv-blocks to spare.
+	Cltv *uint32 `json:"cltv,omitempty"`
+	// The node to start the route from.
+	Fromid *string `json:"fromid,omitempty"`
+	// Used to distort fees to provide some randomization to the route generated, but it was not properly implemented and is ignored.
+	Fuzzpercent *uint32 `json:"fuzzpercent,omitempty"`
+	// A JSON array of short-channel-id/direction (e.g. ['564334x877x1/0', '564195x1292x0/1' ]) or node-id which should be excluded from consideration for routing.
+	Exclude []string `json:"exclude,omitempty"`
+	// The maximum number of channels to return.
+	Maxhops *uint32 `json:"maxhops,omitempty"`
+}
+
+// GetRouteTypedResponse is the result of `getroute`.
+type GetRouteTypedResponse struct {
+	Route []GetRouteTypedRoute `json:"route"`
+}
+
+type GetRouteTypedRoute struct {
+	// The node at the end of this hop.
+	ID string `json:"id"`
+	// The channel joining these nodes.
+	Channel string `json:"channel"`
+	// 0 if this channel is traversed from lesser to greater **id**, otherwise 1.
+	Direction uint32 `json:"direction"`
+	// The amount expected by the node at the end of this hop.
+	AmountMsat Msat `json:"amount_msat"`
+	// The total CLTV expected by the node at the end of this hop.
+	Delay uint32 `json:"delay"`
+	// The features understood by the destination node.
+	Style string `json:"style"`
+}
+
+// Help calls `help`: Command to return all information about RPC commands.
+func (ln *Client) Help(ctx context.Context, req HelpRequest) (resp HelpResponse, err error) {
+	err = ln.CallTyped(ctx, "help", req, &resp)
+	return
+}
+
+// HelpRequest are the parameters for `help`.
+type HelpRequest struct {
+	// The command.
+	Command *string `json:"command,omitempty"`
+}
+
+// HelpResponse is the result of `help`.
+type HelpResponse struct {
+	Help []HelpHelp `json:"help"`
+	// Prints the help in human-readable flat form.
+	FormatHint string `json:"format-hint,omitempty"`
+}
+
+type HelpHelp struct {
+	// The command.
+	Command string `json:"command"`
+}
+
+// Invoice calls `invoice`: Command for accepting payments.
+func (ln *Client) Invoice(ctx context.Context, req InvoiceRequest) (resp InvoiceResponse, err error) {
+	err = ln.CallTyped(ctx, "invoice", req, &resp)
+	return
+}
+
+// InvoiceRequest are the parameters for `invoice`.
+type InvoiceRequest struct {
+	// The string `any`, which creates an invoice that can be paid with any amount. Otherwise it is a positive value in millisatoshi precision.
+	AmountMsat Msat `json:"amount_msat"`
+	// A unique string or number (which is treated as a string, so `01` is different from `1`); it is never revealed to other nodes on the lightning network.
+	Label string `json:"label"`
+	// A short description of purpose of payment, e.g. *1 cup of coffee*.
+	Description string `json:"description"`
+	// The time the invoice is valid for, in seconds. If no value is provided the default of 604800 (1 week) is used.
+	Expiry *uint64 `json:"expiry,omitempty"`
+	// One or more fallback addresses to include in the invoice (in order from most-preferred to least).
+	Fallbacks []string `json:"fallbacks,omitempty"`
+	// A 64-digit hex string to be used as payment preimage for the created invoice.
+	Preimage *string `json:"preimage,omitempty"`
+	// If specified, sets the *min_final_cltv_expiry* for the invoice.
+	Cltv *uint32 `json:"cltv,omitempty"`
+	// If specified, then a description hash is used in the resulting BOLT 11 invoice, and the description is not included.
+	Deschashonly *bool `json:"deschashonly,omitempty"`
+}
+
+// InvoiceResponse is the result of `invoice`.
+type InvoiceResponse struct {
+	// The bolt11 string.
+	Bolt11 string `json:"bolt11"`
+	// The hash of the *payment_preimage* which will prove payment.
+	PaymentHash string `json:"payment_hash"`
+	// The *payment_secret* to place in the onion.
+	PaymentSecret string `json:"payment_secret"`
+	// UNIX timestamp of when invoice expires.
+	ExpiresAt uint64 `json:"expires_at"`
+	// 1-based index indicating order this invoice was created in.
+	CreatedIndex uint64 `json:"created_index"`
+	// Even using all possible channels, there's not enough incoming capacity to pay this invoice.
+	WarningCapacity string `json:"warning_capacity,omitempty"`
+	// There would be enough incoming capacity, but some channels are offline, so there isn't.
+	WarningOffline string `json:"warning_offline,omitempty"`
+	// There would be enough incoming capacity, but some channels are dead-ends (no other public channels from those peers), so there isn't.
+	WarningDeadends string `json:"warning_deadends,omitempty"`
+	// There would be enough incoming capacity, but some channels are unannounced and *exposeprivatechannels* is *false*, so there isn't.
+	WarningPrivateUnused string `json:"warning_private_unused,omitempty"`
+	// There is sufficient capacity, but not in a single channel, so the payer will have to use multi-part payments.
+	WarningMpp string `json:"warning_mpp,omitempty"`
+}
+
+// InvoiceRequest calls `invoicerequest`: Command for offering payments.
+func (ln *Client) InvoiceRequest(ctx context.Context, req InvoiceRequestRequest) (resp InvoiceRequestResponse, err error) {
+	err = ln.CallTyped(ctx, "invoicerequest", req, &resp)
+	return
+}
+
+// InvoiceRequestRequest are the parameters for `invoicerequest`.
+type InvoiceRequestRequest struct {
+	// A positive value in millisatoshi precision; it can be a whole number, or a whole number ending in *msat* or *sat*, or a number with three decimal places ending in *sat*, or a number with 1 to 11 decimal places ending in *btc*.
+	Amount Msat `json:"amount"`
+	// A short description of purpose of the payment, e.g. *ATM withdrawl*. This value is encoded into the resulting offer and is viewable by anyone you expose this offer to. It must be UTF-8, and cannot use *\u* JSON escape codes.
+	Description string `json:"description"`
+	// Who is issuing it (i.e. you) if appropriate.
+	Issuer *string `json:"issuer,omitempty"`
+	// An internal-use name for the offer, which can be any UTF-8 string.
+	Label *string `json:"label,omitempty"`
+	// The time the offer is valid until, in seconds since the first day of 1970 UTC. If not set, the offer remains valid (though it can be deactivated by the issuer of course). This is encoded in the offer.
+	AbsoluteExpiry *uint64 `json:"absolute_expiry,omitempty"`
+	// Indicates that the invoice associated with the offer is valid only once.
+	SingleUse *bool `json:"single_use,omitempty"`
+}
+
+// InvoiceRequestResponse is the result of `invoicerequest`.
+type InvoiceRequestResponse struct {
+	// The SHA256 hash of all invoice_request fields less than 160.
+	InvreqID string `json:"invreq_id"`
+	// Whether the invoice_request is currently active.
+	Active bool `json:"active"`
+	// Whether the invoice_request will become inactive after we pay an invoice for it.
+	SingleUse bool `json:"single_use"`
+	// The bolt12 string starting with lnr.
+	Bolt12 string `json:"bolt12"`
+	// Whether the invoice_request has already been used.
+	Used bool `json:"used"`
+	// The label provided when creating the invoice_request.
+	Label string `json:"label,omitempty"`
+}
+
+// Keysend calls `keysend`: Send funds to a node without an invoice.
+func (ln *Client) Keysend(ctx context.Context, req KeysendRequest) (resp KeysendResponse, err error) {
+	err = ln.CallTyped(ctx, "keysend", req, &resp)
+	return
+}
+
+// KeysendRequest are the parameters for `keysend`.
+type KeysendRequest struct {
+	// The 33 byte, hex-encoded, node ID of the node that the payment should go to.
+	Destination string `json:"destination"`
+	// In millisatoshi precision; it can be a whole number, or a whole number with suffix *msat* or *sat*, or a three decimal point number with suffix *sat*, or an 1 to 11 decimal point number suffixed by *btc*.
+	AmountMsat Msat `json:"amount_msat"`
+	// Used to attach a label to payments, and is returned in lightning-listpays(7) and lightning-listsendpays(7).
+	Label *string `json:"label,omitempty"`
+	// Limits the money paid in fees as percentage of the total amount that is to be transferred.
+	Maxfeepercent *float64 `json:"maxfeepercent,omitempty"`
+	// Until *retry_for* seconds passes, the command will keep finding routes and retrying the payment.
+	RetryFor *uint32 `json:"retry_for,omitempty"`
+	// A payment may be delayed for up to `maxdelay` blocks by another node; clients should be prepared for this worst case.
+	Maxdelay *uint32 `json:"maxdelay,omitempty"`
+	// This option can be used for tiny payments which would be dominated by the fee leveraged by forwarding nodes. Setting `exemptfee` allows the `maxfeepercent` check to be skipped on fees that are smaller than `exemptfee`.
+	Exemptfee *Msat `json:"exemptfee,omitempty"`
+	// Array of routehints to use for the payment.
+	Routehints [][]KeysendRoutehints `json:"routehints,omitempty"`
+	// Dictionary of additional fields to insert into the final tlv. The format is 'fieldnumber': 'hexstring'.
+	Extratlvs json.RawMessage `json:"extratlvs,omitempty"`
+}
+
+type KeysendRoutehints struct {
+	ID             string `json:"id"`
+	ShortChannelID string `json:"short_channel_id"`
+	Feebase        Msat   `json:"feebase"`
+	Feeprop        uint32 `json:"feeprop"`
+	Expirydelta    uint16 `json:"expirydelta"`
+}
+
+// KeysendResponse is the result of `keysend`.
+type KeysendResponse struct {
+	// The proof of payment: SHA256 of this **payment_hash**.
+	PaymentPreimage string `json:"payment_preimage"`
+	// The final destination of the payment.
+	Destination string `json:"destination,omitempty"`
+	// The hash of the *payment_preimage* which will prove payment.
+	PaymentHash string `json:"payment_hash"`
+	// The UNIX timestamp showing when this payment was initiated.
+	CreatedAt float64 `json:"created_at"`
+	// How many attempts this took.
+	Parts uint32 `json:"parts"`
+	// Amount the recipient received.
+	AmountMsat Msat `json:"amount_msat"`
+	// Total amount we sent (including fees).
+	AmountSentMsat Msat `json:"amount_sent_msat"`
+	// Not all parts of a multi-part payment have completed.
+	WarningPartialCompletion string `json:"warning_partial_completion,omitempty"`
+	// Status of payment.
+	Status string `json:"status"`
+}
+
+// ListChannels calls `listchannels`: Command to query active lightning channels in the entire network.
+func (ln *Client) ListChannels(ctx context.Context, req ListChannelsRequest) (resp ListChannelsResponse, err error) {
+	err = ln.CallTyped(ctx, "listchannels", req, &resp)
+	return
+}
+
+// ListChannelsRequest are the parameters for `listchannels`.
+type ListChannelsRequest struct {
+	// If short_channel_id is a short channel id, then only known channels with a matching short_channel_id are returned.
+	ShortChannelID *string `json:"short_channel_id,omitempty"`
+	// If source is a node id, then only channels leading from that node id are returned.
+	Source *string `json:"source,omitempty"`
+	// If destination is a node id, then only channels leading to that node id are returned.
+	Destination *string `json:"destination,omitempty"`
+}
+
+// ListChannelsResponse is the result of `listchannels`.
+type ListChannelsResponse struct {
+	Channels []ListChannelsChannels `json:"channels"`
+}
+
+type ListChannelsChannels struct {
+	// The source node.
+	Source string `json:"source"`
+	// The destination node.
+	Destination string `json:"destination"`
+	// Short channel id of channel.
+	ShortChannelID string `json:"short_channel_id"`
+	// Direction (0 if source < destination, 1 otherwise).
+	Direction uint32 `json:"direction"`
+	// True if this is announced (from *v24.02*, being false is deprecated).
+	Public bool `json:"public"`
+	// The total capacity of this channel (always a whole number of satoshis).
+	AmountMsat Msat `json:"amount_msat"`
+	// As defined by BOLT #7.
+	MessageFlags uint8 `json:"message_flags"`
+	// As defined by BOLT #7.
+	ChannelFlags uint8 `json:"channel_flags"`
+	// True unless source has disabled it (or (deprecated in *v24.02*) it's a local channel and the peer is disconnected or it's still opening or closing).
+	Active bool `json:"active"`
+	// UNIX timestamp on the last channel_update from *source*.
+	LastUpdate uint32 `json:"last_update"`
+	// Base fee changed by *source* to use this channel.
+	BaseFeeMillisatoshi uint32 `json:"base_fee_millisatoshi"`
+	// Proportional fee changed by *source* to use this channel, in parts-per-million.
+	FeePerMillionth uint32 `json:"fee_per_millionth"`
+	// The number of blocks delay required by *source* to use this channel.
+	Delay uint32 `json:"delay"`
+	// The smallest payment *source* will allow via this channel.
+	HtlcMinimumMsat Msat `json:"htlc_minimum_msat"`
+	// The largest payment *source* will allow via this channel.
+	HtlcMaximumMsat Msat `json:"htlc_maximum_msat,omitempty"`
+	// BOLT #9 features bitmap for this channel.
+	Features string `json:"features"`
+}
+
+// ListClosedChannels calls `listclosedchannels`: Get data on our closed historical channels.
+func (ln *Client) ListClosedChannels(ctx context.Context, req ListClosedChannelsRequest) (resp ListClosedChannelsResponse, err error) {
+	err = ln.CallTyped(ctx, "listclosedchannels", req, &resp)
+	return
+}
+
+// ListClosedChannelsRequest are the parameters for `listclosedchannels`.
+type ListClosedChannelsRequest struct {
+	// If no *id* is supplied, then channel data on all historical channels are given. Supplying *id* will filter the results to only match channels to that peer.
+	ID *string `json:"id,omitempty"`
+}
+
+// ListClosedChannelsResponse is the result of `listclosedchannels`.
+type ListClosedChannelsResponse struct {
+	Closedchannels []ListClosedChannelsClosedchannels `json:"closedchannels"`
+}
+
+type ListClosedChannelsClosedchannels struct {
+	// Peer public key (can be missing with pre-v23.05 closes!).
+	PeerID string `json:"peer_id,omitempty"`
+	// The full channel_id (funding txid Xored with output number).
+	ChannelID string `json:"channel_id"`
+	// The short_channel_id.
+	ShortChannelID string                                `json:"short_channel_id,omitempty"`
+	Alias          ListClosedChannelsClosedchannelsAlias `json:"alias,omitempty"`
+	// Who initiated the channel.
+	Opener string `json:"opener"`
+	// Who initiated the channel close (only present if closing).
+	Closer string `json:"closer,omitempty"`
+	// If True, we will not announce this channel.
+	Private bool `json:"private"`
+	// Channel_type as negotiated with peer.
+	ChannelType ListClosedChannelsClosedchannelsChannelType `json:"channel_type,omitempty"`
+	// Number of commitment transaction we made.
+	TotalLocalCommitments uint64 `json:"total_local_commitments"`
+	// Number of commitment transaction they made.
+	TotalRemoteCommitments uint64 `json:"total_remote_commitments"`
+	// Number of HTLCs we ever sent.
+	TotalHtlcsSent uint64 `json:"total_htlcs_sent"`
+	// ID of the funding transaction.
+	FundingTxid string `json:"funding_txid"`
+	// The 0-based output number of the funding transaction which opens the channel.
+	FundingOutnum uint32 `json:"funding_outnum"`
+	// Whether this channel was leased from `opener`.
+	Leased bool `json:"leased"`
+	// How much we paid to lease the channel (iff `leased` is true and `opener` is local).
+	FundingFeePaidMsat Msat `json:"funding_fee_paid_msat,omitempty"`
+	// How much they paid to lease the channel (iff `leased` is true and `opener` is remote).
+	FundingFeeRcvdMsat Msat `json:"funding_fee_rcvd_msat,omitempty"`
+	// How much `opener` pushed immediate (if non-zero).
+	FundingPushedMsat Msat `json:"funding_pushed_msat,omitempty"`
+	// Total amount in the channel.
+	TotalMsat Msat `json:"total_msat"`
+	// Our balance in final commitment transaction.
+	FinalToUsMsat Msat `json:"final_to_us_msat"`
+	// Least amount owed to us ever. If the peer were to succesfully steal from us, this is the amount we would still retain.
+	MinToUsMsat Msat `json:"min_to_us_msat"`
+	// Most amount owed to us ever. If we were to successfully steal from the peer, this is the amount we could potentially get.
+	MaxToUsMsat Msat `json:"max_to_us_msat"`
+	// The final commitment tx's txid (or mutual close, if we accepted it). Not present for some very old, small channels pre-0.7.0.
+	LastCommitmentTxid string `json:"last_commitment_txid,omitempty"`
+	// The fee on `last_commitment_txid`.
+	LastCommitmentFeeMsat Msat `json:"last_commitment_fee_msat,omitempty"`
+	// What caused the channel to close.
+	CloseCause string `json:"close_cause"`
+	// Last time we reestablished the open channel and stayed connected for 1 minute.
+	LastStableConnection uint64 `json:"last_stable_connection,omitempty"`
+}
+
+type ListClosedChannelsClosedchannelsAlias struct {
+	// An alias assigned by this node to this channel, used for outgoing payments.
+	Local string `json:"local,omitempty"`
+	// An alias assigned by the remote node to this channel, usable in routehints and invoices.
+	Remote string `json:"remote,omitempty"`
+}
+
+type ListClosedChannelsClosedchannelsChannelType struct {
+	// Each bit set in this channel_type.
+	Bits []uint32 `json:"bits"`
+	// Feature name for each bit set in this channel_type.
+	Names []string `json:"names"`
+}
+
+// ListConfigs calls `listconfigs`: Command to list all configuration options.
+func (ln *Client) ListConfigs(ctx context.Context, req ListConfigsRequest) (resp ListConfigsResponse, err error) {
+	err = ln.CallTyped(ctx, "listconfigs", req, &resp)
+	return
+}
+
+// ListConfigsRequest are the parameters for `listconfigs`.
+type ListConfigsRequest struct {
+	// Configuration option name to restrict return.
+	Config *string `json:"config,omitempty"`
+}
+
+// ListConfigsResponse is the result of `listconfigs`.
+type ListConfigsResponse struct {
+	Configs ListConfigsConfigs `json:"configs,omitempty"`
+	// Deprecated: removed in newer lightningd versions.
+	Plugins []ListConfigsPlugins `json:"plugins,omitempty"`
+	// Deprecated: removed in newer lightningd versions.
+	ImportantPlugins []ListConfigsImportantPlugins `json:"important-plugins,omitempty"`
+}
+
+type ListConfigsConfigs struct {
+	// `conf` field from cmdline, or default.
+	Conf ListConfigsConfigsConf `json:"conf,omitempty"`
+	// `developer` field from config or cmdline, or default.
+	Developer ListConfigsConfigsDeveloper `json:"developer,omitempty"`
+	// `clear-plugins` field from config or cmdline, or default.
+	ClearPlugins ListConfigsConfigsClearPlugins `json:"clear-plugins,omitempty"`
+	// `disable-mpp` field from config or cmdline, or default.
+	DisableMpp ListConfigsConfigsDisableMpp `json:"disable-mpp,omitempty"`
+	// `mainnet` field from config or cmdline, or default.
+	Mainnet ListConfigsConfigsMainnet `json:"mainnet,omitempty"`
+	// `regtest` field from config or cmdline, or default.
+	Regtest ListConfigsConfigsRegtest `json:"regtest,omitempty"`
+	// `signet` field from config or cmdline, or default.
+	Signet ListConfigsConfigsSignet `json:"signet,omitempty"`
+	// `testnet` field from config or cmdline, or default.
+	Testnet ListConfigsConfigsTestnet `json:"testnet,omitempty"`
+	// `important-plugin` field from config or cmdline, or default.
+	ImportantPlugin ListConfigsConfigsImportantPlugin `json:"important-plugin,omitempty"`
+	// `plugin` field from config or cmdline, or default.
+	Plugin ListConfigsConfigsPlugin `json:"plugin,omitempty"`
+	// `plugin-dir` field from config or cmdline, or default.
+	PluginDir ListConfigsConfigsPluginDir `json:"plugin-dir,omitempty"`
+	// `lightning-dir` field from config or cmdline, or default.
+	LightningDir ListConfigsConfigsLightningDir `json:"lightning-dir,omitempty"`
+	// `network` field from config or cmdline, or default.
+	Network ListConfigsConfigsNetwork `json:"network,omitempty"`
+	// `allow-deprecated-apis` field from config or cmdline, or default.
+	AllowDeprecatedApis ListConfigsConfigsAllowDeprecatedApis `json:"allow-deprecated-apis,omitempty"`
+	// `rpc-file` field from config or cmdline, or default.
+	RpcFile ListConfigsConfigsRpcFile `json:"rpc-file,omitempty"`
+	// `disable-plugin` field from config or cmdline, or default.
+	DisablePlugin ListConfigsConfigsDisablePlugin `json:"disable-plugin,omitempty"`
+	// `always-use-proxy` field from config or cmdline, or default.
+	AlwaysUseProxy ListConfigsConfigsAlwaysUseProxy `json:"always-use-proxy,omitempty"`
+	// `daemon` field from config or cmdline, or default.
+	Daemon ListConfigsConfigsDaemon `json:"daemon,omitempty"`
+	// `wallet` field from config or cmdline, or default.
+	Wallet ListConfigsConfigsWallet `json:"wallet,omitempty"`
+	// `large-channels` field from config or cmdline, or default.
+	LargeChannels ListConfigsConfigsLargeChannels `json:"large-channels,omitempty"`
+	// `experimental-dual-fund` field from config or cmdline, or default.
+	ExperimentalDualFund ListConfigsConfigsExperimentalDualFund `json:"experimental-dual-fund,omitempty"`
+	// `experimental-splicing` field from config or cmdline, or default.
+	ExperimentalSplicing ListConfigsConfigsExperimentalSplicing `json:"experimental-splicing,omitempty"`
+	// `experimental-onion-messages` field from config or cmdline, or default.
+	ExperimentalOnionMessages ListConfigsConfigsExperimentalOnionMessages `json:"experimental-onion-messages,omitempty"`
+	// `experimental-offers` field from config or cmdline, or default.
+	ExperimentalOffers ListConfigsConfigsExperimentalOffers `json:"experimental-offers,omitempty"`
+	// `experimental-shutdown-wrong-funding` field from config or cmdline, or default.
+	ExperimentalShutdownWrongFunding ListConfigsConfigsExperimentalShutdownWrongFunding `json:"experimental-shutdown-wrong-funding,omitempty"`
+	// `experimental-peer-storage` field from config or cmdline, or default.
+	ExperimentalPeerStorage ListConfigsConfigsExperimentalPeerStorage `json:"experimental-peer-storage,omitempty"`
+	// `experimental-anchors` field from config or cmdline, or default.
+	ExperimentalAnchors ListConfigsConfigsExperimentalAnchors `json:"experimental-anchors,omitempty"`
+	// `database-upgrade` field from config or cmdline.
+	DatabaseUpgrade ListConfigsConfigsDatabaseUpgrade `json:"database-upgrade,omitempty"`
+	// `rgb` field from config or cmdline, or default.
+	Rgb ListConfigsConfigsRgb `json:"rgb,omitempty"`
+	// `alias` field from config or cmdline, or default.
+	Alias ListConfigsConfigsAlias `json:"alias,omitempty"`
+	// `pid-file` field from config or cmdline, or default.
+	PidFile ListConfigsConfigsPidFile `json:"pid-file,omitempty"`
+	// `ignore-fee-limits` field from config or cmdline, or default.
+	IgnoreFeeLimits ListConfigsConfigsIgnoreFeeLimits `json:"ignore-fee-limits,omitempty"`
+	// `watchtime-blocks` field from config or cmdline, or default.
+	WatchtimeBlocks ListConfigsConfigsWatchtimeBlocks `json:"watchtime-blocks,omitempty"`
+	// `max-locktime-blocks` field from config or cmdline, or default.
+	MaxLocktimeBlocks ListConfigsConfigsMaxLocktimeBlocks `json:"max-locktime-blocks,omitempty"`
+	// `funding-confirms` field from config or cmdline, or default.
+	FundingConfirms ListConfigsConfigsFundingConfirms `json:"funding-confirms,omitempty"`
+	// `cltv-delta` field from config or cmdline, or default.
+	CltvDelta ListConfigsConfigsCltvDelta `json:"cltv-delta,omitempty"`
+	// `cltv-final` field from config or cmdline, or default.
+	CltvFinal ListConfigsConfigsCltvFinal `json:"cltv-final,omitempty"`
+	// `commit-time` field from config or cmdline, or default.
+	CommitTime ListConfigsConfigsCommitTime `json:"commit-time,omitempty"`
+	// `fee-base` field from config or cmdline, or default.
+	FeeBase ListConfigsConfigsFeeBase `json:"fee-base,omitempty"`
+	// `rescan` field from config or cmdline, or default.
+	Rescan ListConfigsConfigsRescan `json:"rescan,omitempty"`
+	// `fee-per-satoshi` field from config or cmdline, or default.
+	FeePerSatoshi ListConfigsConfigsFeePerSatoshi `json:"fee-per-satoshi,omitempty"`
+	// `htlc-minimum-msat` field from config or cmdline, or default.
+	HtlcMinimumMsat ListConfigsConfigsHtlcMinimumMsat `json:"htlc-minimum-msat,omitempty"`
+	// `htlc-maximum-msat` field from config or cmdline, or default.
+	HtlcMaximumMsat ListConfigsConfigsHtlcMaximumMsat `json:"htlc-maximum-msat,omitempty"`
+	// `max-concurrent-htlcs` field from config or cmdline, or default.
+	MaxConcurrentHtlcs ListConfigsConfigsMaxConcurrentHtlcs `json:"max-concurrent-htlcs,omitempty"`
+	// `max-dust-htlc-exposure-msat` field from config or cmdline, or default.
+	MaxDustHtlcExposureMsat ListConfigsConfigsMaxDustHtlcExposureMsat `json:"max-dust-htlc-exposure-msat,omitempty"`
+	// `min-capacity-sat` field from config or cmdline, or default.
+	MinCapacitySat ListConfigsConfigsMinCapacitySat `json:"min-capacity-sat,omitempty"`
+	// `addr` field from config or cmdline.
+	Addr ListConfigsConfigsAddr `json:"addr,omitempty"`
+	// `announce-addr` field from config or cmdline.
+	AnnounceAddr ListConfigsConfigsAnnounceAddr `json:"announce-addr,omitempty"`
+	// `bind-addr` field from config or cmdline.
+	BindAddr ListConfigsConfigsBindAddr `json:"bind-addr,omitempty"`
+	// `true` if `offline` was set in config or cmdline.
+	Offline ListConfigsConfigsOffline `json:"offline,omitempty"`
+	// `autolisten` field from config or cmdline, or default.
+	Autolisten ListConfigsConfigsAutolisten `json:"autolisten,omitempty"`
+	// `proxy` field from config or cmdline, or default.
+	Proxy ListConfigsConfigsProxy `json:"proxy,omitempty"`
+	// `true` if `disable-dns` was set in config or cmdline.
+	DisableDns ListConfigsConfigsDisableDns `json:"disable-dns,omitempty"`
+	// `announce-addr-discovered` field from config or cmdline, or default.
+	AnnounceAddrDiscovered ListConfigsConfigsAnnounceAddrDiscovered `json:"announce-addr-discovered,omitempty"`
+	// `announce-addr-discovered-port` field from config or cmdline, or default.
+	AnnounceAddrDiscoveredPort ListConfigsConfigsAnnounceAddrDiscoveredPort `json:"announce-addr-discovered-port,omitempty"`
+	// `true` if `encrypted-hsm` was set in config or cmdline.
+	EncryptedHsm ListConfigsConfigsEncryptedHsm `json:"encrypted-hsm,omitempty"`
+	// `rpc-file-mode` field from config or cmdline, or default.
+	RpcFileMode ListConfigsConfigsRpcFileMode `json:"rpc-file-mode,omitempty"`
+	// `log-level` field from config or cmdline, or default.
+	LogLevel ListConfigsConfigsLogLevel `json:"log-level,omitempty"`
+	// `log-prefix` field from config or cmdline, or default.
+	LogPrefix ListConfigsConfigsLogPrefix `json:"log-prefix,omitempty"`
+	// `log-file` field from config or cmdline, or default.
+	LogFile ListConfigsConfigsLogFile `json:"log-file,omitempty"`
+	// `log-timestamps` field from config or cmdline, or default.
+	LogTimestamps ListConfigsConfigsLogTimestamps `json:"log-timestamps,omitempty"`
+	// `force-feerates` field from config or cmdline, or default.
+	ForceFeerates ListConfigsConfigsForceFeerates `json:"force-feerates,omitempty"`
+	// `subdaemon` fields from config or cmdline if any (can be multiple).
+	Subdaemon ListConfigsConfigsSubdaemon `json:"subdaemon,omitempty"`
+	// `fetchinvoice-noconnect` fields from config or cmdline, or default.
+	FetchinvoiceNoconnect ListConfigsConfigsFetchinvoiceNoconnect `json:"fetchinvoice-noconnect,omitempty"`
+	// `tor-service-password` field from config or cmdline, or default.
+	TorServicePassword ListConfigsConfigsTorServicePassword `json:"tor-service-password,omitempty"`
+	// `announce-addr-dns` field from config or cmdline, or default.
+	AnnounceAddrDns ListConfigsConfigsAnnounceAddrDns `json:"announce-addr-dns,omitempty"`
+	// `require-confirmed-inputs` field from config or cmdline, or default.
+	RequireConfirmedInputs ListConfigsConfigsRequireConfirmedInputs `json:"require-confirmed-inputs,omitempty"`
+	// `commit-fee` field from config or cmdline, or default.
+	CommitFee ListConfigsConfigsCommitFee `json:"commit-fee,omitempty"`
+	// `commit-feerate-offset` field from config or cmdline, or default.
+	CommitFeerateOffset ListConfigsConfigsCommitFeerateOffset `json:"commit-feerate-offset,omitempty"`
+	// `autoconnect-seeker-peers` field from config or cmdline, or default.
+	AutoconnectSeekerPeers ListConfigsConfigsAutoconnectSeekerPeers `json:"autoconnect-seeker-peers,omitempty"`
+}
+
+type ListConfigsConfigsConf struct {
+	// Value of the config.
+	ValueStr string `json:"value_str"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsDeveloper struct {
+	// `true` if set in config or cmdline.
+	Set bool `json:"set"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+}
+
+type ListConfigsConfigsClearPlugins struct {
+	// `true` if set in config or cmdline.
+	Set bool `json:"set"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+}
+
+type ListConfigsConfigsDisableMpp struct {
+	// `true` if set in config or cmdline.
+	Set bool `json:"set"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+}
+
+type ListConfigsConfigsMainnet struct {
+	// `true` if set in config or cmdline.
+	Set bool `json:"set"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+}
+
+type ListConfigsConfigsRegtest struct {
+	// `true` if set in config or cmdline.
+	Set bool `json:"set"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+}
+
+type ListConfigsConfigsSignet struct {
+	// `true` if set in config or cmdline.
+	Set bool `json:"set"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+}
+
+type ListConfigsConfigsTestnet struct {
+	// `true` if set in config or cmdline.
+	Set bool `json:"set"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+}
+
+type ListConfigsConfigsImportantPlugin struct {
+	ValuesStr []string `json:"values_str"`
+	Sources   []string `json:"sources"`
+}
+
+type ListConfigsConfigsPlugin struct {
+	ValuesStr []string `json:"values_str"`
+	Sources   []string `json:"sources"`
+}
+
+type ListConfigsConfigsPluginDir struct {
+	ValuesStr []string `json:"values_str"`
+	Sources   []string `json:"sources"`
+}
+
+type ListConfigsConfigsLightningDir struct {
+	// Value of the config.
+	ValueStr string `json:"value_str"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsNetwork struct {
+	// Value of the config.
+	ValueStr string `json:"value_str"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsAllowDeprecatedApis struct {
+	// Value of the config.
+	ValueBool bool `json:"value_bool"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsRpcFile struct {
+	// Value of the config.
+	ValueStr string `json:"value_str"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsDisablePlugin struct {
+	ValuesStr []string `json:"values_str"`
+	Sources   []string `json:"sources"`
+}
+
+type ListConfigsConfigsAlwaysUseProxy struct {
+	// Value of the config.
+	ValueBool bool `json:"value_bool"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsDaemon struct {
+	// `true` if set in config or cmdline.
+	Set bool `json:"set"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+}
+
+type ListConfigsConfigsWallet struct {
+	// Value of the config.
+	ValueStr string `json:"value_str"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsLargeChannels struct {
+	// `true` if set in config or cmdline.
+	Set bool `json:"set"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+}
+
+type ListConfigsConfigsExperimentalDualFund struct {
+	// `true` if set in config or cmdline.
+	Set bool `json:"set"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+}
+
+type ListConfigsConfigsExperimentalSplicing struct {
+	// `true` if set in config or cmdline.
+	Set bool `json:"set"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+}
+
+type ListConfigsConfigsExperimentalOnionMessages struct {
+	// `true` if set in config or cmdline.
+	Set bool `json:"set"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+}
+
+type ListConfigsConfigsExperimentalOffers struct {
+	// `true` if set in config or cmdline.
+	Set bool `json:"set"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+}
+
+type ListConfigsConfigsExperimentalShutdownWrongFunding struct {
+	// `true` if set in config or cmdline.
+	Set bool `json:"set"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+}
+
+type ListConfigsConfigsExperimentalPeerStorage struct {
+	// `true` if set in config or cmdline.
+	Set bool `json:"set"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+}
+
+type ListConfigsConfigsExperimentalAnchors struct {
+	// `true` if set in config or cmdline.
+	Set bool `json:"set"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+}
+
+type ListConfigsConfigsDatabaseUpgrade struct {
+	// Value of the config.
+	ValueBool bool `json:"value_bool"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsRgb struct {
+	// Value of the config.
+	ValueStr string `json:"value_str"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsAlias struct {
+	// Value of the config.
+	ValueStr string `json:"value_str"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsPidFile struct {
+	// Value of the config.
+	ValueStr string `json:"value_str"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsIgnoreFeeLimits struct {
+	// Value of the config.
+	ValueBool bool `json:"value_bool"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsWatchtimeBlocks struct {
+	// Value of the config.
+	ValueInt uint32 `json:"value_int"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsMaxLocktimeBlocks struct {
+	// Value of the config.
+	ValueInt uint32 `json:"value_int"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsFundingConfirms struct {
+	// Value of the config.
+	ValueInt uint32 `json:"value_int"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsCltvDelta struct {
+	// Value of the config.
+	ValueInt uint32 `json:"value_int"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsCltvFinal struct {
+	// Value of the config.
+	ValueInt uint32 `json:"value_int"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsCommitTime struct {
+	// Value of the config.
+	ValueInt uint32 `json:"value_int"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsFeeBase struct {
+	// Value of the config.
+	ValueInt uint32 `json:"value_int"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsRescan struct {
+	// Value of the config.
+	ValueInt uint32 `json:"value_int"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsFeePerSatoshi struct {
+	// Value of the config.
+	ValueInt uint32 `json:"value_int"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsHtlcMinimumMsat struct {
+	// Value of the config.
+	ValueMsat Msat `json:"value_msat"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+}
+
+type ListConfigsConfigsHtlcMaximumMsat struct {
+	// Value of the config.
+	ValueMsat Msat `json:"value_msat"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+}
+
+type ListConfigsConfigsMaxConcurrentHtlcs struct {
+	// Value of the config.
+	ValueInt uint32 `json:"value_int"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsMaxDustHtlcExposureMsat struct {
+	// Value of the config.
+	ValueMsat Msat `json:"value_msat"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+}
+
+type ListConfigsConfigsMinCapacitySat struct {
+	// Value of the config.
+	ValueInt uint32 `json:"value_int"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsAddr struct {
+	ValuesStr []string `json:"values_str"`
+	Sources   []string `json:"sources"`
+}
+
+type ListConfigsConfigsAnnounceAddr struct {
+	ValuesStr []string `json:"values_str"`
+	Sources   []string `json:"sources"`
+}
+
+type ListConfigsConfigsBindAddr struct {
+	ValuesStr []string `json:"values_str"`
+	Sources   []string `json:"sources"`
+}
+
+type ListConfigsConfigsOffline struct {
+	// `true` if set in config or cmdline.
+	Set bool `json:"set"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+}
+
+type ListConfigsConfigsAutolisten struct {
+	// Value of the config.
+	ValueBool bool `json:"value_bool"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsProxy struct {
+	// Value of the config.
+	ValueStr string `json:"value_str"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsDisableDns struct {
+	// `true` if set in config or cmdline.
+	Set bool `json:"set"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+}
+
+type ListConfigsConfigsAnnounceAddrDiscovered struct {
+	// Value of the config.
+	ValueStr string `json:"value_str"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsAnnounceAddrDiscoveredPort struct {
+	// Value of the config.
+	ValueInt uint32 `json:"value_int"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsEncryptedHsm struct {
+	// `true` if set in config or cmdline.
+	Set bool `json:"set"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+}
+
+type ListConfigsConfigsRpcFileMode struct {
+	// Value of the config.
+	ValueStr string `json:"value_str"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsLogLevel struct {
+	// Value of the config.
+	ValueStr string `json:"value_str"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsLogPrefix struct {
+	// Value of the config.
+	ValueStr string `json:"value_str"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsLogFile struct {
+	ValuesStr []string `json:"values_str"`
+	Sources   []string `json:"sources"`
+}
+
+type ListConfigsConfigsLogTimestamps struct {
+	// Value of the config.
+	ValueBool bool `json:"value_bool"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsForceFeerates struct {
+	// Value of the config.
+	ValueStr string `json:"value_str"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsSubdaemon struct {
+	ValuesStr []string `json:"values_str"`
+	Sources   []string `json:"sources"`
+}
+
+type ListConfigsConfigsFetchinvoiceNoconnect struct {
+	// `true` if set in config or cmdline.
+	Set bool `json:"set"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+}
+
+type ListConfigsConfigsTorServicePassword struct {
+	// Value of the config.
+	ValueStr string `json:"value_str"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsAnnounceAddrDns struct {
+	// Value of the config.
+	ValueBool bool `json:"value_bool"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsRequireConfirmedInputs struct {
+	// Value of the config.
+	ValueBool bool `json:"value_bool"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsCommitFee struct {
+	// Value of the config.
+	ValueInt uint32 `json:"value_int"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsCommitFeerateOffset struct {
+	// Value of the config.
+	ValueInt uint32 `json:"value_int"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsConfigsAutoconnectSeekerPeers struct {
+	// Value of the config.
+	ValueInt uint32 `json:"value_int"`
+	// Source of configuration setting.
+	Source string `json:"source"`
+	// Plugin which registered this configuration setting.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this is dynamically changeable (`setconfig`).
+	Dynamic bool `json:"dynamic,omitempty"`
+}
+
+type ListConfigsPlugins struct {
+	// Full path of the plugin.
+	Path string `json:"path"`
+	// Short name of the plugin.
+	Name string `json:"name"`
+	// Specific options set for this plugin.
+	Options json.RawMessage `json:"options,omitempty"`
+}
+
+type ListConfigsImportantPlugins struct {
+	// Full path of the plugin.
+	Path string `json:"path"`
+	// Short name of the plugin.
+	Name string `json:"name"`
+	// Specific options set for this plugin.
+	Options json.RawMessage `json:"options,omitempty"`
+}
+
+// ListDatastore calls `listdatastore`: Command for listing (plugin) data.
+func (ln *Client) ListDatastore(ctx context.Context, req ListDatastoreRequest) (resp ListDatastoreResponse, err error) {
+	err = ln.CallTyped(ctx, "listdatastore", req, &resp)
+	return
+}
+
+// ListDatastoreRequest are the parameters for `listdatastore`.
+type ListDatastoreRequest struct {
+	// All descendents of this key (or root, if not specified) are returned.
+	Key json.RawMessage `json:"key,omitempty"`
+}
+
+// ListDatastoreResponse is the result of `listdatastore`.
+type ListDatastoreResponse struct {
+	Datastore []ListDatastoreDatastore `json:"datastore"`
+}
+
+type ListDatastoreDatastore struct {
+	Key []string `json:"key"`
+	// The number of times this has been updated.
+	Generation uint64 `json:"generation,omitempty"`
+	// The hex data from the datastore.
+	Hex string `json:"hex,omitempty"`
+	// The data as a string, if it's valid utf-8.
+	String string `json:"string,omitempty"`
+}
+
+// ListForwards calls `listforwards`: Command showing all htlcs and their information.
+func (ln *Client) ListForwards(ctx context.Context, req ListForwardsRequest) (resp ListForwardsResponse, err error) {
+	err = ln.CallTyped(ctx, "listforwards", req, &resp)
+	return
+}
+
+// ListForwardsRequest are the parameters for `listforwards`.
+type ListForwardsRequest struct {
+	// If specified, then only the forwards with the given status are returned.
+	Status *string `json:"status,omitempty"`
+	// Only the matching forwards on the given inbound channel are returned.
+	InChannel *string `json:"in_channel,omitempty"`
+	// Only the matching forwards on the given outbount channel are returned.
+	OutChannel *string `json:"out_channel,omitempty"`
+	// If neither *in_channel* nor *out_channel* is specified, it controls ordering.
+	Index *string `json:"index,omitempty"`
+	// If `index` is specified, `start` may be specified to start from that value, which is generally returned from lightning-wait(7).
+	Start *uint64 `json:"start,omitempty"`
+	// If `index` is specified, `limit` can be used to specify the maximum number of entries to return.
+	Limit *uint32 `json:"limit,omitempty"`
+}
+
+// ListForwardsResponse is the result of `listforwards`.
+type ListForwardsResponse struct {
+	Forwards []ListForwardsForwards `json:"forwards"`
+}
+
+type ListForwardsForwards struct {
+	// 1-based index indicating order this forward was created in.
+	CreatedIndex uint64 `json:"created_index"`
+	// The channel that received the HTLC.
+	InChannel string `json:"in_channel"`
+	// The unique HTLC id the sender gave this (not present if incoming channel was closed before upgrade to v22.11).
+	InHtlcID uint64 `json:"in_htlc_id,omitempty"`
+	// The value of the incoming HTLC.
+	InMsat Msat `json:"in_msat"`
+	// Still ongoing, completed, failed locally, or failed after forwarding.
+	Status string `json:"status"`
+	// The UNIX timestamp when this was received.
+	ReceivedTime float64 `json:"received_time"`
+	// The channel that the HTLC (trying to) forward to.
+	OutChannel string `json:"out_channel,omitempty"`
+	// The unique HTLC id we gave this when sending (may be missing even if out_channel is present, for old forwards before v22.11).
+	OutHtlcID uint64 `json:"out_htlc_id,omitempty"`
+	// 1-based index indicating order this forward was changed (only present if it has changed since creation).
+	UpdatedIndex uint64 `json:"updated_index,omitempty"`
+	// Either a legacy onion format or a modern tlv format.
+	Style string `json:"style,omitempty"`
+	// The amount this paid in fees.
+	FeeMsat Msat `json:"fee_msat,omitempty"`
+	// The amount we sent out the *out_channel*.
+	OutMsat Msat `json:"out_msat,omitempty"`
+	// The UNIX timestamp when this was resolved.
+	ResolvedTime float64 `json:"resolved_time,omitempty"`
+	// The numeric onion code returned.
+	Failcode uint32 `json:"failcode,omitempty"`
+	// The name of the onion code returned.
+	Failreason string `json:"failreason,omitempty"`
+}
+
+// ListFunds calls `listfunds`: Command showing all funds currently managed by the Core Lightning node.
+func (ln *Client) ListFunds(ctx context.Context, req ListFundsRequest) (resp ListFundsResponse, err error) {
+	err = ln.CallTyped(ctx, "listfunds", req, &resp)
+	return
+}
+
+// ListFundsRequest are the parameters for `listfunds`.
+type ListFundsRequest struct {
+	// If True, then the *outputs* will include spent outputs in addition to the unspent ones.
+	Spent *bool `json:"spent,omitempty"`
+}
+
+// ListFundsResponse is the result of `listfunds`.
+type ListFundsResponse struct {
+	Outputs  []ListFundsOutputs  `json:"outputs"`
+	Channels []ListFundsChannels `json:"channels"`
+}
+
+type ListFundsOutputs struct {
+	// The ID of the spendable transaction.
+	Txid string `json:"txid"`
+	// The index within *txid*.
+	Output uint32 `json:"output"`
+	// The amount of the output.
+	AmountMsat Msat `json:"amount_msat"`
+	// The scriptPubkey of the output.
+	Scriptpubkey string `json:"scriptpubkey"`
+	// The bitcoin address of the output.
+	Address string `json:"address,omitempty"`
+	// The redeemscript, only if it's p2sh-wrapped.
+	Redeemscript string `json:"redeemscript,omitempty"`
+	Status       string `json:"status"`
+	// Whether this UTXO is currently reserved for an in-flight tx.
+	Reserved bool `json:"reserved"`
+	// Block height where reservation will expire.
+	ReservedToBlock uint32 `json:"reserved_to_block,omitempty"`
+	// Block height where it was confirmed.
+	Blockheight uint32 `json:"blockheight,omitempty"`
+}
+
+type ListFundsChannels struct {
+	// The peer with which the channel is opened.
+	PeerID string `json:"peer_id"`
+	// Available satoshis on our node's end of the channel.
+	OurAmountMsat Msat `json:"our_amount_msat"`
+	// Total channel value.
+	AmountMsat Msat `json:"amount_msat"`
+	// Funding transaction id.
+	FundingTxid string `json:"funding_txid"`
+	// The 0-based index of the output in the funding transaction.
+	FundingOutput uint32 `json:"funding_output"`
+	// Whether the channel peer is connected.
+	Connected bool `json:"connected"`
+	// The channel state, in particular `CHANNELD_NORMAL` means the channel can be used normally.
+	State string `json:"state"`
+	// The full channel_id (funding txid Xored with output number).
+	ChannelID string `json:"channel_id"`
+	// Short channel id of channel (only if funding reached lockin depth).
+	ShortChannelID string `json:"short_channel_id,omitempty"`
+}
+
+// ListHtlcs calls `listhtlcs`: Command for querying HTLCs.
+func (ln *Client) ListHtlcs(ctx context.Context, req ListHtlcsRequest) (resp ListHtlcsResponse, err error) {
+	err = ln.CallTyped(ctx, "listhtlcs", req, &resp)
+	return
+}
+
+// ListHtlcsRequest are the parameters for `listhtlcs`.
+type ListHtlcsRequest struct {
+	// A short channel id (e.g. 1x2x3) or full 64-byte hex channel id, it will only list htlcs for that channel (which must be known).
+	ID *string `json:"id,omitempty"`
+}
+
+// ListHtlcsResponse is the result of `listhtlcs`.
+type ListHtlcsResponse struct {
+	Htlcs []ListHtlcsHtlcs `json:"htlcs"`
+}
+
+type ListHtlcsHtlcs struct {
+	// The channel that contains/contained the HTLC.
+	ShortChannelID string `json:"short_channel_id"`
+	// The unique, incrementing HTLC id the creator gave this.
+	ID uint64 `json:"id"`
+	// The block number where this HTLC expires/expired.
+	Expiry uint32 `json:"expiry"`
+	// The value of the HTLC.
+	AmountMsat Msat `json:"amount_msat"`
+	// Out if we offered this to the peer, in if they offered it.
+	Direction string `json:"direction"`
+	// Payment hash sought by HTLC.
+	PaymentHash string `json:"payment_hash"`
+	// The first 10 states are for `in`, the next 10 are for `out`.
+	State string `json:"state"`
+}
+
+// ListInvoiceRequests calls `listinvoicerequests`: Command for querying invoice_request status.
+func (ln *Client) ListInvoiceRequests(ctx context.Context, req ListInvoiceRequestsRequest) (resp ListInvoiceRequestsResponse, err error) {
+	err = ln.CallTyped(ctx, "listinvoicerequests", req, &resp)
+	return
+}
+
+// ListInvoiceRequestsRequest are the parameters for `listinvoicerequests`.
+type ListInvoiceRequestsRequest struct {
+	// A specific invoice can be queried by providing the `invreq_id`, which is presented by lightning-invoicerequest(7), or can be calculated from a bolt12 invoice.
+	InvreqID *string `json:"invreq_id,omitempty"`
+	// If it is *True* then only active invoice requests are returned.
+	ActiveOnly *bool `json:"active_only,omitempty"`
+}
+
+// ListInvoiceRequestsResponse is the result of `listinvoicerequests`.
+type ListInvoiceRequestsResponse struct {
+	Invoicerequests []ListInvoiceRequestsInvoicerequests `json:"invoicerequests"`
+}
+
+type ListInvoiceRequestsInvoicerequests struct {
+	// The SHA256 hash of all invoice_request fields less than 160.
+	InvreqID string `json:"invreq_id"`
+	// Whether the invoice_request is currently active.
+	Active bool `json:"active"`
+	// Whether the invoice_request will become inactive after we pay an invoice for it.
+	SingleUse bool `json:"single_use"`
+	// The bolt12 string starting with lnr.
+	Bolt12 string `json:"bolt12"`
+	// Whether the invoice_request has already been used.
+	Used bool `json:"used"`
+	// The label provided when creating the invoice_request.
+	Label string `json:"label,omitempty"`
+}
+
+// ListInvoices calls `listinvoices`: Command for querying invoice status.
+func (ln *Client) ListInvoices(ctx context.Context, req ListInvoicesRequest) (resp ListInvoicesResponse, err error) {
+	err = ln.CallTyped(ctx, "listinvoices", req, &resp)
+	return
+}
+
+// ListInvoicesRequest are the parameters for `listinvoices`.
+type ListInvoicesRequest struct {
+	// A label used a creation time to identify the invoice.
+	Label *string `json:"label,omitempty"`
+	// BOLT11 or BOLT12 string.
+	Invstring *string `json:"invstring,omitempty"`
+	// A payment_hash belonging to the invoice.
+	PaymentHash *string `json:"payment_hash,omitempty"`
+	// A local `offer_id` the invoice was issued for a specific invoice details.
+	OfferID *string `json:"offer_id,omitempty"`
+	// If neither *in_channel* nor *out_channel* is specified, it controls ordering.
+	Index *string `json:"index,omitempty"`
+	// If `index` is specified, `start` may be specified to start from that value, which is generally returned from lightning-wait(7).
+	Start *uint64 `json:"start,omitempty"`
+	// If `index` is specified, `limit` can be used to specify the maximum number of entries to return.
+	Limit *uint32 `json:"limit,omitempty"`
+}
+
+// ListInvoicesResponse is the result of `listinvoices`.
+type ListInvoicesResponse struct {
+	Invoices []ListInvoicesInvoices `json:"invoices"`
+}
+
+type ListInvoicesInvoices struct {
+	// Unique label supplied at invoice creation.
+	Label string `json:"label"`
+	// Description used in the invoice.
+	Description string `json:"description,omitempty"`
+	// The hash of the *payment_preimage* which will prove payment.
+	PaymentHash string `json:"payment_hash"`
+	// Whether it's paid, unpaid or unpayable.
+	Status string `json:"status"`
+	// UNIX timestamp of when it will become / became unpayable.
+	ExpiresAt uint64 `json:"expires_at"`
+	// The amount required to pay this invoice.
+	AmountMsat Msat `json:"amount_msat,omitempty"`
+	// The BOLT11 string (always present unless *bolt12* is).
+	Bolt11 string `json:"bolt11,omitempty"`
+	// The BOLT12 string (always present unless *bolt11* is).
+	Bolt12 string `json:"bolt12,omitempty"`
+	// The *id* of our offer which created this invoice (**experimental-offers** only).
+	LocalOfferID string `json:"local_offer_id,omitempty"`
+	// The optional *invreq_payer_note* from invoice_request which created this invoice (**experimental-offers** only).
+	InvreqPayerNote string `json:"invreq_payer_note,omitempty"`
+	// 1-based index indicating order this invoice was created in.
+	CreatedIndex uint64 `json:"created_index"`
+	// 1-based index indicating order this invoice was changed (only present if it has changed since creation).
+	UpdatedIndex uint64 `json:"updated_index,omitempty"`
+	// Unique incrementing index for this payment (only if status is paid).
+	PayIndex uint64 `json:"pay_index,omitempty"`
+	// The amount actually received (could be slightly greater than *amount_msat*, since clients may overpay) (only if status is paid).
+	AmountReceivedMsat Msat `json:"amount_received_msat,omitempty"`
+	// UNIX timestamp of when it was paid (only if status is paid).
+	PaidAt uint64 `json:"paid_at,omitempty"`
+	// Proof of payment (only if status is paid).
+	PaymentPreimage string `json:"payment_preimage,omitempty"`
+}
+
+// ListNodes calls `listnodes`: Command to get the list of nodes in the known network.
+func (ln *Client) ListNodes(ctx context.Context, req ListNodesRequest) (resp ListNodesResponse, err error) {
+	err = ln.CallTyped(ctx, "listnodes", req, &resp)
+	return
+}
+
+// ListNodesRequest are the parameters for `listnodes`.
+type ListNodesRequest struct {
+	// The public key of the node to list.
+	ID *string `json:"id,omitempty"`
+}
+
+// ListNodesResponse is the result of `listnodes`.
+type ListNodesResponse struct {
+	Nodes []ListNodesNodes `json:"nodes"`
+}
+
+type ListNodesNodes struct {
+	// The public key of the node.
+	Nodeid string `json:"nodeid"`
+	// A node_announcement has been received for this node (UNIX timestamp).
+	LastTimestamp uint32 `json:"last_timestamp,omitempty"`
+	// The fun alias this node advertized.
+	Alias string `json:"alias,omitempty"`
+	// The favorite RGB color this node advertized.
+	Color string `json:"color,omitempty"`
+	// BOLT #9 features bitmap this node advertized.
+	Features string `json:"features,omitempty"`
+	// The addresses this node advertized.
+	Addresses []ListNodesNodesAddresses `json:"addresses,omitempty"`
+}
+
+type ListNodesNodesAddresses struct {
+	// Type of connection (until 23.08, `websocket` was also allowed).
+	Type string `json:"type"`
+	// Port number.
+	Port uint16 `json:"port"`
+	// Address in expected format for **type**.
+	Address string `json:"address,omitempty"`
+}
+
+// ListOffers calls `listoffers`: Command for listing offers.
+func (ln *Client) ListOffers(ctx context.Context, req ListOffersRequest) (resp ListOffersResponse, err error) {
+	err = ln.CallTyped(ctx, "listoffers", req, &resp)
+	return
+}
+
+// ListOffersRequest are the parameters for `listoffers`.
+type ListOffersRequest struct {
+	// Offer_id to get details for (if it exists).
+	OfferID *string `json:"offer_id,omitempty"`
+	// If set and is true, only offers with `active` true are returned.
+	ActiveOnly *bool `json:"active_only,omitempty"`
+}
+
+// ListOffersResponse is the result of `listoffers`.
+type ListOffersResponse struct {
+	Offers []ListOffersOffers `json:"offers"`
+}
+
+type ListOffersOffers struct {
+	// The id of this offer (merkle hash of non-signature fields).
+	OfferID string `json:"offer_id"`
+	// Whether this can still be used.
+	Active bool `json:"active"`
+	// Whether this expires as soon as it's paid.
+	SingleUse bool `json:"single_use"`
+	// The bolt12 encoding of the offer.
+	Bolt12 string `json:"bolt12"`
+	// True if an associated invoice has been paid.
+	Used bool `json:"used"`
+	// The (optional) user-specified label.
+	Label string `json:"label,omitempty"`
+}
+
+// ListPays calls `listpays`: Command for querying payment status.
+func (ln *Client) ListPays(ctx context.Context, req ListPaysRequest) (resp ListPaysResponse, err error) {
+	err = ln.CallTyped(ctx, "listpays", req, &resp)
+	return
+}
+
+// ListPaysRequest are the parameters for `listpays`.
+type ListPaysRequest struct {
+	// Bolt11 string to get the payment details.
+	Bolt11 *string `json:"bolt11,omitempty"`
+	// Payment hash to get the payment details.
+	PaymentHash *string `json:"payment_hash,omitempty"`
+	// To filter the payment by status.
+	Status *string `json:"status,omitempty"`
+}
+
+// ListPaysResponse is the result of `listpays`.
+type ListPaysResponse struct {
+	Pays []ListPaysPays `json:"pays"`
+}
+
+type ListPaysPays struct {
+	// The hash of the *payment_preimage* which will prove payment.
+	PaymentHash string `json:"payment_hash"`
+	// Status of the payment.
+	Status string `json:"status"`
+	// The final destination of the payment if known.
+	Destination string `json:"destination,omitempty"`
+	// The UNIX timestamp showing when this payment was initiated.
+	CreatedAt uint64 `json:"created_at"`
+	// The UNIX timestamp showing when this payment was completed.
+	CompletedAt uint64 `json:"completed_at,omitempty"`
+	// The label, if given to sendpay.
+	Label string `json:"label,omitempty"`
+	// The bolt11 string (if pay supplied one).
+	Bolt11 string `json:"bolt11,omitempty"`
+	// The description matching the bolt11 description hash (if pay supplied one).
+	Description string `json:"description,omitempty"`
+	// The bolt12 string (if supplied for pay: **experimental-offers** only).
+	Bolt12 string `json:"bolt12,omitempty"`
+	// The amount the destination received, if known (**status** *complete* or *pending*).
+	AmountMsat Msat `json:"amount_msat,omitempty"`
+	// The amount we actually sent, including fees (**status** *complete* or *pending*).
+	AmountSentMsat Msat `json:"amount_sent_msat,omitempty"`
+	// Proof of payment (**status** *complete* only).
+	Preimage string `json:"preimage,omitempty"`
+	// The number of parts for a successful payment (only if more than one, **status** *complete* only).
+	NumberOfParts uint64 `json:"number_of_parts,omitempty"`
+	// The error onion returned on failure, if any (**status** *failed* only).
+	Erroronion string `json:"erroronion,omitempty"`
+}
+
+// ListPeerChannels calls `listpeerchannels`: Command returning data on channels of connected lightning nodes.
+func (ln *Client) ListPeerChannels(ctx context.Context, req ListPeerChannelsRequest) (resp ListPeerChannelsResponse, err error) {
+	err = ln.CallTyped(ctx, "listpeerchannels", req, &resp)
+	return
+}
+
+// ListPeerChannelsRequest are the parameters for `listpeerchannels`.
+type ListPeerChannelsRequest struct {
+	// If supplied, limits the channels to just the peer with the given ID, if it exists.
+	ID *string `json:"id,omitempty"`
+	// If supplied, limits the channels to just the given short_channel_id, if it exists.
+	ShortChannelID *string `json:"short_channel_id,omitempty"`
+}
+
+// ListPeerChannelsResponse is the result of `listpeerchannels`.
+type ListPeerChannelsResponse struct {
+	Channels []ListPeerChannelsChannels `json:"channels"`
+}
+
+type ListPeerChannelsChannels struct {
+	// Node Public key.
+	PeerID string `json:"peer_id"`
+	// A boolean flag that is set to true if the peer is online.
+	PeerConnected bool `json:"peer_connected"`
+	// The channel state, in particular `CHANNELD_NORMAL` means the channel can be used normally.
+	State string `json:"state"`
+	// The txid we would use if we went onchain now.
+	ScratchTxid string `json:"scratch_txid,omitempty"`
+	// Feerates for the current tx.
+	Feerate ListPeerChannelsChannelsFeerate `json:"feerate,omitempty"`
+	// The current subdaemon controlling this connection.
+	Owner string `json:"owner,omitempty"`
+	// The short_channel_id (once locked in).
+	ShortChannelID string `json:"short_channel_id,omitempty"`
+	// The full channel_id (funding txid Xored with output number).
+	ChannelID string `json:"channel_id,omitempty"`
+	// ID of the funding transaction.
+	FundingTxid string `json:"funding_txid,omitempty"`
+	// The 0-based output number of the funding transaction which opens the channel.
+	FundingOutnum uint32                        `json:"funding_outnum,omitempty"`
+	Alias         ListPeerChannelsChannelsAlias `json:"alias,omitempty"`
+	// Who initiated the channel.
+	Opener string `json:"opener"`
+	// Who initiated the channel close (only present if closing).
+	Closer   string   `json:"closer,omitempty"`
+	Features []string `json:"features"`
+	// If True, we don't announce this channel.
+	Private bool `json:"private,omitempty"`
+	// How much of channel is owed to us.
+	ToUsMsat Msat `json:"to_us_msat,omitempty"`
+	// Least amount owed to us ever. If the peer were to successfully steal from us, this is the amount we would still retain.
+	MinToUsMsat Msat `json:"min_to_us_msat,omitempty"`
+	// Most amount owed to us ever. If we were to successfully steal from the peer, this is the amount we could potentially get.
+	MaxToUsMsat Msat `json:"max_to_us_msat,omitempty"`
+	// Total amount in the channel.
+	TotalMsat Msat `json:"total_msat,omitempty"`
+	// Amount we charge to use the channel.
+	FeeBaseMsat Msat `json:"fee_base_msat,omitempty"`
+	// Amount we charge to use the channel in parts-per-million.
+	FeeProportionalMillionths uint32 `json:"fee_proportional_millionths,omitempty"`
+	// Minimum possible output from this channel.
+	DustLimitMsat Msat `json:"dust_limit_msat,omitempty"`
+	// An estimate of the maximum we can send through this channel.
+	SpendableMsat Msat `json:"spendable_msat,omitempty"`
+	// An estimate of the maximum peer can send to us over this channel.
+	ReceivableMsat Msat `json:"receivable_msat,omitempty"`
+	// The number of blocks before they can take their funds if they unilateral close.
+	TheirToSelfDelay uint32 `json:"their_to_self_delay,omitempty"`
+	// The number of blocks before we can take our funds if we unilateral close.
+	OurToSelfDelay uint32 `json:"our_to_self_delay,omitempty"`
+	// Latest gossip updates sent/received.
+	Updates ListPeerChannelsChannelsUpdates `json:"updates,omitempty"`
+	Status  []string                        `json:"status,omitempty"`
+	// Number of incoming payment attempts.
+	InPaymentsOffered uint64 `json:"in_payments_offered,omitempty"`
+	// Total amount of incoming payment attempts.
+	InOfferedMsat Msat `json:"in_offered_msat,omitempty"`
+	// Number of successful incoming payment attempts.
+	InPaymentsFulfilled uint64 `json:"in_payments_fulfilled,omitempty"`
+	// Total amount of successful incoming payment attempts.
+	InFulfilledMsat Msat `json:"in_fulfilled_msat,omitempty"`
+	// Number of outgoing payment attempts.
+	OutPaymentsOffered uint64 `json:"out_payments_offered,omitempty"`
+	// Total amount of outgoing payment attempts.
+	OutOfferedMsat Msat `json:"out_offered_msat,omitempty"`
+	// Number of successful outgoing payment attempts.
+	OutPaymentsFulfilled uint64 `json:"out_payments_fulfilled,omitempty"`
+	// Total amount of successful outgoing payment attempts.
+	OutFulfilledMsat Msat `json:"out_fulfilled_msat,omitempty"`
+	// Current HTLCs in this channel.
+	Htlcs []ListPeerChannelsChannelsHtlcs `json:"htlcs,omitempty"`
+}
+
+type ListPeerChannelsChannelsFeerate struct {
+	// Feerate per 1000 weight (i.e kSipa).
+	Perkw uint32 `json:"perkw"`
+	// Feerate per 1000 virtual bytes.
+	Perkb uint32 `json:"perkb"`
+}
+
+type ListPeerChannelsChannelsAlias struct {
+	// An alias assigned by this node to this channel, used for outgoing payments.
+	Local string `json:"local,omitempty"`
+	// An alias assigned by the remote node to this channel, usable in routehints and invoices.
+	Remote string `json:"remote,omitempty"`
+}
+
+type ListPeerChannelsChannelsUpdates struct {
+	// Our gossip for channel.
+	Local ListPeerChannelsChannelsUpdatesLocal `json:"local"`
+	// Peer's gossip for channel.
+	Remote ListPeerChannelsChannelsUpdatesRemote `json:"remote,omitempty"`
+}
+
+type ListPeerChannelsChannelsUpdatesLocal struct {
+	// Minimum msat amount we allow.
+	HtlcMinimumMsat Msat `json:"htlc_minimum_msat"`
+	// Maximum msat amount we allow.
+	HtlcMaximumMsat Msat `json:"htlc_maximum_msat"`
+	// Blocks delay required between incoming and outgoing HTLCs.
+	CltvExpiryDelta uint32 `json:"cltv_expiry_delta"`
+	// Amount we charge to use the channel.
+	FeeBaseMsat Msat `json:"fee_base_msat"`
+	// Amount we charge to use the channel in parts-per-million.
+	FeeProportionalMillionths uint32 `json:"fee_proportional_millionths"`
+}
+
+type ListPeerChannelsChannelsUpdatesRemote struct {
+	// Minimum msat amount they allow.
+	HtlcMinimumMsat Msat `json:"htlc_minimum_msat"`
+	// Maximum msat amount they allow.
+	HtlcMaximumMsat Msat `json:"htlc_maximum_msat"`
+	// Blocks delay required between incoming and outgoing HTLCs.
+	CltvExpiryDelta uint32 `json:"cltv_expiry_delta"`
+	// Amount they charge to use the channel.
+	FeeBaseMsat Msat `json:"fee_base_msat"`
+	// Amount they charge to use the channel in parts-per-million.
+	FeeProportionalMillionths uint32 `json:"fee_proportional_millionths"`
+}
+
+type ListPeerChannelsChannelsHtlcs struct {
+	// Whether it came from peer, or is going to peer.
+	Direction string `json:"direction"`
+	// Unique ID for this htlc on this channel in this direction.
+	ID uint64 `json:"id"`
+	// Amount send/received for this HTLC.
+	AmountMsat Msat `json:"amount_msat"`
+	// Block this HTLC expires at.
+	Expiry uint32 `json:"expiry"`
+	// The hash of the payment_preimage which will prove payment.
+	PaymentHash string `json:"payment_hash"`
+	// If this is too small to enforce onchain; it doesn't appear in the commitment transaction and will not be enforced in a unilateral close.
+	LocalTrimmed bool `json:"local_trimmed,omitempty"`
+	// Set if this HTLC is currently waiting on a hook (and shows what plugin).
+	Status string `json:"status,omitempty"`
+	// Status of the HTLC.
+	State string `json:"state"`
+}
+
+// ListPeers calls `listpeers`: Command returning data on connected lightning nodes.
+func (ln *Client) ListPeers(ctx context.Context, req ListPeersRequest) (resp ListPeersResponse, err error) {
+	err = ln.CallTyped(ctx, "listpeers", req, &resp)
+	return
+}
+
+// ListPeersRequest are the parameters for `listpeers`.
+type ListPeersRequest struct {
+	// If supplied, limits the result to just the peer with the given ID, if it exists.
+	ID *string `json:"id,omitempty"`
+	// Supplying level will show log entries related to that peer at the given log level.
+	Level *string `json:"level,omitempty"`
+}
+
+// ListPeersResponse is the result of `listpeers`.
+type ListPeersResponse struct {
+	Peers []ListPeersPeers `json:"peers"`
+}
+
+type ListPeersPeers struct {
+	// The unique id of the peer.
+	ID string `json:"id"`
+	// Value showing the connection status.
+	Connected bool `json:"connected"`
+	// The number of channels the peer has with this node.
+	NumChannels uint32 `json:"num_channels"`
+	// If *level* is specified, logs for this peer.
+	Log []ListPeersPeersLog `json:"log,omitempty"`
+	// A single entry array.
+	Netaddr []string `json:"netaddr,omitempty"`
+	// The public IPv4/6 address the peer sees us from, e.g. 1.2.3.4:1234.
+	RemoteAddr string `json:"remote_addr,omitempty"`
+	// Bitmap of BOLT #9 features from peer's INIT message.
+	Features string `json:"features,omitempty"`
+}
+
+type ListPeersPeersLog struct {
+	Type string `json:"type"`
+	// Number of deleted/omitted entries.
+	NumSkipped uint32 `json:"num_skipped,omitempty"`
+	// UNIX timestamp with 9 decimal places.
+	Time string `json:"time,omitempty"`
+	// The particular logbook this was found in.
+	Source string `json:"source,omitempty"`
+	// The actual log message.
+	Log string `json:"log,omitempty"`
+	// The peer this is associated with.
+	NodeID string `json:"node_id,omitempty"`
+	// The IO which occurred.
+	Data string `json:"data,omitempty"`
+}
+
+// ListSendPays calls `listsendpays`: Low-level command for querying sendpay status.
+func (ln *Client) ListSendPays(ctx context.Context, req ListSendPaysRequest) (resp ListSendPaysResponse, err error) {
+	err = ln.CallTyped(ctx, "listsendpays", req, &resp)
+	return
+}
+
+// ListSendPaysRequest are the parameters for `listsendpays`.
+type ListSendPaysRequest struct {
+	// Bolt11 invoice.
+	Bolt11 *string `json:"bolt11,omitempty"`
+	// The hash of the payment_preimage.
+	PaymentHash *string `json:"payment_hash,omitempty"`
+	// Whether the invoice has been paid, pending, or failed.
+	Status *string `json:"status,omitempty"`
+	// If neither bolt11 or payment_hash is specified, `index` controls ordering, by `created` (default) or `updated`.
+	Index *string `json:"index,omitempty"`
+	// If `index` is specified, `start` may be specified to start from that value, which is generally returned from lightning-wait(7).
+	Start *uint64 `json:"start,omitempty"`
+	// If `index` is specified, `limit` can be used to specify the maximum number of entries to return.
+	Limit *uint32 `json:"limit,omitempty"`
+}
+
+// ListSendPaysResponse is the result of `listsendpays`.
+type ListSendPaysResponse struct {
+	Payments []ListSendPaysPayments `json:"payments"`
+}
+
+type ListSendPaysPayments struct {
+	// 1-based index indicating order this payment was created in.
+	CreatedIndex uint64 `json:"created_index"`
+	// Old synonym for created_index.
+	ID uint64 `json:"id"`
+	// Grouping key to disambiguate multiple attempts to pay an invoice or the same payment_hash.
+	Groupid uint64 `json:"groupid"`
+	// Part number (for multiple parts to a single payment).
+	Partid uint64 `json:"partid,omitempty"`
+	// The hash of the *payment_preimage* which will prove payment.
+	PaymentHash string `json:"payment_hash"`
+	// 1-based index indicating order this payment was changed (only present if it has changed since creation).
+	UpdatedIndex uint64 `json:"updated_index,omitempty"`
+	// Status of the payment.
+	Status string `json:"status"`
+	// The amount delivered to destination (if known).
+	AmountMsat Msat `json:"amount_msat,omitempty"`
+	// The final destination of the payment if known.
+	Destination string `json:"destination,omitempty"`
+	// The UNIX timestamp showing when this payment was initiated.
+	CreatedAt uint64 `json:"created_at"`
+	// The UNIX timestamp showing when this payment was completed.
+	CompletedAt uint64 `json:"completed_at,omitempty"`
+	// The amount sent.
+	AmountSentMsat Msat `json:"amount_sent_msat"`
+	// The label, if given to sendpay.
+	Label string `json:"label,omitempty"`
+	// The bolt11 string (if pay supplied one).
+	Bolt11 string `json:"bolt11,omitempty"`
+	// The description matching the bolt11 description hash (if pay supplied one).
+	Description string `json:"description,omitempty"`
+	// The bolt12 string (if supplied for pay: **experimental-offers** only).
+	Bolt12 string `json:"bolt12,omitempty"`
+	// The proof of payment: SHA256 of this **payment_hash** (only if status is complete).
+	PaymentPreimage string `json:"payment_preimage,omitempty"`
+	// The onion message returned (only if status is failed).
+	Erroronion string `json:"erroronion,omitempty"`
+}
+
+// ListSqlSchemas calls `listsqlschemas`: Command to example lightning-sql schemas.
+func (ln *Client) ListSqlSchemas(ctx context.Context, req ListSqlSchemasRequest) (resp ListSqlSchemasResponse, err error) {
+	err = ln.CallTyped(ctx, "listsqlschemas", req, &resp)
+	return
+}
+
+// ListSqlSchemasRequest are the parameters for `listsqlschemas`.
+type ListSqlSchemasRequest struct {
+	// If specified, only the schema for that table is returned.
+	Table *string `json:"table,omitempty"`
+}
+
+// ListSqlSchemasResponse is the result of `listsqlschemas`.
+type ListSqlSchemasResponse struct {
+	Schemas []ListSqlSchemasSchemas `json:"schemas"`
+}
+
+type ListSqlSchemasSchemas struct {
+	// The name of the table.
+	Tablename string `json:"tablename"`
+	// The columns, in database order.
+	Columns []ListSqlSchemasSchemasColumns `json:"columns"`
+	// Any index we created to speed lookups.
+	Indices [][]string `json:"indices,omitempty"`
+}
+
+type ListSqlSchemasSchemasColumns struct {
+	// The name of the column.
+	Name string `json:"name"`
+	// The SQL type of the column.
+	Type string `json:"type"`
+}
+
+// ListTransactions calls `listtransactions`: Command to get the list of transactions that was stored in the wallet.
+func (ln *Client) ListTransactions(ctx context.Context) (resp ListTransactionsResponse, err error) {
+	err = ln.CallTyped(ctx, "listtransactions", nil, &resp)
+	return
+}
+
+// ListTransactionsResponse is the result of `listtransactions`.
+type ListTransactionsResponse struct {
+	Transactions []ListTransactionsTransactions `json:"transactions"`
+}
+
+type ListTransactionsTransactions struct {
+	// The transaction id.
+	Hash string `json:"hash"`
+	// The raw transaction.
+	Rawtx string `json:"rawtx"`
+	// The block height of this tx.
+	Blockheight uint32 `json:"blockheight"`
+	// The transaction number within the block.
+	Txindex uint32 `json:"txindex"`
+	// The nLocktime for this tx.
+	Locktime uint32 `json:"locktime"`
+	// The nVersion for this tx.
+	Version uint32 `json:"version"`
+	// Each input, in order.
+	Inputs []ListTransactionsTransactionsInputs `json:"inputs"`
+	// Each output, in order.
+	Outputs []ListTransactionsTransactionsOutputs `json:"outputs"`
+}
+
+type ListTransactionsTransactionsInputs struct {
+	// The transaction id spent.
+	Txid string `json:"txid"`
+	// The output spent.
+	Index uint32 `json:"index"`
+	// The nSequence value.
+	Sequence uint32 `json:"sequence"`
+}
+
+type ListTransactionsTransactionsOutputs struct {
+	// The 0-based output number.
+	Index uint32 `json:"index"`
+	// The amount of the output.
+	AmountMsat Msat `json:"amount_msat"`
+	// The scriptPubKey.
+	ScriptPubKey string `json:"scriptPubKey"`
+}
+
+// MakeSecret calls `makesecret`: Command for deriving pseudorandom key from HSM.
+func (ln *Client) MakeSecret(ctx context.Context, req MakeSecretRequest) (resp MakeSecretResponse, err error) {
+	err = ln.CallTyped(ctx, "makesecret", req, &resp)
+	return
+}
+
+// MakeSecretRequest are the parameters for `makesecret`.
+type MakeSecretRequest struct {
+	// One of `hex` or `string` must be specified: `hex` can be any hex data.
+	Hex *string `json:"hex,omitempty"`
+	// One of `hex` or `string` must be specified: `string` is a UTF-8 string interpreted literally.
+	String *string `json:"string,omitempty"`
+}
+
+// MakeSecretResponse is the result of `makesecret`.
+type MakeSecretResponse struct {
+	// The pseudorandom key derived from HSM_secret.
+	Secret string `json:"secret"`
+}
+
+// MultiFundChannel calls `multifundchannel`: Command for establishing many lightning channels.
+func (ln *Client) MultiFundChannel(ctx context.Context, req MultiFundChannelRequest) (resp MultiFundChannelResponse, err error) {
+	err = ln.CallTyped(ctx, "multifundchannel", req, &resp)
+	return
+}
+
+// MultiFundChannelRequest are the parameters for `multifundchannel`.
+type MultiFundChannelRequest struct {
+	// There must be at least one entry in *destinations*; it cannot be an empty array.
+	Destinations []MultiFundChannelDestinations `json:"destinations"`
+	// Feerate used for the opening transaction, and if *commitment_feerate* is not set, as initial feerate for commitment and HTLC transactions.
+	Feerate *string `json:"feerate,omitempty"`
+	// Minimum number of confirmations that used outputs should have.
+	Minconf *int64 `json:"minconf,omitempty"`
+	// The utxos to be used to fund the channel, as an array of `txid:vout`.
+	Utxos []string `json:"utxos,omitempty"`
+	// Re-attempt funding as long as at least this many peers remain (must not be zero). The **multifundchannel** command will only fail if too many peers fail the funding process.
+	Minchannels *int64 `json:"minchannels,omitempty"`
+	// Initial feerate for commitment and HTLC transactions. See *feerate* for valid values.
+	CommitmentFeerate *string `json:"commitment_feerate,omitempty"`
+}
+
+type MultiFundChannelDestinations struct {
+	// Node ID, with an optional *@host:port* specification.
+	ID string `json:"id"`
+	// Amount in satoshis taken from the internal wallet to fund the channel.
+	Amount string `json:"amount"`
+	// Flag that indicates whether to announce the channel with this.
+	Announce *bool `json:"announce,omitempty"`
+	// Amount of millisatoshis to outright give to the node.
+	PushMsat *Msat `json:"push_msat,omitempty"`
+	// Bitcoin address to which the channel funds should be sent to on close.
+	CloseTo *string `json:"close_to,omitempty"`
+	// Amount of liquidity you'd like to lease from peer.
+	RequestAmt *uint64 `json:"request_amt,omitempty"`
+	// Compact representation of the peer's expected channel lease terms.
+	CompactLease *string `json:"compact_lease,omitempty"`
+	// Number of confirmations before we consider the channel active.
+	Mindepth *uint32 `json:"mindepth,omitempty"`
+	// Amount we want the peer to maintain on its side.
+	Reserve *uint64 `json:"reserve,omitempty"`
+}
+
+// MultiFundChannelResponse is the result of `multifundchannel`.
+type MultiFundChannelResponse struct {
+	// The raw transaction which funded the channel.
+	Tx string `json:"tx"`
+	// The txid of the transaction which funded the channel.
+	Txid       string                       `json:"txid"`
+	ChannelIds []MultiFundChannelChannelIds `json:"channel_ids"`
+	// Any peers we failed to open with (if *minchannels* was specified less than the number of destinations).
+	Failed []MultiFundChannelFailed `json:"failed,omitempty"`
+}
+
+type MultiFundChannelChannelIds struct {
+	// The peer we opened the channel with.
+	ID string `json:"id"`
+	// The 0-based output index showing which output funded the channel.
+	Outnum uint32 `json:"outnum"`
+	// The channel_id of the resulting channel.
+	ChannelID string `json:"channel_id"`
+	// Channel_type as negotiated with peer.
+	ChannelType MultiFundChannelChannelIdsChannelType `json:"channel_type"`
+	// The raw scriptPubkey which mutual close will go to; only present if *close_to* parameter was specified and peer supports `option_upfront_shutdown_script`.
+	CloseTo string `json:"close_to,omitempty"`
+}
+
+type MultiFundChannelChannelIdsChannelType struct {
+	// Each bit set in this channel_type.
+	Bits []uint32 `json:"bits"`
+	// Feature name for each bit set in this channel_type.
+	Names []string `json:"names"`
+}
+
+type MultiFundChannelFailed struct {
+	// The peer we failed to open the channel with.
+	ID string `json:"id"`
+	// What stage we failed at.
+	Method string                      `json:"method"`
+	Error  MultiFundChannelFailedError `json:"error"`
+}
+
+type MultiFundChannelFailedError struct {
+	// JSON error code from failing stage.
+	Code int64 `json:"code"`
+	// Message from stage.
+	Message string `json:"message"`
+	// Additional error data.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// MultiWithdraw calls `multiwithdraw`: Command for withdrawing to multiple addresses.
+func (ln *Client) MultiWithdraw(ctx context.Context, req MultiWithdrawRequest) (resp MultiWithdrawResponse, err error) {
+	err = ln.CallTyped(ctx, "multiwithdraw", req, &resp)
+	return
+}
+
+// MultiWithdrawRequest are the parameters for `multiwithdraw`.
+type MultiWithdrawRequest struct {
+	// An array containing objects of the form `{address: amount}`. The `amount` may be the string *all*, indicating that all onchain funds be sent to the specified address.
+	Outputs []json.RawMessage `json:"outputs"`
+	// Feerate used for the withdrawals. See NOTES in lightning-feerates(7) for possible values.
+	Feerate *string `json:"feerate,omitempty"`
+	// Minimum number of confirmations that used outputs should have.
+	Minconf *uint32  `json:"minconf,omitempty"`
+	Utxos   []string `json:"utxos,omitempty"`
+}
+
+// MultiWithdrawResponse is the result of `multiwithdraw`.
+type MultiWithdrawResponse struct {
+	// The raw transaction which was sent.
+	Tx string `json:"tx"`
+	// The txid of the **tx**.
+	Txid string `json:"txid"`
+}
+
+// NewAddr calls `newaddr`: Command for generating a new address to be used by Core Lightning.
+func (ln *Client) NewAddr(ctx context.Context, req NewAddrRequest) (resp NewAddrResponse, err error) {
+	err = ln.CallTyped(ctx, "newaddr", req, &resp)
+	return
+}
+
+// NewAddrRequest are the parameters for `newaddr`.
+type NewAddrRequest struct {
+	// It specifies the type of address wanted; currently *bech32* (e.g. `tb1qu9j4lg5f9rgjyfhvfd905vw46eg39czmktxqgg` on bitcoin testnet or `bc1qwqdg6squsna38e46795at95yu9atm8azzmyvckulcc7kytlcckxswvvzej` on bitcoin mainnet), or *p2tr* taproot addresses. The special value *all* generates all known address types for the same underlying key.
+	Addresstype *string `json:"addresstype,omitempty"`
+}
+
+// NewAddrResponse is the result of `newaddr`.
+type NewAddrResponse struct {
+	// The taproot address.
+	P2tr string `json:"p2tr,omitempty"`
+	// The bech32 (native segwit) address.
+	Bech32 string `json:"bech32,omitempty"`
+}
+
+// Notifications calls `notifications`: Command to set up notifications.
+func (ln *Client) Notifications(ctx context.Context, req NotificationsRequest) (resp NotificationsResponse, err error) {
+	err = ln.CallTyped(ctx, "notifications", req, &resp)
+	return
+}
+
+// NotificationsRequest are the parameters for `notifications`.
+type NotificationsRequest struct {
+	// Whether to enable or disable notifications.
+	Enable bool `json:"enable"`
+}
+
+// NotificationsResponse is the result of `notifications`.
+type NotificationsResponse struct {
+}
+
+// Offer calls `offer`: Command for accepting payments.
+func (ln *Client) Offer(ctx context.Context, req OfferRequest) (resp OfferResponse, err error) {
+	err = ln.CallTyped(ctx, "offer", req, &resp)
+	return
+}
+
+// OfferRequest are the parameters for `offer`.
+type OfferRequest struct {
+	// Can be the string `any`, which creates an offer that can be paid with any amount (e.g. a donation). Otherwise it can be a positive value in millisatoshi precision; it can be a whole number, or a whole number ending in *msat* or *sat*, or a number with three decimal places ending in *sat*, or a number with 1 to 11 decimal places ending in *btc*.
+	Amount Msat `json:"amount"`
+	// A short description of purpose of the offer, e.g. *coffee*. This value is encoded into the resulting offer and is viewable by anyone you expose this offer to. It must be UTF-8, and cannot use *\u* JSON escape codes.
+	Description *string `json:"description,omitempty"`
+	// Who is issuing this offer (i.e. you) if appropriate.
+	Issuer *string `json:"issuer,omitempty"`
+	// An internal-use name for the offer, which can be any UTF-8 string. This is *NOT* encoded in the offer not sent to the issuer.
+	Label *string `json:"label,omitempty"`
+	// Invoice can specify more than one of the items up (and including) this maximum: 0 is a special value meaning `no maximuim`. The *amount* for the invoice will need to be multiplied accordingly. This is encoded in the offer.
+	QuantityMax *uint64 `json:"quantity_max,omitempty"`
+	// Time the offer is valid until, in seconds since the first day of 1970 UTC. If not set, the offer remains valid (though it can be deactivated by the issuer of course). This is encoded in the offer.
+	AbsoluteExpiry *uint64 `json:"absolute_expiry,omitempty"`
+	// An invoice is expected at regular intervals. The argument is a positive number followed by one of `seconds`, `minutes`, `hours`, `days`, `weeks`, `months` or `years` (variants without the trailing `s` are also permitted). This is encoded in the offer.
+	Recurrence *string `json:"recurrence,omitempty"`
+	// Time in seconds since the first day of 1970 UTC. This indicates when the first period begins; without this, the recurrence periods start from the first invoice.
+	RecurrenceBase *string `json:"recurrence_base,omitempty"`
+	// Argument of form `-time+time[%]`. The first time is the number of seconds before the start of a period in which an invoice and payment is valid, the second time is the number of seconds after the start of the period.
+	RecurrencePaywindow *string `json:"recurrence_paywindow,omitempty"`
+	// To indicate the maximum period which exists. eg. `12` means there are 13 periods, from 0 to 12 inclusive. This is encoded in the offer.
+	RecurrenceLimit *uint32 `json:"recurrence_limit,omitempty"`
+	// Indicates that the offer is only valid once; we may issue multiple invoices, but as soon as one is paid all other invoices will be expired (i.e. only one person can pay this offer).
+	SingleUse *bool `json:"single_use,omitempty"`
+}
+
+// OfferResponse is the result of `offer`.
+type OfferResponse struct {
+	// The id of this offer (merkle hash of non-signature fields).
+	OfferID string `json:"offer_id"`
+	// Whether this can still be used.
+	Active bool `json:"active"`
+	// Whether this expires as soon as it's paid (reflects the *single_use* parameter).
+	SingleUse bool `json:"single_use"`
+	// The bolt12 encoding of the offer.
+	Bolt12 string `json:"bolt12"`
+	// True if an associated invoice has been paid.
+	Used bool `json:"used"`
+	// False if the offer already existed.
+	Created bool `json:"created"`
+	// The (optional) user-specified label.
+	Label string `json:"label,omitempty"`
+}
+
+// OpenChannelAbort calls `openchannel_abort`: Command to abort a channel to a peer.
+func (ln *Client) OpenChannelAbort(ctx context.Context, req OpenChannelAbortRequest) (resp OpenChannelAbortResponse, err error) {
+	err = ln.CallTyped(ctx, "openchannel_abort", req, &resp)
+	return
+}
+
+// OpenChannelAbortRequest are the parameters for `openchannel_abort`.
+type OpenChannelAbortRequest struct {
+	// Channel id of the channel to be aborted.
+	ChannelID string `json:"channel_id"`
+}
+
+// OpenChannelAbortResponse is the result of `openchannel_abort`.
+type OpenChannelAbortResponse struct {
+	// The channel id of the aborted channel.
+	ChannelID string `json:"channel_id"`
+	// Whether this is completely canceled (there may be remaining in-flight transactions).
+	ChannelCanceled bool `json:"channel_canceled"`
+	// Usually "Abort requested", but if it happened to fail at the same time it could be different.
+	Reason string `json:"reason"`
+}
+
+// OpenChannelBump calls `openchannel_bump`: Command to initiate a channel RBF.
+func (ln *Client) OpenChannelBump(ctx context.Context, req OpenChannelBumpRequest) (resp OpenChannelBumpResponse, err error) {
+	err = ln.CallTyped(ctx, "openchannel_bump", req, &resp)
+	return
+}
+
+// OpenChannelBumpRequest are the parameters for `openchannel_bump`.
+type OpenChannelBumpRequest struct {
+	// Id of the channel to RBF.
+	ChannelID string `json:"channel_id"`
+	// Satoshi value that we will contribute to the channel. This value will be _added_ to the provided PSBT in the output which is encumbered by the 2-of-2 script for this channel.
+	Amount uint64 `json:"amount"`
+	// The funded, incomplete PSBT that specifies the UTXOs and change output for our channel contribution. It can be updated, see `openchannel_update`; *initialpsbt* must have at least one input.
+	Initialpsbt string `json:"initialpsbt"`
+	// Feerate for the funding transaction.
+	FundingFeerate *string `json:"funding_feerate,omitempty"`
+}
+
+// OpenChannelBumpResponse is the result of `openchannel_bump`.
+type OpenChannelBumpResponse struct {
+	// The channel id of the channel.
+	ChannelID string `json:"channel_id"`
+	// Channel_type as negotiated with peer.
+	ChannelType OpenChannelBumpChannelType `json:"channel_type"`
+	// The (incomplete) PSBT of the RBF transaction.
+	Psbt string `json:"psbt"`
+	// Whether the *psbt* is complete.
+	CommitmentsSecured bool `json:"commitments_secured"`
+	// The serial_id of the funding output in the *psbt*.
+	FundingSerial uint64 `json:"funding_serial"`
+	// Does peer require confirmed inputs in psbt?
+	RequiresConfirmedInputs bool `json:"requires_confirmed_inputs,omitempty"`
+}
+
+type OpenChannelBumpChannelType struct {
+	// Each bit set in this channel_type.
+	Bits []uint32 `json:"bits"`
+	// Feature name for each bit set in this channel_type.
+	Names []string `json:"names"`
+}
+
+// OpenChannelInit calls `openchannel_init`: Command to initiate a channel to a peer.
+func (ln *Client) OpenChannelInit(ctx context.Context, req OpenChannelInitRequest) (resp OpenChannelInitResponse, err error) {
+	err = ln.CallTyped(ctx, "openchannel_init", req, &resp)
+	return
+}
+
+// OpenChannelInitRequest are the parameters for `openchannel_init`.
+type OpenChannelInitRequest struct {
+	// Node id of the remote peer.
+	ID string `json:"id"`
+	// Satoshi value that we will contribute to the channel. This value will be _added_ to the provided PSBT in the output which is encumbered by the 2-of-2 script for this channel.
+	Amount uint64 `json:"amount"`
+	// Funded, incomplete PSBT that specifies the UTXOs and change output for our channel contribution. It can be updated, see `openchannel_update`; *initialpsbt* must have at least one input.
+	Initialpsbt string `json:"initialpsbt"`
+	// Feerate for commitment transactions for non-anchor channels: see **fundchannel**. For anchor channels, it is ignored.
+	CommitmentFeerate *string `json:"commitment_feerate,omitempty"`
+	// Feerate for the funding transaction.
+	FundingFeerate *string `json:"funding_feerate,omitempty"`
+	// Whether or not to announce this channel.
+	Announce *bool `json:"announce,omitempty"`
+	// Bitcoin address to which the channel funds should be sent on close. Only valid if both peers have negotiated `option_upfront_shutdown_script`.
+	CloseTo *string `json:"close_to,omitempty"`
+	// An amount of liquidity you'd like to lease from the peer. If peer supports `option_will_fund`, indicates to them to include this much liquidity into the channel. Must also pass in *compact_lease*.
+	RequestAmt *uint64 `json:"request_amt,omitempty"`
+	// A compact represenation of the peer's expected channel lease terms. If the peer's terms don't match this set, we will fail to open the channel to this destination.
+	CompactLease *string `json:"compact_lease,omitempty"`
+	// Each bit set in this channel_type.
+	ChannelType []uint32 `json:"channel_type,omitempty"`
+}
+
+// OpenChannelInitResponse is the result of `openchannel_init`.
+type OpenChannelInitResponse struct {
+	// The channel id of the channel.
+	ChannelID string `json:"channel_id"`
+	// The (incomplete) PSBT of the funding transaction.
+	Psbt string `json:"psbt"`
+	// Channel_type as negotiated with peer.
+	ChannelType OpenChannelInitChannelType `json:"channel_type"`
+	// Whether the *psbt* is complete.
+	CommitmentsSecured bool `json:"commitments_secured"`
+	// The serial_id of the funding output in the *psbt*.
+	FundingSerial uint64 `json:"funding_serial"`
+	// Does peer require confirmed inputs in psbt?
+	RequiresConfirmedInputs bool `json:"requires_confirmed_inputs,omitempty"`
+}
+
+type OpenChannelInitChannelType struct {
+	// Each bit set in this channel_type.
+	Bits []uint32 `json:"bits"`
+	// Feature name for each bit set in this channel_type.
+	Names []string `json:"names"`
+}
+
+// OpenChannelSigned calls `openchannel_signed`: Command to conclude a channel open.
+func (ln *Client) OpenChannelSigned(ctx context.Context, req OpenChannelSignedRequest) (resp OpenChannelSignedResponse, err error) {
+	err = ln.CallTyped(ctx, "openchannel_signed", req, &resp)
+	return
+}
+
+// OpenChannelSignedRequest are the parameters for `openchannel_signed`.
+type OpenChannelSignedRequest struct {
+	// Id of the channel.
+	ChannelID string `json:"channel_id"`
+	// The PSBT returned from step 2 with all of our inputs signed and the remote peer's signatures.
+	SignedPsbt string `json:"signed_psbt"`
+}
+
+// OpenChannelSignedResponse is the result of `openchannel_signed`.
+type OpenChannelSignedResponse struct {
+	// The channel id of the channel.
+	ChannelID string `json:"channel_id"`
+	// The funding transaction.
+	Tx string `json:"tx"`
+	// The txid of the **tx**.
+	Txid string `json:"txid"`
+}
+
+// OpenChannelUpdate calls `openchannel_update`: Command to update a collab channel open.
+func (ln *Client) OpenChannelUpdate(ctx context.Context, req OpenChannelUpdateRequest) (resp OpenChannelUpdateResponse, err error) {
+	err = ln.CallTyped(ctx, "openchannel_update", req, &resp)
+	return
+}
+
+// OpenChannelUpdateRequest are the parameters for `openchannel_update`.
+type OpenChannelUpdateRequest struct {
+	// Id of the channel.
+	ChannelID string `json:"channel_id"`
+	// Updated PSBT to be sent to the peer. May be identical to the PSBT last returned by either `openchannel_init` or `openchannel_update`.
+	Psbt string `json:"psbt"`
+}
+
+// OpenChannelUpdateResponse is the result of `openchannel_update`.
+type OpenChannelUpdateResponse struct {
+	// The channel id of the channel.
+	ChannelID string `json:"channel_id"`
+	// Channel_type as negotiated with peer.
+	ChannelType OpenChannelUpdateChannelType `json:"channel_type"`
+	// The PSBT of the funding transaction.
+	Psbt string `json:"psbt"`
+	// Whether the *psbt* is complete (if true, sign *psbt* and call `openchannel_signed` to complete the channel open).
+	CommitmentsSecured bool `json:"commitments_secured"`
+	// The index of the funding output in the psbt.
+	FundingOutnum uint32 `json:"funding_outnum"`
+	// Scriptpubkey which we have to close to if we mutual close.
+	CloseTo string `json:"close_to,omitempty"`
+	// Does peer require confirmed inputs in psbt?
+	RequiresConfirmedInputs bool `json:"requires_confirmed_inputs,omitempty"`
+}
+
+type OpenChannelUpdateChannelType struct {
+	// Each bit set in this channel_type.
+	Bits []uint32 `json:"bits"`
+	// Feature name for each bit set in this channel_type.
+	Names []string `json:"names"`
+}
+
+// ParseFeeRate calls `parsefeerate`: Command for parsing a feerate string to a feerate.
+func (ln *Client) ParseFeeRate(ctx context.Context, req ParseFeeRateRequest) (resp ParseFeeRateResponse, err error) {
+	err = ln.CallTyped(ctx, "parsefeerate", req, &resp)
+	return
+}
+
+// ParseFeeRateRequest are the parameters for `parsefeerate`.
+type ParseFeeRateRequest struct {
+	// The feerate string to parse.
+	Feerate string `json:"feerate"`
+}
+
+// ParseFeeRateResponse is the result of `parsefeerate`.
+type ParseFeeRateResponse struct {
+	// Value of *feerate_str* in kilosipa.
+	Perkw uint32 `json:"perkw,omitempty"`
+}
+
+// Pay calls `pay`: Command for sending a payment to a BOLT11 invoice.
+func (ln *Client) Pay(ctx context.Context, req PayRequest) (resp PayResponse, err error) {
+	err = ln.CallTyped(ctx, "pay", req, &resp)
+	return
+}
+
+// PayRequest are the parameters for `pay`.
+type PayRequest struct {
+	// Bolt11 invoice.
+	Bolt11 string `json:"bolt11"`
+	// The amount to pay, only required if the *bolt11* does not contain an amount.
+	AmountMsat *Msat `json:"amount_msat,omitempty"`
+	// The label field is used to attach a label to payments, and is returned in lightning-listpays(7) and lightning-listsendpays(7).
+	Label *string `json:"label,omitempty"`
+	// The riskfactor is described in detail in lightning-getroute(7).
+	Riskfactor *float64 `json:"riskfactor,omitempty"`
+	// Limits the money paid in fees as percentage of the total amount that is to be transferred.
+	Maxfeepercent *float64 `json:"maxfeepercent,omitempty"`
+	// Until *retry_for* seconds passes, the command will keep finding routes and retrying the payment.
+	RetryFor *uint16 `json:"retry_for,omitempty"`
+	// A payment may be delayed for up to `maxdelay` blocks by another node; clients should be prepared for this worst case.
+	Maxdelay *uint16 `json:"maxdelay,omitempty"`
+	// This option can be used for tiny payments which would be dominated by the fee leveraged by forwarding nodes.
+	Exemptfee *Msat `json:"exemptfee,omitempty"`
+	// This is used by lightning-fetchinvoice(7) to ensure that payments for local invoice requests are only made once.
+	Localinvreqid *string `json:"localinvreqid,omitempty"`
+	// A set of node-ids or short-channel-ids (with directions) which the payment algorithm should avoid.
+	Exclude []string `json:"exclude,omitempty"`
+	// *maxfee* overrides both *maxfeepercent* and *exemptfee* defaults (and if you specify *maxfee* you cannot specify either of those), and creates an absolute limit on what fee we will pay.
+	Maxfee *Msat `json:"maxfee,omitempty"`
+	// Only required for bolt11 invoices which do not contain a description themselves, but contain a description hash.
+	Description *string `json:"description,omitempty"`
+	// Explicitly state that you are only paying some part of the invoice.
+	PartialMsat *Msat `json:"partial_msat,omitempty"`
+}
+
+// PayResponse is the result of `pay`.
+type PayResponse struct {
+	// The proof of payment: SHA256 of this **payment_hash**.
+	PaymentPreimage string `json:"payment_preimage"`
+	// The final destination of the payment.
+	Destination string `json:"destination,omitempty"`
+	// The hash of the *payment_preimage* which will prove payment.
+	PaymentHash string `json:"payment_hash"`
+	// The UNIX timestamp showing when this payment was initiated.
+	CreatedAt float64 `json:"created_at"`
+	// How many attempts this took.
+	Parts uint32 `json:"parts"`
+	// Amount the recipient received.
+	AmountMsat Msat `json:"amount_msat"`
+	// Total amount we sent (including fees).
+	AmountSentMsat Msat `json:"amount_sent_msat"`
+	// Not all parts of a multi-part payment have completed.
+	WarningPartialCompletion string `json:"warning_partial_completion,omitempty"`
+	// Status of payment.
+	Status string `json:"status"`
+}
+
+// Ping calls `ping`: Command to check if a node is up.
+func (ln *Client) Ping(ctx context.Context, req PingRequest) (resp PingResponse, err error) {
+	err = ln.CallTyped(ctx, "ping", req, &resp)
+	return
+}
+
+// PingRequest are the parameters for `ping`.
+type PingRequest struct {
+	// The pubkey of the node to ping.
+	ID string `json:"id"`
+	// The length of the ping.
+	Len *uint16 `json:"len,omitempty"`
+	// The length of the reply. A value of 65532 to 65535 means `don't reply`.
+	Pongbytes *uint16 `json:"pongbytes,omitempty"`
+}
+
+// PingResponse is the result of `ping`.
+type PingResponse struct {
+	// The answer length of the reply message (including header: 0 means no reply expected).
+	Totlen uint16 `json:"totlen"`
+}
+
+// Plugin calls `plugin`: Manage plugins with RPC.
+func (ln *Client) Plugin(ctx context.Context, req PluginRequest) (resp PluginResponse, err error) {
+	err = ln.CallTyped(ctx, "plugin", req, &resp)
+	return
+}
+
+// PluginRequest are the parameters for `plugin`.
+type PluginRequest struct {
+	// Determines what action is taken.
+	Subcommand string `json:"subcommand"`
+	// *name* parameter for the *start* or *stop* subcommands.
+	Plugin *string `json:"plugin,omitempty"`
+	// *directory* parameter for the *startdir* subcommand.
+	Directory *string  `json:"directory,omitempty"`
+	Options   []string `json:"options,omitempty"`
+}
+
+// PluginResponse is the result of `plugin`.
+type PluginResponse struct {
+	// The subcommand this is responding to.
+	Command string          `json:"command"`
+	Plugins []PluginPlugins `json:"plugins,omitempty"`
+	// A message saying it successfully stopped (*stop* subcommand only).
+	Result string `json:"result,omitempty"`
+}
+
+type PluginPlugins struct {
+	// Full pathname of the plugin.
+	Name string `json:"name"`
+	// Status; plugin completed init and is operational, plugins are configured asynchronously.
+	Active bool `json:"active"`
+	// Plugin can be stopped or started without restarting lightningd.
+	Dynamic bool `json:"dynamic"`
+}
+
+// PreapproveInvoice calls `preapproveinvoice`: Ask the HSM to preapprove an invoice (low-level).
+func (ln *Client) PreapproveInvoice(ctx context.Context, req PreapproveInvoiceRequest) (resp PreapproveInvoiceResponse, err error) {
+	err = ln.CallTyped(ctx, "preapproveinvoice", req, &resp)
+	return
+}
+
+// PreapproveInvoiceRequest are the parameters for `preapproveinvoice`.
+type PreapproveInvoiceRequest struct {
+	// Bolt11 invoice to ask the HSM to preapprove.
+	Bolt11 string `json:"bolt11"`
+}
+
+// PreapproveInvoiceResponse is the result of `preapproveinvoice`.
+type PreapproveInvoiceResponse struct {
+}
+
+// PreapproveKeysend calls `preapprovekeysend`: Ask the HSM to preapprove a keysend payment (low-level).
+func (ln *Client) PreapproveKeysend(ctx context.Context, req PreapproveKeysendRequest) (resp PreapproveKeysendResponse, err error) {
+	err = ln.CallTyped(ctx, "preapprovekeysend", req, &resp)
+	return
+}
+
+// PreapproveKeysendRequest are the parameters for `preapprovekeysend`.
+type PreapproveKeysendRequest struct {
+	// It is a 33 byte, hex-encoded, node ID of the node that the payment should go to.
+	Destination string `json:"destination"`
+	// It is the unique identifier of a payment.
+	PaymentHash string `json:"payment_hash"`
+	// The amount to send in millisatoshi precision; it can be a whole number, or a whole number with suffix `msat` or `sat`, or a three decimal point number with suffix `sat`, or an 1 to 11 decimal point number suffixed by `btc`.
+	AmountMsat Msat `json:"amount_msat"`
+}
+
+// PreapproveKeysendResponse is the result of `preapprovekeysend`.
+type PreapproveKeysendResponse struct {
+}
+
+// Recover calls `recover`: Reinitialize Your Node for Recovery.
+func (ln *Client) Recover(ctx context.Context, req RecoverRequest) (resp RecoverResponse, err error) {
+	err = ln.CallTyped(ctx, "recover", req, &resp)
+	return
+}
+
+// RecoverRequest are the parameters for `recover`.
+type RecoverRequest struct {
+	// Either a codex32 secret starting with `cl1` as returned by `hsmtool getcodexsecret`, or a raw 64 character hex string.
+	Hsmsecret string `json:"hsmsecret"`
+}
+
+// RecoverResponse is the result of `recover`.
+type RecoverResponse struct {
+	Result string `json:"result,omitempty"`
+}
+
+// RecoverChannel calls `recoverchannel`: Command for recovering channels bundeled in an array in the form of *Static Backup*.
+func (ln *Client) RecoverChannel(ctx context.Context, req RecoverChannelRequest) (resp RecoverChannelResponse, err error) {
+	err = ln.CallTyped(ctx, "recoverchannel", req, &resp)
+	return
+}
+
+// RecoverChannelRequest are the parameters for `recoverchannel`.
+type RecoverChannelRequest struct {
+	// Array of *scb* consisting of the serialized static channel backups.
+	Scb []string `json:"scb"`
+}
+
+// RecoverChannelResponse is the result of `recoverchannel`.
+type RecoverChannelResponse struct {
+	Stubs []string `json:"stubs"`
+}
+
+// RenePay calls `renepay`: Command for sending a payment to a BOLT11 invoice.
+func (ln *Client) RenePay(ctx context.Context, req RenePayRequest) (resp RenePayResponse, err error) {
+	err = ln.CallTyped(ctx, "renepay", req, &resp)
+	return
+}
+
+// RenePayRequest are the parameters for `renepay`.
+type RenePayRequest struct {
+	// Bolt11 invoice which the RPC command will pay.
+	Invstring string `json:"invstring"`
+	// If the invoice does not have an amount, this is required. If it is specified and the invoice has an amount, it must match.
+	AmountMsat *Msat `json:"amount_msat,omitempty"`
+	// Maximum fee that the payment may cost.
+	Maxfee *Msat `json:"maxfee,omitempty"`
+	// A payment may be delayed for up to `maxdelay` blocks by another node; clients should be prepared for this worst case.
+	Maxdelay *uint32 `json:"maxdelay,omitempty"`
+	// Measured in seconds specifies how much time it is allowed for the command to keep retrying the payment.
+	RetryFor *uint32 `json:"retry_for,omitempty"`
+	// Only required for bolt11 invoices which do not contain a description themselves, but contain a description hash: in this case *description* is required.
+	Description *string `json:"description,omitempty"`
+	// Used to attach a label to payments, and is returned in lightning-listpays(7) and lightning-listsendpays(7).
+	Label *string `json:"label,omitempty"`
+	// Add a shadow route to the payment.
+	DevUseShadow *bool `json:"dev_use_shadow,omitempty"`
+	// A list of nodes or channels to exclude from routing.
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// RenePayResponse is the result of `renepay`.
+type RenePayResponse struct {
+	// The proof of payment: SHA256 of this **payment_hash**.
+	PaymentPreimage string `json:"payment_preimage"`
+	// The hash of the *payment_preimage* which will prove payment.
+	PaymentHash string `json:"payment_hash"`
+	// The UNIX timestamp showing when this payment was initiated.
+	CreatedAt float64 `json:"created_at"`
+	// How many attempts this took.
+	Parts uint32 `json:"parts"`
+	// Amount the recipient received.
+	AmountMsat Msat `json:"amount_msat"`
+	// Total amount we sent (including fees).
+	AmountSentMsat Msat `json:"amount_sent_msat"`
+	// Status of payment.
+	Status string `json:"status"`
+	// The final destination of the payment.
+	Destination string `json:"destination,omitempty"`
+	// The bolt11 invoice paid.
+	Bolt11 string `json:"bolt11,omitempty"`
+	// The bolt12 invoice paid.
+	Bolt12 string `json:"bolt12,omitempty"`
+	// The id of this payment group.
+	Groupid uint64 `json:"groupid,omitempty"`
+}
+
+// RenePayStatus calls `renepaystatus`: Command for quering the status of previous renepay attempts.
+func (ln *Client) RenePayStatus(ctx context.Context, req RenePayStatusRequest) (resp RenePayStatusResponse, err error) {
+	err = ln.CallTyped(ctx, "renepaystatus", req, &resp)
+	return
+}
+
+// RenePayStatusRequest are the parameters for `renepaystatus`.
+type RenePayStatusRequest struct {
+	// Bolt11 invoice which the RPC command will pay.
+	Invstring *string `json:"invstring,omitempty"`
+}
+
+// RenePayStatusResponse is the result of `renepaystatus`.
+type RenePayStatusResponse struct {
+	Paystatus []RenePayStatusPaystatus `json:"paystatus"`
+}
+
+type RenePayStatusPaystatus struct {
+	// Invoice string BOLT11.
+	Bolt11 string `json:"bolt11"`
+	// The proof of payment: SHA256 of this **payment_hash** (**status** is *complete* only).
+	PaymentPreimage string `json:"payment_preimage,omitempty"`
+	// The hash of the *payment_preimage* which will prove payment.
+	PaymentHash string `json:"payment_hash"`
+	// The UNIX timestamp showing when this payment was initiated.
+	CreatedAt float64 `json:"created_at"`
+	// The id for this payment attempt.
+	Groupid uint32 `json:"groupid"`
+	// How many attempts this took.
+	Parts uint32 `json:"parts,omitempty"`
+	// Amount the recipient received.
+	AmountMsat Msat `json:"amount_msat"`
+	// Total amount we sent including fees (**status** is *complete* only).
+	AmountSentMsat Msat `json:"amount_sent_msat,omitempty"`
+	// Status of payment.
+	Status string `json:"status"`
+	// The final destination of the payment if known.
+	Destination string `json:"destination,omitempty"`
+	// A list of messages for debugging purposes.
+	Notes []string `json:"notes"`
+}
+
+// ReserveInputs calls `reserveinputs`: Construct a transaction and reserve the UTXOs it spends.
+func (ln *Client) ReserveInputs(ctx context.Context, req ReserveInputsRequest) (resp ReserveInputsResponse, err error) {
+	err = ln.CallTyped(ctx, "reserveinputs", req, &resp)
+	return
+}
+
+// ReserveInputsRequest are the parameters for `reserveinputs`.
+type ReserveInputsRequest struct {
+	// The PSBT to reserve inputs from.
+	Psbt string `json:"psbt"`
+	// If true, then if any inputs are already reserved, fail.
+	Exclusive *bool `json:"exclusive,omitempty"`
+	// The number of blocks to reserve (or increase reservation) for.
+	Reserve *uint32 `json:"reserve,omitempty"`
+}
+
+// ReserveInputsResponse is the result of `reserveinputs`.
+type ReserveInputsResponse struct {
+	Reservations []ReserveInputsReservations `json:"reservations"`
+}
+
+type ReserveInputsReservations struct {
+	// The input transaction id.
+	Txid string `json:"txid"`
+	// The input index output number which was reserved.
+	Vout uint32 `json:"vout"`
+	// Whether the input was already reserved.
+	WasReserved bool `json:"was_reserved"`
+	// Whether the input is now reserved.
+	Reserved bool `json:"reserved"`
+	// What blockheight the reservation will expire.
+	ReservedToBlock uint32 `json:"reserved_to_block"`
+}
+
+// SendCustomMsg calls `sendcustommsg`: Low-level interface to send protocol messages to peers.
+func (ln *Client) SendCustomMsg(ctx context.Context, req SendCustomMsgRequest) (resp SendCustomMsgResponse, err error) {
+	err = ln.CallTyped(ctx, "sendcustommsg", req, &resp)
+	return
+}
+
+// SendCustomMsgRequest are the parameters for `sendcustommsg`.
+type SendCustomMsgRequest struct {
+	// The node specified by `node_id` must be a peer, i.e., it must have a direct connection with the node receiving the RPC call, and the connection must be established.
+	NodeID string `json:"node_id"`
+	// Must be a hex encoded well-formed message, including the 2-byte type prefix, but excluding the length prefix which will be added by the RPC method.
+	Msg string `json:"msg"`
+}
+
+// SendCustomMsgResponse is the result of `sendcustommsg`.
+type SendCustomMsgResponse struct {
+	// Information about where message was queued.
+	Status string `json:"status"`
+}
+
+// SendInvoice calls `sendinvoice`: Command for send an invoice for an offer.
+func (ln *Client) SendInvoice(ctx context.Context, req SendInvoiceRequest) (resp SendInvoiceResponse, err error) {
+	err = ln.CallTyped(ctx, "sendinvoice", req, &resp)
+	return
+}
+
+// SendInvoiceRequest are the parameters for `sendinvoice`.
+type SendInvoiceRequest struct {
+	// Bolt12 invoice_request string beginning with `lnr1`.
+	Invreq string `json:"invreq"`
+	// The unique label to use for this invoice.
+	Label string `json:"label"`
+	// Required only if the *offer* does not specify an amount at all, or specifies it in a different currency. Otherwise you may set it (e.g. to provide a tip).
+	AmountMsat *Msat `json:"amount_msat,omitempty"`
+	// Seconds to wait for the offering node to pay the invoice or return an error. This will also be the timeout on the invoice that is sent.
+	Timeout *uint32 `json:"timeout,omitempty"`
+	// Quantity is is required if the offer specifies quantity_max, otherwise it is not allowed.
+	Quantity *uint64 `json:"quantity,omitempty"`
+}
+
+// SendInvoiceResponse is the result of `sendinvoice`.
+type SendInvoiceResponse struct {
+	// Unique label supplied at invoice creation.
+	Label string `json:"label"`
+	// Description used in the invoice.
+	Description string `json:"description"`
+	// The hash of the *payment_preimage* which will prove payment.
+	PaymentHash string `json:"payment_hash"`
+	// Whether it's paid, unpaid or unpayable.
+	Status string `json:"status"`
+	// UNIX timestamp of when it will become / became unpayable.
+	ExpiresAt uint64 `json:"expires_at"`
+	// The amount required to pay this invoice.
+	AmountMsat Msat `json:"amount_msat,omitempty"`
+	// The BOLT12 string.
+	Bolt12 string `json:"bolt12,omitempty"`
+	// 1-based index indicating order this invoice was created in.
+	CreatedIndex uint64 `json:"created_index"`
+	// 1-based index indicating order this invoice was changed (only present if it has changed since creation).
+	UpdatedIndex uint64 `json:"updated_index,omitempty"`
+	// Unique incrementing index for this payment.
+	PayIndex uint64 `json:"pay_index,omitempty"`
+	// The amount actually received (could be slightly greater than *amount_msat*, since clients may overpay).
+	AmountReceivedMsat Msat `json:"amount_received_msat,omitempty"`
+	// UNIX timestamp of when it was paid.
+	PaidAt uint64 `json:"paid_at,omitempty"`
+	// Proof of payment.
+	PaymentPreimage string `json:"payment_preimage,omitempty"`
+}
+
+// SendOnion calls `sendonion`: Send a payment with a custom onion packet.
+func (ln *Client) SendOnion(ctx context.Context, req SendOnionRequest) (resp SendOnionResponse, err error) {
+	err = ln.CallTyped(ctx, "sendonion", req, &resp)
+	return
+}
+
+// SendOnionRequest are the parameters for `sendonion`.
+type SendOnionRequest struct {
+	// Hex-encoded 1366 bytes long blob that was returned by either of the tools that can generate onions.
+	Onion string `json:"onion"`
+	// Instructs the node which peer to send the onion to.
+	FirstHop SendOnionFirstHop `json:"first_hop"`
+	// Specifies the 32 byte hex-encoded hash to use as a challenge to the HTLC that we are sending.
+	PaymentHash string `json:"payment_hash"`
+	// Can be used to provide a human readable reference to retrieve the payment at a later time.
+	Label *string `json:"label,omitempty"`
+	// A JSON list of 32 byte hex-encoded secrets that were used when creating the onion.
+	SharedSecrets []string `json:"shared_secrets,omitempty"`
+	// If provided and non-zero, allows for multiple parallel partial payments with the same *payment_hash*.
+	Partid *uint16 `json:"partid,omitempty"`
+	// If provided, it will be returned in *waitsendpay* and *listsendpays* results.
+	Bolt11 *string `json:"bolt11,omitempty"`
+	// Used to annotate the payment, and is returned by *waitsendpay* and *listsendpays*.
+	AmountMsat *Msat `json:"amount_msat,omitempty"`
+	// If provided, it will be returned in **listpays** result.
+	Destination *string `json:"destination,omitempty"`
+	// Used by offers to link a payment attempt to a local `invoice_request` offer created by lightningd-invoicerequest(7).
+	Localinvreqid *string `json:"localinvreqid,omitempty"`
+	// Allows you to attach a number which appears in **listsendpays** so payments can be identified as part of a logical group.
+	Groupid *uint64 `json:"groupid,omitempty"`
+	// If provided, it will be returned in *waitsendpay* and *listsendpays* results.
+	Description *string `json:"description,omitempty"`
+}
+
+type SendOnionFirstHop struct {
+	// Node id for the peer. Use any available channel available to this peer.
+	ID string `json:"id"`
+	// The amount to add an HTLC for millisatoshis.
+	AmountMsat Msat `json:"amount_msat"`
+	// The number of blocks delay of blocks on top of the current blockheight.
+	Delay uint16 `json:"delay"`
+}
+
+// SendOnionResponse is the result of `sendonion`.
+type SendOnionResponse struct {
+	// 1-based index indicating order this payment was created in.
+	CreatedIndex uint64 `json:"created_index"`
+	// 1-based index indicating order this payment was changed (only present if it has changed since creation).
+	UpdatedIndex uint64 `json:"updated_index,omitempty"`
+	// Old synonym for created_index.
+	ID uint64 `json:"id"`
+	// The hash of the *payment_preimage* which will prove payment.
+	PaymentHash string `json:"payment_hash"`
+	// Grouping key to disambiguate multiple attempts to pay an invoice or the same payment_hash.
+	Groupid uint64 `json:"groupid,omitempty"`
+	// The part number of this payment, if it was sent in parts.
+	Partid uint64 `json:"partid,omitempty"`
+	// The amount delivered to destination (if known).
+	AmountMsat Msat `json:"amount_msat,omitempty"`
+	// The final destination of the payment if known.
+	Destination string `json:"destination,omitempty"`
+	// The amount sent.
+	AmountSentMsat Msat `json:"amount_sent_msat"`
+	// The UNIX timestamp showing when this payment was initiated.
+	CreatedAt uint64 `json:"created_at"`
+	// The UNIX timestamp showing when this payment was completed.
+	CompletedAt uint64 `json:"completed_at,omitempty"`
+	// The label, if given to sendpay.
+	Label string `json:"label,omitempty"`
+	// The bolt11 string (if supplied).
+	Bolt11 string `json:"bolt11,omitempty"`
+	// The bolt12 string (if supplied).
+	Bolt12 string `json:"bolt12,omitempty"`
+	// The proof of payment: SHA256 of this **payment_hash** (**status** *complete* only).
+	PaymentPreimage string `json:"payment_preimage,omitempty"`
+	// Status of the payment.
+	Status string `json:"status"`
+	// Monitor status with listpays or waitsendpay (**status** *pending* only).
+	Message string `json:"message,omitempty"`
+}
+
+// SendPay calls `sendpay`: Send a payment via a route.
+func (ln *Client) SendPay(ctx context.Context, req SendPayRequest) (resp SendPayResponse, err error) {
+	err = ln.CallTyped(ctx, "sendpay", req, &resp)
+	return
+}
+
+// SendPayRequest are the parameters for `sendpay`.
+type SendPayRequest struct {
+	Route []SendPayRoute `json:"route"`
+	// The hash of the payment_preimage.
+	PaymentHash string `json:"payment_hash"`
+	// The label provided when creating the invoice_request.
+	Label *string `json:"label,omitempty"`
+	// Amount must be provided if *partid* is non-zero, or the payment is to a bolt11 invoice without an amount.
+	AmountMsat *Msat `json:"amount_msat,omitempty"`
+	// Bolt11 invoice to pay. If provided, will be returned in *waitsendpay* and *listsendpays* results.
+	Bolt11 *string `json:"bolt11,omitempty"`
+	// Value that the final recipient requires to accept the payment, as defined by the `payment_data` field in BOLT 4 and the `s` field in the BOLT 11 invoice format. It is required if *partid* is non-zero.
+	PaymentSecret *string `json:"payment_secret,omitempty"`
+	// Must not be provided unless *payment_secret* is provided. If it is provided and non-zero, the *amount_msat* must be the whole invoice amount.
+	Partid *uint64 `json:"partid,omitempty"`
+	// Indicates that this payment is being made for a local invoice_request. This ensures that we only send a payment for a single-use invoice_request once.
+	Localinvreqid *string `json:"localinvreqid,omitempty"`
+	// Allows you to attach a number which appears in **listsendpays** so payments can be identified as part of a logical group.
+	Groupid *uint64 `json:"groupid,omitempty"`
+	// Placed in the final onion hop TLV.
+	PaymentMetadata *string `json:"payment_metadata,omitempty"`
+	// Description used in the invoice.
+	Description *string `json:"description,omitempty"`
+}
+
+type SendPayRoute struct {
+	// The node at the end of this hop.
+	ID string `json:"id"`
+	// The channel joining these nodes.
+	Channel string `json:"channel"`
+	// The total CLTV expected by the node at the end of this hop.
+	Delay uint32 `json:"delay"`
+	// The amount expected by the node at the end of this hop.
+	AmountMsat Msat `json:"amount_msat"`
+}
+
+// SendPayResponse is the result of `sendpay`.
+type SendPayResponse struct {
+	// 1-based index indicating order this payment was created in.
+	CreatedIndex uint64 `json:"created_index"`
+	// 1-based index indicating order this payment was changed (only present if it has changed since creation).
+	UpdatedIndex uint64 `json:"updated_index,omitempty"`
+	// Old synonym for created_index.
+	ID uint64 `json:"id"`
+	// The hash of the *payment_preimage* which will prove payment.
+	PaymentHash string `json:"payment_hash"`
+	// Grouping key to disambiguate multiple attempts to pay an invoice or the same payment_hash.
+	Groupid uint64 `json:"groupid,omitempty"`
+	// The part number of this payment, if it was sent in parts.
+	Partid uint64 `json:"partid,omitempty"`
+	// The amount delivered to destination (if known).
+	AmountMsat Msat `json:"amount_msat,omitempty"`
+	// The final destination of the payment if known.
+	Destination string `json:"destination,omitempty"`
+	// The amount sent.
+	AmountSentMsat Msat `json:"amount_sent_msat"`
+	// The UNIX timestamp showing when this payment was initiated.
+	CreatedAt uint64 `json:"created_at"`
+	// The UNIX timestamp showing when this payment was completed.
+	CompletedAt uint64 `json:"completed_at,omitempty"`
+	// The label, if given to sendpay.
+	Label string `json:"label,omitempty"`
+	// The bolt11 string (if supplied).
+	Bolt11 string `json:"bolt11,omitempty"`
+	// The bolt12 string (if supplied).
+	Bolt12 string `json:"bolt12,omitempty"`
+	// The proof of payment: SHA256 of this **payment_hash** (**status** *complete* only).
+	PaymentPreimage string `json:"payment_preimage,omitempty"`
+	// Status of the payment.
+	Status string `json:"status"`
+	// Monitor status with listpays or waitsendpay (**status** *pending* only).
+	Message string `json:"message,omitempty"`
+}
+
+// SendPsbt calls `sendpsbt`: Command to finalize, extract and send a partially signed bitcoin transaction (PSBT).
+func (ln *Client) SendPsbt(ctx context.Context, req SendPsbtRequest) (resp SendPsbtResponse, err error) {
+	err = ln.CallTyped(ctx, "sendpsbt", req, &resp)
+	return
+}
+
+// SendPsbtRequest are the parameters for `sendpsbt`.
+type SendPsbtRequest struct {
+	// The fully signed psbt to be sent.
+	Psbt string `json:"psbt"`
+	// Number of blocks to increase reservation of any of our inputs by.
+	Reserve *uint32 `json:"reserve,omitempty"`
+}
+
+// SendPsbtResponse is the result of `sendpsbt`.
+type SendPsbtResponse struct {
+	// The raw transaction which was sent.
+	Tx string `json:"tx"`
+	// The txid of the **tx**.
+	Txid string `json:"txid"`
+}
+
+// SetChannel calls `setchannel`: Command for configuring fees / htlc range advertized for a channel.
+func (ln *Client) SetChannel(ctx context.Context, req SetChannelRequest) (resp SetChannelResponse, err error) {
+	err = ln.CallTyped(ctx, "setchannel", req, &resp)
+	return
+}
+
+// SetChannelRequest are the parameters for `setchannel`.
+type SetChannelRequest struct {
+	// Should contain a scid (short channel ID), channel id or peerid (pubkey) of the channel to be modified. If *id* is set to `all`, the updates are applied to all channels in states CHANNELD_NORMAL CHANNELD_AWAITING_LOCKIN or DUALOPEND_AWAITING_LOCKIN.
+	ID string `json:"id"`
+	// Value in millisatoshi that is added as base fee to any routed payment: if omitted, it is unchanged.
+	Feebase *Msat `json:"feebase,omitempty"`
+	// Value that is added proportionally per-millionths to any routed payment volume in satoshi.
+	Feeppm *uint32 `json:"feeppm,omitempty"`
+	// Value that limits how small an HTLC we will forward: if omitted, it is unchanged.
+	Htlcmin *Msat `json:"htlcmin,omitempty"`
+	// Value that limits how large an HTLC we will forward: if omitted, it is unchanged.
+	Htlcmax *Msat `json:"htlcmax,omitempty"`
+	// Number of seconds to delay before enforcing the new fees/htlc max.
+	Enforcedelay *uint32 `json:"enforcedelay,omitempty"`
+	// If set to True means to allow the peer to set the commitment transaction fees (or closing transaction fees) to any value they want.
+	Ignorefeelimits *bool `json:"ignorefeelimits,omitempty"`
+}
+
+// SetChannelResponse is the result of `setchannel`.
+type SetChannelResponse struct {
+	// Channel(s) set, and their resulting configuration.
+	Channels []SetChannelChannels `json:"channels"`
+}
+
+type SetChannelChannels struct {
+	// The node_id of the peer.
+	PeerID string `json:"peer_id"`
+	// The channel_id of the channel.
+	ChannelID string `json:"channel_id"`
+	// The short_channel_id (if locked in).
+	ShortChannelID string `json:"short_channel_id,omitempty"`
+	// The resulting feebase (this is the BOLT #7 name).
+	FeeBaseMsat Msat `json:"fee_base_msat"`
+	// The resulting feeppm (this is the BOLT #7 name).
+	FeeProportionalMillionths uint32 `json:"fee_proportional_millionths"`
+	// If we are relaxing fee limits for peer transactions.
+	IgnoreFeeLimits bool `json:"ignore_fee_limits"`
+	// The resulting htlcmin we will advertize (the BOLT #7 name is htlc_minimum_msat).
+	MinimumHtlcOutMsat Msat `json:"minimum_htlc_out_msat"`
+	// The requested htlcmin was too low for this peer, so we set it to the minimum they will allow.
+	WarningHtlcminTooLow string `json:"warning_htlcmin_too_low,omitempty"`
+	// The resulting htlcmax we will advertize (the BOLT #7 name is htlc_maximum_msat).
+	MaximumHtlcOutMsat Msat `json:"maximum_htlc_out_msat"`
+	// The requested htlcmax was greater than the channel capacity, so we set it to the channel capacity.
+	WarningHtlcmaxTooHigh string `json:"warning_htlcmax_too_high,omitempty"`
+}
+
+// SetConfig calls `setconfig`: Dynamically change some config options.
+func (ln *Client) SetConfig(ctx context.Context, req SetConfigRequest) (resp SetConfigResponse, err error) {
+	err = ln.CallTyped(ctx, "setconfig", req, &resp)
+	return
+}
+
+// SetConfigRequest are the parameters for `setconfig`.
+type SetConfigRequest struct {
+	// Name of the config variable which should be set to the value of the variable.
+	Config string `json:"config"`
+	// Value of the config variable to be set or updated.
+	Val json.RawMessage `json:"val,omitempty"`
+}
+
+// SetConfigResponse is the result of `setconfig`.
+type SetConfigResponse struct {
+	// Config settings after completion.
+	Config SetConfigConfig `json:"config"`
+}
+
+type SetConfigConfig struct {
+	// Name of the config variable which was set.
+	Config string `json:"config"`
+	// Source of configuration setting (`file`:`linenum`).
+	Source string `json:"source"`
+	// The plugin this configuration setting is for.
+	Plugin string `json:"plugin,omitempty"`
+	// Whether this option is settable via setconfig.
+	Dynamic bool `json:"dynamic"`
+	// For simple flag options.
+	Set bool `json:"set,omitempty"`
+	// For string options.
+	ValueStr string `json:"value_str,omitempty"`
+	// For msat options.
+	ValueMsat Msat `json:"value_msat,omitempty"`
+	// For integer options.
+	ValueInt int64 `json:"value_int,omitempty"`
+	// For boolean options.
+	ValueBool bool `json:"value_bool,omitempty"`
+}
+
+// SetPsbtVersion calls `setpsbtversion`: Command for setting PSBT version.
+func (ln *Client) SetPsbtVersion(ctx context.Context, req SetPsbtVersionRequest) (resp SetPsbtVersionResponse, err error) {
+	err = ln.CallTyped(ctx, "setpsbtversion", req, &resp)
+	return
+}
+
+// SetPsbtVersionRequest are the parameters for `setpsbtversion`.
+type SetPsbtVersionRequest struct {
+	// The PSBT to change versions.
+	Psbt string `json:"psbt"`
+	// The version to set.
+	Version uint32 `json:"version"`
+}
+
+// SetPsbtVersionResponse is the result of `setpsbtversion`.
+type SetPsbtVersionResponse struct {
+	// A converted PSBT of the requested version.
+	Psbt string `json:"psbt"`
+}
+
+// ShowRunes calls `showrunes`: Command to list previously generated runes.
+func (ln *Client) ShowRunes(ctx context.Context, req ShowRunesRequest) (resp ShowRunesResponse, err error) {
+	err = ln.CallTyped(ctx, "showrunes", req, &resp)
+	return
+}
+
+// ShowRunesRequest are the parameters for `showrunes`.
+type ShowRunesRequest struct {
+	// If specified, only details of that rune will be returned.
+	Rune *string `json:"rune,omitempty"`
+}
+
+// ShowRunesResponse is the result of `showrunes`.
+type ShowRunesResponse struct {
+	Runes []ShowRunesRunes `json:"runes"`
+}
+
+type ShowRunesRunes struct {
+	// Base64 encoded rune.
+	Rune string `json:"rune"`
+	// Unique id assigned when the rune was generated; this is always a u64 for commando runes.
+	UniqueID string `json:"unique_id"`
+	// The restrictions on what commands this rune can authorize.
+	Restrictions []ShowRunesRunesRestrictions `json:"restrictions"`
+	// English readable description of the restrictions array above.
+	RestrictionsAsEnglish string `json:"restrictions_as_english"`
+	// This is false if the rune does not appear in our datastore (only possible when `rune` is specified).
+	Stored bool `json:"stored,omitempty"`
+	// The rune has been blacklisted; see commando-blacklist(7).
+	Blacklisted bool `json:"blacklisted,omitempty"`
+	// The last time this rune was successfully used.
+	LastUsed float64 `json:"last_used,omitempty"`
+	// This is not a rune for this node (only possible when `rune` is specified).
+	OurRune bool `json:"our_rune,omitempty"`
+}
+
+type ShowRunesRunesRestrictions struct {
+	Alternatives []ShowRunesRunesRestrictionsAlternatives `json:"alternatives"`
+	// English readable summary of alternatives above.
+	English string `json:"english"`
+}
+
+type ShowRunesRunesRestrictionsAlternatives struct {
+	// The field this restriction applies to; see commando-rune(7).
+	Fieldname string `json:"fieldname"`
+	// The value accepted for this field.
+	Value string `json:"value"`
+	// The way to compare fieldname and value.
+	Condition string `json:"condition"`
+	// English readable description of this alternative.
+	English string `json:"english"`
+}
+
+// SignInvoice calls `signinvoice`: Low-level invoice signing.
+func (ln *Client) SignInvoice(ctx context.Context, req SignInvoiceRequest) (resp SignInvoiceResponse, err error) {
+	err = ln.CallTyped(ctx, "signinvoice", req, &resp)
+	return
+}
+
+// SignInvoiceRequest are the parameters for `signinvoice`.
+type SignInvoiceRequest struct {
+	// Bolt11 form, but the final signature is ignored. Minimal sanity checks are done.
+	Invstring string `json:"invstring"`
+}
+
+// SignInvoiceResponse is the result of `signinvoice`.
+type SignInvoiceResponse struct {
+	// The bolt11 string.
+	Bolt11 string `json:"bolt11"`
+}
+
+// SignMessage calls `signmessage`: Command to create a signature from this node.
+func (ln *Client) SignMessage(ctx context.Context, req SignMessageRequest) (resp SignMessageResponse, err error) {
+	err = ln.CallTyped(ctx, "signmessage", req, &resp)
+	return
+}
+
+// SignMessageRequest are the parameters for `signmessage`.
+type SignMessageRequest struct {
+	// Less than 65536 characters long message to be signed by the node.
+	Message string `json:"message"`
+}
+
+// SignMessageResponse is the result of `signmessage`.
+type SignMessageResponse struct {
+	// The signature.
+	Signature string `json:"signature"`
+	// The recovery id (0, 1, 2 or 3).
+	Recid string `json:"recid"`
+	// *signature* and *recid* encoded in a style compatible with **lnd**'s SignMessageRequest (https://api.lightning.community/#grpc-request- signmessagerequest).
+	Zbase string `json:"zbase"`
+}
+
+// SignPsbt calls `signpsbt`: Command to sign a wallet's inputs on a provided bitcoin transaction (PSBT).
+func (ln *Client) SignPsbt(ctx context.Context, req SignPsbtRequest) (resp SignPsbtResponse, err error) {
+	err = ln.CallTyped(ctx, "signpsbt", req, &resp)
+	return
+}
+
+// SignPsbtRequest are the parameters for `signpsbt`.
+type SignPsbtRequest struct {
+	// The psbt to be signed.
+	Psbt string `json:"psbt"`
+	// Input numbers to sign.
+	Signonly []uint32 `json:"signonly,omitempty"`
+}
+
+// SignPsbtResponse is the result of `signpsbt`.
+type SignPsbtResponse struct {
+	// The fully signed PSBT.
+	SignedPsbt string `json:"signed_psbt"`
+}
+
+// SpliceInit calls `splice_init`: Command to initiate a channel to a peer.
+func (ln *Client) SpliceInit(ctx context.Context, req SpliceInitRequest) (resp SpliceInitResponse, err error) {
+	err = ln.CallTyped(ctx, "splice_init", req, &resp)
+	return
+}
+
+// SpliceInitRequest are the parameters for `splice_init`.
+type SpliceInitRequest struct {
+	// The channel id of the channel to be spliced.
+	ChannelID string `json:"channel_id"`
+	// A positive or negative amount of satoshis to add or subtract from the channel. Note you may need to add a double dash (--) after splice_init if using a negative *relative_amount* so it is not interpretted as a command modifier.
+	RelativeAmount int64 `json:"relative_amount"`
+	// The (optional) base PSBT to use for the splice. If not provided, one will be created automatically.
+	Initialpsbt *string `json:"initialpsbt,omitempty"`
+	// The miner fee we promise our peer to pay for our side of the splice transaction. It is calculated by `feerate_per_kw` * our_bytes_in_splice_tx / 1000.
+	FeeratePerKw *uint32 `json:"feerate_per_kw,omitempty"`
+	// By default splices will fail if the fee provided looks too high. This is to protect against accidentally setting your fee higher than intended. Set `force_feerate` to true to skip this saftey check.
+	ForceFeerate *bool `json:"force_feerate,omitempty"`
+}
+
+// SpliceInitResponse is the result of `splice_init`.
+type SpliceInitResponse struct {
+	// The (incomplete) PSBT of the splice transaction.
+	Psbt string `json:"psbt"`
+}
+
+// SpliceSigned calls `splice_signed`: Command to initiate a channel to a peer.
+func (ln *Client) SpliceSigned(ctx context.Context, req SpliceSignedRequest) (resp SpliceSignedResponse, err error) {
+	err = ln.CallTyped(ctx, "splice_signed", req, &resp)
+	return
+}
+
+// SpliceSignedRequest are the parameters for `splice_signed`.
+type SpliceSignedRequest struct {
+	// The channel id of the channel to be spliced.
+	ChannelID string `json:"channel_id"`
+	// The final version of the psbt to complete the splice with.
+	Psbt string `json:"psbt"`
+	// A flag that makes our node offer the final splice signature first (defaults to false). When false, the node will calculate who should sign first based off who is adding inputting the least sats to the splice as per spec.
+	SignFirst *bool `json:"sign_first,omitempty"`
+}
+
+// SpliceSignedResponse is the result of `splice_signed`.
+type SpliceSignedResponse struct {
+	// The hex representation of the final transaction that is published.
+	Tx string `json:"tx"`
+	// The txid is of the final transaction.
+	Txid string `json:"txid"`
+	// The index of the new funding output.
+	Outnum uint32 `json:"outnum,omitempty"`
+	// The PSBT of the final transaction.
+	Psbt string `json:"psbt"`
+}
+
+// SpliceUpdate calls `splice_update`: Command to initiate a channel to a peer.
+func (ln *Client) SpliceUpdate(ctx context.Context, req SpliceUpdateRequest) (resp SpliceUpdateResponse, err error) {
+	err = ln.CallTyped(ctx, "splice_update", req, &resp)
+	return
+}
+
+// SpliceUpdateRequest are the parameters for `splice_update`.
+type SpliceUpdateRequest struct {
+	// The channel id of the channel to be spliced.
+	ChannelID string `json:"channel_id"`
+	// The base 64 encoded PSBT returned from `splice_init` with any changes added by the user.
+	Psbt string `json:"psbt"`
+}
+
+// SpliceUpdateResponse is the result of `splice_update`.
+type SpliceUpdateResponse struct {
+	// The (incomplete) PSBT of the splice transaction.
+	Psbt string `json:"psbt"`
+	// Whether or not the commitments were secured.
+	CommitmentsSecured bool `json:"commitments_secured"`
+	// Whether or not the peer sent its signatures.
+	SignaturesSecured bool `json:"signatures_secured,omitempty"`
+}
+
+// Sql calls `sql`: Command to do complex queries on list commands.
+func (ln *Client) Sql(ctx context.Context, req SqlRequest) (resp SqlResponse, err error) {
+	err = ln.CallTyped(ctx, "sql", req, &resp)
+	return
+}
+
+// SqlRequest are the parameters for `sql`.
+type SqlRequest struct {
+	// The standard sqlite3 query to run.
+	Query string `json:"query"`
+}
+
+// SqlResponse is the result of `sql`.
+type SqlResponse struct {
+	Rows [][]json.RawMessage `json:"rows"`
+	// A message if the database encounters an error partway through.
+	WarningDbFailure string `json:"warning_db_failure,omitempty"`
+}
+
+// StaticBackup calls `staticbackup`: Command for deriving getting SCB of all the existing channels.
+func (ln *Client) StaticBackup(ctx context.Context) (resp StaticBackupResponse, err error) {
+	err = ln.CallTyped(ctx, "staticbackup", nil, &resp)
+	return
+}
+
+// StaticBackupResponse is the result of `staticbackup`.
+type StaticBackupResponse struct {
+	Scb []string `json:"scb"`
+}
+
+// Stop calls `stop`: Command to shutdown the Core Lightning node.
+func (ln *Client) Stop(ctx context.Context) (resp StopResponse, err error) {
+	err = ln.CallTyped(ctx, "stop", nil, &resp)
+	return
+}
+
+// StopResponse is the result of `stop`.
+type StopResponse struct {
+	Result string `json:"result,omitempty"`
+}
+
+// TxDiscard calls `txdiscard`: Abandon a transaction from txprepare, release inputs.
+func (ln *Client) TxDiscard(ctx context.Context, req TxDiscardRequest) (resp TxDiscardResponse, err error) {
+	err = ln.CallTyped(ctx, "txdiscard", req, &resp)
+	return
+}
+
+// TxDiscardRequest are the parameters for `txdiscard`.
+type TxDiscardRequest struct {
+	// The transaction id, inputs should be unreseverd from.
+	Txid string `json:"txid"`
+}
+
+// TxDiscardResponse is the result of `txdiscard`.
+type TxDiscardResponse struct {
+	// The unsigned transaction.
+	UnsignedTx string `json:"unsigned_tx"`
+	// The transaction id of *unsigned_tx*.
+	Txid string `json:"txid"`
+}
+
+// TxPrepare calls `txprepare`: Command to prepare to withdraw funds from the internal wallet.
+func (ln *Client) TxPrepare(ctx context.Context, req TxPrepareRequest) (resp TxPrepareResponse, err error) {
+	err = ln.CallTyped(ctx, "txprepare", req, &resp)
+	return
+}
+
+// TxPrepareRequest are the parameters for `txprepare`.
+type TxPrepareRequest struct {
+	// An array containing objects of the form `{destination: amount}`. The `amount` may be the string *all*, indicating that all onchain funds be sent to the specified address.
+	Outputs []json.RawMessage `json:"outputs"`
+	// Used for the transaction as initial feerate.
+	Feerate *string `json:"feerate,omitempty"`
+	// The minimum number of confirmations that used outputs should have.
+	Minconf *uint32  `json:"minconf,omitempty"`
+	Utxos   []string `json:"utxos,omitempty"`
+}
+
+// TxPrepareResponse is the result of `txprepare`.
+type TxPrepareResponse struct {
+	// The PSBT representing the unsigned transaction.
+	Psbt string `json:"psbt"`
+	// The unsigned transaction.
+	UnsignedTx string `json:"unsigned_tx"`
+	// The transaction id of *unsigned_tx*; you hand this to lightning-txsend(7) or lightning-txdiscard(7), as the inputs of this transaction are reserved.
+	Txid string `json:"txid"`
+}
+
+// TxSend calls `txsend`: Command to sign and send transaction from txprepare.
+func (ln *Client) TxSend(ctx context.Context, req TxSendRequest) (resp TxSendResponse, err error) {
+	err = ln.CallTyped(ctx, "txsend", req, &resp)
+	return
+}
+
+// TxSendRequest are the parameters for `txsend`.
+type TxSendRequest struct {
+	// The transaction id of the transaction created by `txprepare` rpc command.
+	Txid string `json:"txid"`
+}
+
+// TxSendResponse is the result of `txsend`.
+type TxSendResponse struct {
+	// The completed PSBT representing the signed transaction.
+	Psbt string `json:"psbt"`
+	// The fully signed transaction.
+	Tx string `json:"tx"`
+	// The transaction id of *tx*.
+	Txid string `json:"txid"`
+}
+
+// UnreserveInputs calls `unreserveinputs`: Release reserved UTXOs.
+func (ln *Client) UnreserveInputs(ctx context.Context, req UnreserveInputsRequest) (resp UnreserveInputsResponse, err error) {
+	err = ln.CallTyped(ctx, "unreserveinputs", req, &resp)
+	return
+}
+
+// UnreserveInputsRequest are the parameters for `unreserveinputs`.
+type UnreserveInputsRequest struct {
+	// Inputs to unreserve.
+	Psbt string `json:"psbt"`
+	// The number of blocks to decrement a reservation by.
+	Reserve *uint32 `json:"reserve,omitempty"`
+}
+
+// UnreserveInputsResponse is the result of `unreserveinputs`.
+type UnreserveInputsResponse struct {
+	Reservations []UnreserveInputsReservations `json:"reservations"`
+}
+
+type UnreserveInputsReservations struct {
+	// The transaction id.
+	Txid string `json:"txid"`
+	// The output number which was reserved.
+	Vout uint32 `json:"vout"`
+	// Whether the input was already reserved (usually `true`).
+	WasReserved bool `json:"was_reserved"`
+	// Whether the input is now reserved (may still be `true` if it was reserved for a long time).
+	Reserved bool `json:"reserved"`
+	// What blockheight the reservation will expire.
+	ReservedToBlock uint32 `json:"reserved_to_block,omitempty"`
+}
+
+// UpgradeWallet calls `upgradewallet`: Command to spend all P2SH-wrapped inputs into a Native Segwit output.
+func (ln *Client) UpgradeWallet(ctx context.Context, req UpgradeWalletRequest) (resp UpgradeWalletResponse, err error) {
+	err = ln.CallTyped(ctx, "upgradewallet", req, &resp)
+	return
+}
+
+// UpgradeWalletRequest are the parameters for `upgradewallet`.
+type UpgradeWalletRequest struct {
+	// Feerate for the upgrade transaction.
+	Feerate *string `json:"feerate,omitempty"`
+	// Tells the wallet to include all P2SH-wrapped inputs, including reserved ones.
+	Reservedok *bool `json:"reservedok,omitempty"`
+}
+
+// UpgradeWalletResponse is the result of `upgradewallet`.
+type UpgradeWalletResponse struct {
+	// Count of spent/upgraded UTXOs.
+	UpgradedOuts uint64 `json:"upgraded_outs"`
+	// The PSBT that was finalized and sent.
+	Psbt string `json:"psbt,omitempty"`
+	// The raw transaction which was sent.
+	Tx string `json:"tx,omitempty"`
+	// The txid of the **tx**.
+	Txid string `json:"txid,omitempty"`
+}
+
+// UtxoPsbt calls `utxopsbt`: Command to populate PSBT inputs from given UTXOs.
+func (ln *Client) UtxoPsbt(ctx context.Context, req UtxoPsbtRequest) (resp UtxoPsbtResponse, err error) {
+	err = ln.CallTyped(ctx, "utxopsbt", req, &resp)
+	return
+}
+
+// UtxoPsbtRequest are the parameters for `utxopsbt`.
+type UtxoPsbtRequest struct {
+	// The minimum satoshi value of the output(s) needed (or the string `all` meaning use all available inputs).
+	Satoshi string `json:"satoshi"`
+	// Used for the transaction as initial feerate.
+	Feerate string `json:"feerate"`
+	// The weight of the transaction before *fundpsbt* has added any inputs.
+	Startweight uint32 `json:"startweight"`
+	// An array of `txid:vout`, each of which must be reserved or available.
+	Utxos []string `json:"utxos"`
+	// If not zero, then *reserveinputs* is called (successfully, with *exclusive* true) on the returned PSBT for this number of blocks.
+	Reserve *uint32 `json:"reserve,omitempty"`
+	// If set to true, it will also fail if any of the *utxos* are already reserved.
+	Reservedok *bool `json:"reservedok,omitempty"`
+	// If not set, it is set to a recent block height.
+	Locktime *uint32 `json:"locktime,omitempty"`
+	// Minimum weight to use for a UTXO's witness. If the actual witness weight is greater than the provided minimum, the actual witness weight will be used.
+	MinWitnessWeight *uint32 `json:"min_witness_weight,omitempty"`
+	// Flag to add a change output for the excess sats.
+	ExcessAsChange *bool `json:"excess_as_change,omitempty"`
+	// To signel that it needs emergency reserve for anchors so that we can lowball our commitment tx fees, and min-emergency-msat for reserving some sats for closing anchor channels.
+	OpeningAnchorChannel *bool `json:"opening_anchor_channel,omitempty"`
+}
+
+// UtxoPsbtResponse is the result of `utxopsbt`.
+type UtxoPsbtResponse struct {
+	// Unsigned PSBT which fulfills the parameters given.
+	Psbt string `json:"psbt"`
+	// The feerate used to create the PSBT, in satoshis-per-kiloweight.
+	FeeratePerKw uint32 `json:"feerate_per_kw"`
+	// The estimated weight of the transaction once fully signed.
+	EstimatedFinalWeight uint32 `json:"estimated_final_weight"`
+	// The amount above *satoshi* which is available. This could be zero, or dust; it will be zero if *change_outnum* is also returned.
+	ExcessMsat Msat `json:"excess_msat"`
+	// The 0-based output number where change was placed (only if parameter *excess_as_change* was true and there was sufficient funds).
+	ChangeOutnum uint32 `json:"change_outnum,omitempty"`
+	// If *reserve* was true or a non-zero number, just as per lightning- reserveinputs(7).
+	Reservations []UtxoPsbtReservations `json:"reservations,omitempty"`
+}
+
+type UtxoPsbtReservations struct {
+	// The txid of the transaction.
+	Txid string `json:"txid"`
+	// The 0-based output number.
+	Vout uint32 `json:"vout"`
+	// Whether this output was previously reserved.
+	WasReserved bool `json:"was_reserved"`
+	// Whether this output is now reserved.
+	Reserved bool `json:"reserved"`
+	// The blockheight the reservation will expire.
+	ReservedToBlock uint32 `json:"reserved_to_block"`
+}
+
+// Wait calls `wait`: Command to wait for creations, changes and deletions.
+func (ln *Client) Wait(ctx context.Context, req WaitRequest) (resp WaitResponse, err error) {
+	err = ln.CallTyped(ctx, "wait", req, &resp)
+	return
+}
+
+// WaitRequest are the parameters for `wait`.
+type WaitRequest struct {
+	// The subsystem to get the next index value from.
+	Subsystem string `json:"subsystem"`
+	// The name of the index to get the next value for.
+	Indexname string `json:"indexname"`
+	// The next value of the index.
+	Nextvalue uint64 `json:"nextvalue"`
+}
+
+// WaitResponse is the result of `wait`.
+type WaitResponse struct {
+	Subsystem string `json:"subsystem"`
+	// 1-based index indicating order entry was created.
+	Created uint64 `json:"created,omitempty"`
+	// 1-based index indicating order entry was updated.
+	Updated uint64 `json:"updated,omitempty"`
+	// 1-based index indicating order entry was deleted.
+	Deleted uint64      `json:"deleted,omitempty"`
+	Details WaitDetails `json:"details,omitempty"`
+}
+
+type WaitDetails struct {
+	// Status of the payment, invoice or forward.
+	Status string `json:"status,omitempty"`
+	// Unique label for the invoice (invoices only).
+	Label string `json:"label,omitempty"`
+	// Description used in the invoice (invoices only).
+	Description string `json:"description,omitempty"`
+	// The BOLT11 string.
+	Bolt11 string `json:"bolt11,omitempty"`
+	// The BOLT12 string.
+	Bolt12 string `json:"bolt12,omitempty"`
+	// Part number (for multiple parts to a single payment; sendpays only).
+	Partid uint64 `json:"partid,omitempty"`
+	// Grouping key to disambiguate multiple attempts to pay an invoice or the same payment_hash (sendpays only).
+	Groupid uint64 `json:"groupid,omitempty"`
+	// The hash of the *payment_preimage* which will prove payment.
+	PaymentHash string `json:"payment_hash,omitempty"`
+	// Unique short_channel_id the HTLC was received on (forwards only).
+	InChannel string `json:"in_channel,omitempty"`
+	// The unique HTLC id the sender gave this (forwards only).
+	InHtlcID uint64 `json:"in_htlc_id,omitempty"`
+	// The value of the incoming HTLC (forwards only).
+	InMsat Msat `json:"in_msat,omitempty"`
+	// The channel that the HTLC (trying to) forward to (forwards only).
+	OutChannel string `json:"out_channel,omitempty"`
+}
+
+// WaitAnyInvoice calls `waitanyinvoice`: Command for waiting for payments.
+func (ln *Client) WaitAnyInvoice(ctx context.Context, req WaitAnyInvoiceRequest) (resp WaitAnyInvoiceResponse, err error) {
+	err = ln.CallTyped(ctx, "waitanyinvoice", req, &resp)
+	return
+}
+
+// WaitAnyInvoiceRequest are the parameters for `waitanyinvoice`.
+type WaitAnyInvoiceRequest struct {
+	// Ignores any invoices paid prior to or including this index. 0 is equivalent to not specifying and negative value is invalid.
+	LastpayIndex *uint64 `json:"lastpay_index,omitempty"`
+	// If specified, wait at most that number of seconds, which must be an integer. If the specified timeout is reached, this command will return with an error.
+	Timeout *uint64 `json:"timeout,omitempty"`
+}
+
+// WaitAnyInvoiceResponse is the result of `waitanyinvoice`.
+type WaitAnyInvoiceResponse struct {
+	// Unique label supplied at invoice creation.
+	Label string `json:"label"`
+	// Description used in the invoice.
+	Description string `json:"description"`
+	// The hash of the *payment_preimage* which will prove payment.
+	PaymentHash string `json:"payment_hash"`
+	// Whether it's paid or expired.
+	Status string `json:"status"`
+	// UNIX timestamp of when it will become / became unpayable.
+	ExpiresAt uint64 `json:"expires_at"`
+	// The amount required to pay this invoice.
+	AmountMsat Msat `json:"amount_msat,omitempty"`
+	// The BOLT11 string (always present unless *bolt12* is).
+	Bolt11 string `json:"bolt11,omitempty"`
+	// The BOLT12 string (always present unless *bolt11* is).
+	Bolt12 string `json:"bolt12,omitempty"`
+	// 1-based index indicating order this invoice was created in.
+	CreatedIndex uint64 `json:"created_index"`
+	// 1-based index indicating order this invoice was changed (only present if it has changed since creation).
+	UpdatedIndex uint64 `json:"updated_index,omitempty"`
+	// Unique incrementing index for this payment (only if status is paid).
+	PayIndex uint64 `json:"pay_index,omitempty"`
+	// The amount actually received (could be slightly greater than *amount_msat*, since clients may overpay) (only if status is paid).
+	AmountReceivedMsat Msat `json:"amount_received_msat,omitempty"`
+	// UNIX timestamp of when it was paid (only if status is paid).
+	PaidAt uint64 `json:"paid_at,omitempty"`
+	// Proof of payment (only if status is paid).
+	PaymentPreimage string `json:"payment_preimage,omitempty"`
+}
+
+// WaitBlockHeight calls `waitblockheight`: Command for waiting for blocks on the blockchain.
+func (ln *Client) WaitBlockHeight(ctx context.Context, req WaitBlockHeightRequest) (resp WaitBlockHeightResponse, err error) {
+	err = ln.CallTyped(ctx, "waitblockheight", req, &resp)
+	return
+}
+
+// WaitBlockHeightRequest are the parameters for `waitblockheight`.
+type WaitBlockHeightRequest struct {
+	// Current blockheight of the blockchain if the value is greater than this number. If it is a present or past block height, then the command returns immediately.
+	Blockheight uint32 `json:"blockheight"`
+	// Timeout in seconds, after which the command fails if no block with height greater than *blockheight* arrived.
+	Timeout *uint32 `json:"timeout,omitempty"`
+}
+
+// WaitBlockHeightResponse is the result of `waitblockheight`.
+type WaitBlockHeightResponse struct {
+	// The current block height (>= *blockheight* parameter).
+	Blockheight uint32 `json:"blockheight"`
+}
+
+// WaitInvoice calls `waitinvoice`: Command for waiting for specific payment.
+func (ln *Client) WaitInvoice(ctx context.Context, req WaitInvoiceRequest) (resp WaitInvoiceResponse, err error) {
+	err = ln.CallTyped(ctx, "waitinvoice", req, &resp)
+	return
+}
+
+// WaitInvoiceRequest are the parameters for `waitinvoice`.
+type WaitInvoiceRequest struct {
+	// Unique label of the invoice waiting to be paid or expired.
+	Label string `json:"label"`
+}
+
+// WaitInvoiceResponse is the result of `waitinvoice`.
+type WaitInvoiceResponse struct {
+	// Unique label supplied at invoice creation.
+	Label string `json:"label"`
+	// Description used in the invoice.
+	Description string `json:"description"`
+	// The hash of the *payment_preimage* which will prove payment.
+	PaymentHash string `json:"payment_hash"`
+	// Whether it's paid or expired.
+	Status string `json:"status"`
+	// UNIX timestamp of when it will become / became unpayable.
+	ExpiresAt uint64 `json:"expires_at"`
+	// The amount required to pay this invoice.
+	AmountMsat Msat `json:"amount_msat,omitempty"`
+	// The BOLT11 string (always present unless *bolt12* is).
+	Bolt11 string `json:"bolt11,omitempty"`
+	// The BOLT12 string (always present unless *bolt11* is).
+	Bolt12 string `json:"bolt12,omitempty"`
+	// 1-based index indicating order this invoice was created in.
+	CreatedIndex uint64 `json:"created_index"`
+	// 1-based index indicating order this invoice was changed (only present if it has changed since creation).
+	UpdatedIndex uint64 `json:"updated_index,omitempty"`
+	// Unique incrementing index for this payment (only if status is paid).
+	PayIndex uint64 `json:"pay_index,omitempty"`
+	// The amount actually received (could be slightly greater than *amount_msat*, since clients may overpay) (only if status is paid).
+	AmountReceivedMsat Msat `json:"amount_received_msat,omitempty"`
+	// UNIX timestamp of when it was paid (only if status is paid).
+	PaidAt uint64 `json:"paid_at,omitempty"`
+	// Proof of payment (only if status is paid).
+	PaymentPreimage string `json:"payment_preimage,omitempty"`
+}
+
+// WaitSendPay calls `waitsendpay`: Command for sending a payment via a route.
+func (ln *Client) WaitSendPay(ctx context.Context, req WaitSendPayRequest) (resp WaitSendPayResponse, err error) {
+	err = ln.CallTyped(ctx, "waitsendpay", req, &resp)
+	return
+}
+
+// WaitSendPayRequest are the parameters for `waitsendpay`.
+type WaitSendPayRequest struct {
+	// The hash of the *payment_preimage*.
+	PaymentHash string `json:"payment_hash"`
+	// A timeout in seconds, for this RPC command to return. If the *timeout* is provided and the given amount of time passes without the payment definitely succeeding or definitely failing, this command returns with a 200 error code (payment still in progress). If *timeout* is not provided this call will wait indefinitely.
+	Timeout *uint32 `json:"timeout,omitempty"`
+	// Unique ID within this (multi-part) payment. It must match that of the **sendpay** command.
+	Partid *uint64 `json:"partid,omitempty"`
+	// Grouping key to disambiguate multiple attempts to pay the same payment_hash.
+	Groupid *uint64 `json:"groupid,omitempty"`
+}
+
+// WaitSendPayResponse is the result of `waitsendpay`.
+type WaitSendPayResponse struct {
+	// 1-based index indicating order this payment was created in.
+	CreatedIndex uint64 `json:"created_index"`
+	// 1-based index indicating order this payment was changed (only present if it has changed since creation).
+	UpdatedIndex uint64 `json:"updated_index,omitempty"`
+	// Old synonym for created_index.
+	ID uint64 `json:"id"`
+	// The hash of the *payment_preimage* which will prove payment.
+	PaymentHash string `json:"payment_hash"`
+	// Grouping key to disambiguate multiple attempts to pay an invoice or the same payment_hash.
+	Groupid uint64 `json:"groupid,omitempty"`
+	// The part number of this payment, if it was sent in parts.
+	Partid uint64 `json:"partid,omitempty"`
+	// The amount delivered to destination (if known).
+	AmountMsat Msat `json:"amount_msat,omitempty"`
+	// The final destination of the payment if known.
+	Destination string `json:"destination,omitempty"`
+	// The amount sent.
+	AmountSentMsat Msat `json:"amount_sent_msat"`
+	// The UNIX timestamp showing when this payment was initiated.
+	CreatedAt uint64 `json:"created_at"`
+	// The UNIX timestamp showing when this payment was completed.
+	CompletedAt uint64 `json:"completed_at,omitempty"`
+	// The label, if given to sendpay.
+	Label string `json:"label,omitempty"`
+	// The bolt11 string (if supplied).
+	Bolt11 string `json:"bolt11,omitempty"`
+	// The bolt12 string (if supplied).
+	Bolt12 string `json:"bolt12,omitempty"`
+	// The proof of payment: SHA256 of this **payment_hash** (**status** *complete* only).
+	PaymentPreimage string `json:"payment_preimage,omitempty"`
+	// Status of the payment.
+	Status string `json:"status"`
+}
+
+// Withdraw calls `withdraw`: Command for withdrawing funds from the internal wallet.
+func (ln *Client) Withdraw(ctx context.Context, req WithdrawRequest) (resp WithdrawResponse, err error) {
+	err = ln.CallTyped(ctx, "withdraw", req, &resp)
+	return
+}
+
+// WithdrawRequest are the parameters for `withdraw`.
+type WithdrawRequest struct {
+	// Any Bitcoin accepted type, including bech32.
+	Destination string `json:"destination"`
+	// The amount to be withdrawn from the internal wallet (expressed, as name suggests, in satoshi). The string *all* can be used to specify withdrawal of all available funds (but if we have any anchor channels, this will always leave at least `min-emergency-msat` as change). Otherwise, it is in satoshi precision, it can be a whole number, a whole number ending in *sat*, a whole number ending in *000msat*, or a number with 1 to 8 decimal places ending in *btc*.
+	Satoshi string `json:"satoshi"`
+	// Used for the withdrawal as initial feerate.
+	Feerate *string `json:"feerate,omitempty"`
+	// Minimum number of confirmations that used outputs should have.
+	Minconf *uint16 `json:"minconf,omitempty"`
+	// Specifies the utxos to be used to be withdrawn from, as an array of `txid:vout`. These must be drawn from the node's available UTXO set.
+	Utxos []string `json:"utxos,omitempty"`
+}
+
+// WithdrawResponse is the result of `withdraw`.
+type WithdrawResponse struct {
+	// The fully signed bitcoin transaction.
+	Tx string `json:"tx"`
+	// The transaction id of *tx*.
+	Txid string `json:"txid"`
+	// The PSBT representing the unsigned transaction.
+	Psbt string `json:"psbt"`
+}
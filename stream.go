@@ -0,0 +1,216 @@
+package lightning
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// CallStream makes a call and, instead of keeping the whole response in memory,
+// decodes the items of the array at field (like "channels" for listchannels or
+// "forwards" for listforwards) one by one and passes each to fn. Returning an
+// error from fn stops the call and CallStream returns that error.
+//
+// Since items can't be taken back the call is only retried if we couldn't
+// connect. With Interceptors, a Cassette or Compat the response is read
+// whole and then iterated, so everything still works, just without the savings.
+func (ln *Client) CallStream(
+	timeout time.Duration,
+	message JSONRPCMessage,
+	field string,
+	fn func(item gjson.Result) error,
+) error {
+	if len(ln.Interceptors) > 0 || ln.Cassette != nil || ln.Compat {
+		res, err := ln.CallMessage(timeout, message)
+		if err != nil {
+			return err
+		}
+		for _, item := range res.Get(field).Array() {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	start := time.Now()
+	err := ln.stream(timeout, message, strings.Split(field, "."), fn)
+	if ln.Metrics != nil {
+		ln.Metrics.observeCall(message.Method, time.Since(start), err)
+	}
+
+	var cberr callbackError
+	if errors.As(err, &cberr) {
+		return cberr.err
+	}
+	return err
+}
+
+// callbackError marks errors returned by the CallStream callback.
+type callbackError struct{ err error }
+
+func (c callbackError) Error() string { return c.err.Error() }
+
+func (ln *Client) stream(timeout time.Duration, message JSONRPCMessage, path []string, fn func(gjson.Result) error) error {
+	message.Id = "0"
+	message.OnNotification = nil
+	if message.Version == "" {
+		message.Version = version
+	}
+	if message.Params == nil {
+		message.Params = make([]string, 0)
+	}
+	mbytes, _ := encodeJSON(message)
+
+	// an empty method is never idempotent, so only failed dials are retried
+	retryAs := ""
+
+	if ln.Path != "" {
+		_, err := ln.withRetries(timeout, retryAs, func(timeout time.Duration) ([]byte, bool, error) {
+			err := ln.streamSocket(timeout, mbytes, path, fn)
+			_, dialFailed := err.(ErrorConnect)
+			return nil, !dialFailed, err
+		})
+		return err
+	} else if ln.SparkURL != "" {
+		_, err := ln.withRetries(timeout, retryAs, func(timeout time.Duration) ([]byte, bool, error) {
+			delivered, err := ln.streamSpark(timeout, mbytes, path, fn)
+			return nil, delivered, err
+		})
+		return err
+	} else {
+		return errors.New("misconfigured client: missing Path or SparkURL.")
+	}
+}
+
+func (ln *Client) streamSocket(timeout time.Duration, message []byte, path []string, fn func(gjson.Result) error) error {
+	conn, err := net.DialTimeout("unix", ln.Path, timeout)
+	if err != nil {
+		return ErrorConnect{ln.Path, err.Error()}
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	if _, err := conn.Write(message); err != nil {
+		return ErrorConnectionBroken{}
+	}
+
+	// the response envelope: {"jsonrpc": "2.0", "id": "0", "result": {...}}
+	decoder := json.NewDecoder(conn)
+	err = walkObject(decoder, func(key string) error {
+		switch key {
+		case "result":
+			return streamArray(decoder, path, fn)
+		case "error":
+			var rpcerr JSONRPCError
+			if err := decoder.Decode(&rpcerr); err != nil {
+				return err
+			}
+			if rpcerr.Code != 0 {
+				return ErrorCommand{rpcerr.Message, rpcerr.Code, rpcerr.Data}
+			}
+			return nil
+		default:
+			return skipValue(decoder)
+		}
+	})
+	return streamError(err, timeout)
+}
+
+func (ln *Client) streamSpark(timeout time.Duration, body []byte, path []string, fn func(gjson.Result) error) (delivered bool, err error) {
+	resp, delivered, err := ln.sparkRequest(timeout, body)
+	if err != nil {
+		return delivered, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return delivered, sparkError(resp)
+	}
+
+	// spark returns the result without the envelope
+	decoder := json.NewDecoder(resp.Body)
+	return delivered, streamError(streamArray(decoder, path, fn), timeout)
+}
+
+// streamArray reads a value and calls fn for each item of the array found at path.
+func streamArray(decoder *json.Decoder, path []string, fn func(gjson.Result) error) error {
+	if len(path) == 0 {
+		tok, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		if tok == nil {
+			return nil
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+			return errors.New("expected an array")
+		}
+		for decoder.More() {
+			var item json.RawMessage
+			if err := decoder.Decode(&item); err != nil {
+				return err
+			}
+			if err := fn(gjson.ParseBytes(item)); err != nil {
+				return callbackError{err}
+			}
+		}
+		_, err = decoder.Token()
+		return err
+	}
+
+	return walkObject(decoder, func(key string) error {
+		if key == path[0] {
+			return streamArray(decoder, path[1:], fn)
+		}
+		return skipValue(decoder)
+	})
+}
+
+// walkObject reads an object calling onKey for each key, which must consume the value.
+func walkObject(decoder *json.Decoder, onKey func(key string) error) error {
+	tok, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return errors.New("expected an object")
+	}
+	for decoder.More() {
+		tok, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := tok.(string)
+		if err := onKey(key); err != nil {
+			return err
+		}
+	}
+	_, err = decoder.Token()
+	return err
+}
+
+func skipValue(decoder *json.Decoder) error {
+	var v json.RawMessage
+	return decoder.Decode(&v)
+}
+
+// streamError turns decoding errors into the errors returned by other calls.
+func streamError(err error, timeout time.Duration) error {
+	switch err.(type) {
+	case nil, callbackError, ErrorCommand:
+		return err
+	}
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return ErrorConnectionBroken{}
+	}
+	if neterr, ok := err.(net.Error); ok && neterr.Timeout() {
+		return ErrorTimeout{int(timeout.Seconds())}
+	}
+	return ErrorJSONDecode{err.Error()}
+}
@@ -0,0 +1,66 @@
+package lightning_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	lightning "github.com/fiatjaf/lightningd-gjson-rpc"
+	"github.com/fiatjaf/lightningd-gjson-rpc/lightningtest"
+	"github.com/tidwall/gjson"
+)
+
+func TestCallStream(t *testing.T) {
+	rpc := lightningtest.NewServer(t)
+	rpc.Respond("listforwards", map[string]interface{}{
+		"before":   map[string]interface{}{"forwards": []interface{}{"wrong"}},
+		"forwards": []interface{}{map[string]interface{}{"id": 1}, map[string]interface{}{"id": 2}, map[string]interface{}{"id": 3}},
+	})
+	rpc.Respond("listpeers", map[string]interface{}{
+		"peers": map[string]interface{}{"list": []interface{}{"a", "b"}},
+	})
+	rpc.Fail("listchannels", -32602, "bad params")
+
+	message := func(method string) lightning.JSONRPCMessage {
+		return lightning.JSONRPCMessage{Method: method}
+	}
+
+	for name, ln := range map[string]*lightning.Client{"socket": rpc.Client(), "spark": rpc.SparkClient()} {
+		var ids []int64
+		err := ln.CallStream(time.Second*5, message("listforwards"), "forwards", func(item gjson.Result) error {
+			ids = append(ids, item.Get("id").Int())
+			return nil
+		})
+		if err != nil || len(ids) != 3 || ids[0] != 1 || ids[2] != 3 {
+			t.Errorf("%s: got %v, %v", name, ids, err)
+		}
+
+		var nested []string
+		err = ln.CallStream(time.Second*5, message("listpeers"), "peers.list", func(item gjson.Result) error {
+			nested = append(nested, item.String())
+			return nil
+		})
+		if err != nil || len(nested) != 2 || nested[1] != "b" {
+			t.Errorf("%s: nested field got %v, %v", name, nested, err)
+		}
+
+		stop := errors.New("stop")
+		count := 0
+		err = ln.CallStream(time.Second*5, message("listforwards"), "forwards", func(item gjson.Result) error {
+			count++
+			return stop
+		})
+		if err != stop || count != 1 {
+			t.Errorf("%s: callback error got %v after %d items", name, err, count)
+		}
+
+		err = ln.CallStream(time.Second*5, message("listchannels"), "channels", func(item gjson.Result) error {
+			t.Errorf("%s: callback called on a failed command", name)
+			return nil
+		})
+		var cmderr lightning.ErrorCommand
+		if !errors.As(err, &cmderr) || cmderr.Code != -32602 {
+			t.Errorf("%s: expected a command error, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package lightning
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+//go:generate go run ./cmd/schemagen -schemas testdata/schemas -out rpc_generated.go
+
+// CallTyped is what the generated methods use: req is encoded as named params
+// and the result is decoded into resp. The context deadline, if any, replaces
+// ln.CallTimeout.
+func (ln *Client) CallTyped(ctx context.Context, method string, req interface{}, resp interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	params := make(map[string]interface{})
+	if req != nil {
+		jreq, err := json.Marshal(req)
+		if err != nil {
+			return err
+		}
+		if err := decodeUsingNumber(jreq, &params); err != nil {
+			return err
+		}
+	}
+
+	bres, err := ln.CallMessageRaw(timeoutFromContext(ctx, ln.CallTimeout), JSONRPCMessage{
+		Version: version,
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp == nil {
+		return nil
+	}
+	if err := json.Unmarshal(bres, resp); err != nil {
+		return ErrorJSONDecode{err.Error()}
+	}
+	return nil
+}
+
+func timeoutFromContext(ctx context.Context, fallback time.Duration) time.Duration {
+	if deadline, ok := ctx.Deadline(); ok {
+		return time.Until(deadline)
+	}
+	if fallback == 0 {
+		return DefaultTimeout
+	}
+	return fallback
+}